@@ -7,6 +7,7 @@ import (
 	"os"
 	"os/signal"
 	"strconv"
+	"strings"
 	"syscall"
 	"time"
 
@@ -16,6 +17,7 @@ import (
 	"github.com/Hbrtjm/SWIFT_API/backend/internal/parser"
 	"github.com/Hbrtjm/SWIFT_API/backend/internal/service"
 	"github.com/Hbrtjm/SWIFT_API/backend/internal/util"
+	"github.com/Hbrtjm/SWIFT_API/backend/pkg/sanctions"
 )
 
 func main() {
@@ -60,7 +62,58 @@ func main() {
 	swiftFileParser := parser.NewSwiftFileParser()
 
 	// Update the service to use our new logger
-	swiftService := service.NewSwiftCodeService(repo, swiftFileParser, logger)
+	swiftService := service.NewSwiftCodeService(repo, swiftFileParser, logger, util.GetEnvOrDefault("VERSION", "v1"))
+
+	// Handling of the branches without a headquarter and of the headquarter deletes
+	integrityRules := service.IntegrityRules{
+		BranchWithoutHeadquarter: strings.ToLower(util.GetEnvOrDefault("INTEGRITY_BRANCH_WITHOUT_HEADQUARTER", service.BranchRuleWarn)),
+		HeadquarterDeleteMode:    strings.ToLower(util.GetEnvOrDefault("INTEGRITY_HEADQUARTER_DELETE_MODE", service.DeleteModeDetach)),
+	}
+	if err := swiftService.SetIntegrityRules(integrityRules); err != nil {
+		logger.Fatal("Invalid integrity rules: %v", err)
+	}
+
+	// Bank code mappings are upserted, so the file can be loaded on every start
+	if bankCodesFile := util.GetEnvOrDefault("BANK_CODES_FILE", ""); bankCodesFile != "" {
+		if err := swiftService.LoadBankCodes(bankCodesFile); err != nil {
+			logger.Error("Error loading bank codes: %v", err)
+		}
+	}
+
+	// Clearing codes are upserted as well
+	if clearingCodesFile := util.GetEnvOrDefault("CLEARING_CODES_FILE", ""); clearingCodesFile != "" {
+		if err := swiftService.LoadClearingCodes(clearingCodesFile); err != nil {
+			logger.Error("Error loading clearing codes: %v", err)
+		}
+	}
+
+	// GLEIF BIC-to-LEI relationship file
+	if leiMappingFile := util.GetEnvOrDefault("LEI_MAPPING_FILE", ""); leiMappingFile != "" {
+		if err := swiftService.LoadLEIMappings(leiMappingFile); err != nil {
+			logger.Error("Error loading LEI mappings: %v", err)
+		}
+	}
+
+	// Correspondent relationships are upserted as well
+	if correspondentsFile := util.GetEnvOrDefault("CORRESPONDENTS_FILE", ""); correspondentsFile != "" {
+		if err := swiftService.LoadCorrespondents(correspondentsFile); err != nil {
+			logger.Error("Error loading correspondents: %v", err)
+		}
+	}
+
+	// Participants of the payment schemes, every CSV file of the directory is upserted
+	if schemeParticipantsDir := util.GetEnvOrDefault("SCHEME_PARTICIPANTS_DIR", ""); schemeParticipantsDir != "" {
+		if err := swiftService.LoadSchemeParticipants(schemeParticipantsDir); err != nil {
+			logger.Error("Error loading scheme participants: %v", err)
+		}
+	}
+
+	// Holiday calendars from the directory replace the embedded ones with the same id
+	if calendarsDir := util.GetEnvOrDefault("HOLIDAY_CALENDARS_DIR", ""); calendarsDir != "" {
+		if err := swiftService.LoadHolidayCalendars(calendarsDir); err != nil {
+			logger.Error("Error loading holiday calendars: %v", err)
+		}
+	}
 
 	// Load initial data if needed
 	if util.GetEnvOrDefault("LOAD_INITIAL_DATA", "false") == "true" {
@@ -78,6 +131,31 @@ func main() {
 		}
 	}
 
+	// Status changes with a future effective date are applied in the background
+	schedulerInterval, err := time.ParseDuration(util.GetEnvOrDefault("STATUS_SCHEDULER_INTERVAL", "1m"))
+	if err != nil || schedulerInterval <= 0 {
+		logger.Fatal("Invalid STATUS_SCHEDULER_INTERVAL: %v", err)
+	}
+	schedulerCtx, stopScheduler := context.WithCancel(context.Background())
+	defer stopScheduler()
+	go swiftService.RunStatusScheduler(schedulerCtx, schedulerInterval)
+
+	// Sanctions lists are imported in the background and imported again whenever the files change
+	sanctionsFiles := map[string]string{}
+	if ofacFile := util.GetEnvOrDefault("SANCTIONS_OFAC_SDN_FILE", ""); ofacFile != "" {
+		sanctionsFiles[sanctions.ListOFAC] = ofacFile
+	}
+	if euFile := util.GetEnvOrDefault("SANCTIONS_EU_FILE", ""); euFile != "" {
+		sanctionsFiles[sanctions.ListEU] = euFile
+	}
+	if len(sanctionsFiles) > 0 {
+		watchInterval, err := time.ParseDuration(util.GetEnvOrDefault("SANCTIONS_WATCH_INTERVAL", "5m"))
+		if err != nil || watchInterval <= 0 {
+			logger.Fatal("Invalid SANCTIONS_WATCH_INTERVAL: %v", err)
+		}
+		go swiftService.RunSanctionsWatcher(schedulerCtx, watchInterval, sanctionsFiles)
+	}
+
 	// Initialize the router with service and add our middleware
 	router := api.NewRouter(swiftService, logger)
 
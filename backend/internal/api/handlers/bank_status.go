@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/Hbrtjm/SWIFT_API/backend/internal/service"
+	"github.com/gorilla/mux"
+)
+
+// GetBankStatus handles GET request for the lifecycle status of a SWIFT code
+func (rh *RequestsHandler) GetBankStatus(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	swiftCode := vars["swiftCode"]
+
+	rh.logger.Debug("Getting status of SWIFT code: %s", swiftCode)
+
+	response, err := rh.service.GetBankStatus(swiftCode)
+
+	w.Header().Set("Content-Type", "application/json")
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		errResponse := map[string]string{"message": "Unable to get the status"}
+		if errors.Is(err, service.ErrBankNotFound) {
+			statusCode = http.StatusNotFound
+			errResponse["message"] = service.ErrBankNotFound.Error()
+		}
+		if IsAPIDebugActive() {
+			errResponse["message"] = err.Error()
+		}
+		w.WriteHeader(statusCode)
+		rh.logger.Error("Error fetching status: %v", err)
+		json.NewEncoder(w).Encode(errResponse)
+		return
+	}
+
+	setSuccessorLink(w, response.Links.Successor)
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
+
+// PutBankStatus handles PUT request changing the lifecycle status of a SWIFT code. A change with a future
+// effective date is scheduled and answered with 202 Accepted
+func (rh *RequestsHandler) PutBankStatus(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	swiftCode := vars["swiftCode"]
+
+	w.Header().Set("Content-Type", "application/json")
+
+	var request service.BankStatusRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		rh.logger.Error("Error decoding status request: %v", err)
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{"message": "Invalid request body"})
+		return
+	}
+
+	response, scheduled, err := rh.service.SetBankStatus(swiftCode, request, time.Now())
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		errResponse := map[string]string{"message": "Unable to change the status"}
+		switch {
+		case errors.Is(err, service.ErrBankNotFound):
+			statusCode = http.StatusNotFound
+			errResponse["message"] = service.ErrBankNotFound.Error()
+		case errors.Is(err, service.ErrInvalidBankStatus),
+			errors.Is(err, service.ErrInvalidEffectiveDate),
+			errors.Is(err, service.ErrInvalidSuccessorSwift):
+			// The client has to know which value to correct
+			statusCode = http.StatusBadRequest
+			errResponse["message"] = err.Error()
+		}
+		if IsAPIDebugActive() {
+			errResponse["message"] = err.Error()
+		}
+		w.WriteHeader(statusCode)
+		rh.logger.Error("Error changing status: %v", err)
+		json.NewEncoder(w).Encode(errResponse)
+		return
+	}
+
+	setSuccessorLink(w, response.Links.Successor)
+	if scheduled {
+		w.WriteHeader(http.StatusAccepted)
+	} else {
+		w.WriteHeader(http.StatusOK)
+	}
+	json.NewEncoder(w).Encode(response)
+}
+
+// setSuccessorLink points a retired or merged code to its successor (RFC 8288)
+func setSuccessorLink(w http.ResponseWriter, successorPath string) {
+	if successorPath != "" {
+		w.Header().Set("Link", "<"+successorPath+`>; rel="successor-version"`)
+	}
+}
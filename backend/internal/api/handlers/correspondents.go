@@ -0,0 +1,135 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/Hbrtjm/SWIFT_API/backend/internal/db/models"
+	"github.com/Hbrtjm/SWIFT_API/backend/internal/service"
+	"github.com/gorilla/mux"
+)
+
+// GetCorrespondents handles GET request for the correspondent relationships of a SWIFT code, optionally in a single currency
+func (rh *RequestsHandler) GetCorrespondents(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	swiftCode := vars["swiftCode"]
+	currency := r.URL.Query().Get("currency")
+
+	rh.logger.Debug("Getting correspondents of SWIFT code: %s, currency: %s", swiftCode, currency)
+
+	response, err := rh.service.GetCorrespondents(swiftCode, currency)
+
+	w.Header().Set("Content-Type", "application/json")
+	if err != nil {
+		rh.writeCorrespondentError(w, err, "Unable to list the correspondents")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
+
+// PostCorrespondent handles POST request adding a correspondent relationship between two SWIFT codes of the database
+func (rh *RequestsHandler) PostCorrespondent(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	var request models.Correspondent
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		rh.logger.Error("Error decoding correspondent request: %v", err)
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{"message": "Invalid request body"})
+		return
+	}
+
+	correspondent, err := rh.service.AddCorrespondent(request)
+	if err != nil {
+		rh.writeCorrespondentError(w, err, "Error while adding the correspondent")
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(correspondent)
+}
+
+// DeleteCorrespondent handles DELETE request for a correspondent relationship
+func (rh *RequestsHandler) DeleteCorrespondent(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	err := rh.service.DeleteCorrespondent(models.Correspondent{
+		SwiftCode:              vars["swiftCode"],
+		CorrespondentSwiftCode: vars["correspondentSwiftCode"],
+		Currency:               vars["currency"],
+	})
+
+	w.Header().Set("Content-Type", "application/json")
+	if err != nil {
+		rh.writeCorrespondentError(w, err, "Failed to delete the correspondent")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]string{"message": "Correspondent deleted successfully"})
+}
+
+// GetRoutes handles GET request for the shortest correspondent chains between two SWIFT codes in a currency
+func (rh *RequestsHandler) GetRoutes(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	w.Header().Set("Content-Type", "application/json")
+
+	if query.Get("from") == "" || query.Get("to") == "" || query.Get("currency") == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{"message": "from, to and currency are required"})
+		return
+	}
+
+	maxHops := 0
+	if value := query.Get("maxHops"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"message": "maxHops must be a number"})
+			return
+		}
+		maxHops = parsed
+	}
+
+	rh.logger.Debug("Finding routes from %s to %s in %s", query.Get("from"), query.Get("to"), query.Get("currency"))
+
+	response, err := rh.service.FindRoutes(query.Get("from"), query.Get("to"), query.Get("currency"), maxHops)
+	if err != nil {
+		rh.writeCorrespondentError(w, err, "Unable to find the routes")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
+
+// writeCorrespondentError maps the errors of the correspondent operations to the status codes
+func (rh *RequestsHandler) writeCorrespondentError(w http.ResponseWriter, err error, message string) {
+	status := http.StatusInternalServerError
+	errResponse := map[string]string{"message": message}
+	switch {
+	case errors.Is(err, service.ErrBankNotFound):
+		status = http.StatusNotFound
+		errResponse["message"] = service.ErrBankNotFound.Error()
+	case errors.Is(err, service.ErrCorrespondentNotFound):
+		status = http.StatusNotFound
+		errResponse["message"] = service.ErrCorrespondentNotFound.Error()
+	case errors.Is(err, service.ErrInvalidCurrency),
+		errors.Is(err, service.ErrInvalidSwiftCode),
+		errors.Is(err, service.ErrInvalidCorrespondent),
+		errors.Is(err, service.ErrInvalidMaxHops):
+		status = http.StatusBadRequest
+		errResponse["message"] = err.Error()
+	}
+	if IsAPIDebugActive() {
+		errResponse["message"] = err.Error()
+	}
+	w.WriteHeader(status)
+	rh.logger.Error("%s: %v", message, err)
+	json.NewEncoder(w).Encode(errResponse)
+}
@@ -2,31 +2,51 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 
+	"github.com/Hbrtjm/SWIFT_API/backend/internal/service"
 	"github.com/gorilla/mux"
 )
 
-// Handles DELETE request for a SWIFT code
+// Handles DELETE request for a SWIFT code, the optional mode (reject, cascade or detach) overrides
+// the configured handling of the headquarter branches
 func (rh *RequestsHandler) DeleteSwiftCode(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	swiftCode := vars["swiftCode"]
+	mode := r.URL.Query().Get("mode")
 
-	err := rh.service.DeleteSwiftCode(swiftCode)
+	deletedBranches, err := rh.service.DeleteSwiftCodeWithMode(swiftCode, mode)
 
 	w.Header().Set("Content-Type", "application/json")
 
 	if err != nil {
+		statusCode := http.StatusInternalServerError
 		errResponse := map[string]string{"message": "Failed to delete SWIFT code"}
+		switch {
+		case errors.Is(err, service.ErrBankNotFound):
+			statusCode = http.StatusNotFound
+			errResponse["message"] = "SWIFT code not found"
+		case errors.Is(err, service.ErrIntegrityViolation):
+			statusCode = http.StatusConflict
+		case errors.Is(err, service.ErrInvalidDeleteMode), errors.Is(err, service.ErrInvalidSwiftCode):
+			statusCode = http.StatusBadRequest
+		}
+
 		if IsAPIDebugActive() {
 			errResponse["message"] = err.Error()
 		}
-		w.WriteHeader(http.StatusNotFound)
+		w.WriteHeader(statusCode)
 		rh.logger.Error("Failed to delete SWIFT code:  %v", err)
 		json.NewEncoder(w).Encode(errResponse)
 		return
 	}
 
+	response := map[string]interface{}{"message": "SWIFT code deleted successfully"}
+	if deletedBranches > 0 {
+		response["deletedBranches"] = deletedBranches
+	}
+
 	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(map[string]string{"message": "SWIFT code deleted successfully"})
+	json.NewEncoder(w).Encode(response)
 }
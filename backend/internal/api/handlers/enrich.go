@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/Hbrtjm/SWIFT_API/backend/internal/service"
+)
+
+// Enrich handles POST request with a CSV of BICs, sent as the body or as the file field of a multipart form, and
+// streams it back with the bank details appended to every row
+func (rh *RequestsHandler) Enrich(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	file, err := uploadReader(w, r)
+	if err != nil {
+		rh.logger.Error("Invalid upload: %v", err)
+		w.WriteHeader(uploadErrorStatus(err))
+		json.NewEncoder(w).Encode(map[string]string{"message": "Invalid upload"})
+		return
+	}
+
+	options := service.EnrichOptions{
+		Column:    r.URL.Query().Get("column"),
+		Delimiter: r.URL.Query().Get("delimiter"),
+	}
+	stream := &csvStream{ResponseWriter: w, controller: http.NewResponseController(w)}
+	err = rh.service.EnrichCSV(file, stream, options)
+	if err != nil && stream.started {
+		// The status is already sent, the client gets a truncated file
+		rh.logger.Error("Error enriching the CSV after the first rows: %v", err)
+		return
+	}
+	if err != nil {
+		status := http.StatusInternalServerError
+		errResponse := map[string]string{"message": "Unable to enrich the file"}
+		switch {
+		case uploadErrorStatus(err) == http.StatusRequestEntityTooLarge:
+			status = http.StatusRequestEntityTooLarge
+			errResponse["message"] = "File is too large"
+		case errors.Is(err, service.ErrInvalidDelimiter):
+			status = http.StatusBadRequest
+			errResponse["message"] = "Invalid delimiter"
+		case errors.Is(err, service.ErrColumnNotFound):
+			status = http.StatusBadRequest
+			errResponse["message"] = "BIC column not found"
+		case errors.Is(err, service.ErrMalformedCSV):
+			status = http.StatusBadRequest
+			errResponse["message"] = "Malformed CSV"
+		}
+		if IsAPIDebugActive() {
+			errResponse["message"] = err.Error()
+		}
+		w.WriteHeader(status)
+		rh.logger.Error("Error enriching the CSV: %v", err)
+		json.NewEncoder(w).Encode(errResponse)
+	}
+}
+
+// csvStream sends the status and the CSV headers with the first rows, so that the errors found before can still be
+// answered with JSON, and flushes every write to the client
+type csvStream struct {
+	http.ResponseWriter
+	controller *http.ResponseController
+	started    bool
+}
+
+func (s *csvStream) Write(b []byte) (int, error) {
+	if !s.started {
+		s.started = true
+		s.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		s.Header().Set("Content-Disposition", `attachment; filename="enriched.csv"`)
+		s.ResponseWriter.WriteHeader(http.StatusOK)
+	}
+	n, err := s.ResponseWriter.Write(b)
+	if err != nil {
+		return n, err
+	}
+	// Writers which can't flush still get the whole file at the end
+	s.controller.Flush()
+	return n, nil
+}
@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/Hbrtjm/SWIFT_API/backend/internal/service"
+	"github.com/Hbrtjm/SWIFT_API/backend/pkg/clearing"
+	"github.com/gorilla/mux"
+)
+
+// GetByClearingCode handles GET request for the bank of a national clearing system member id
+func (rh *RequestsHandler) GetByClearingCode(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	system := vars["system"]
+	memberID := vars["id"]
+
+	rh.logger.Debug("Getting by clearing code: %s %s", system, memberID)
+
+	response, err := rh.service.GetByClearingCode(system, memberID)
+
+	w.Header().Set("Content-Type", "application/json")
+	if err != nil {
+		status := http.StatusInternalServerError
+		errResponse := map[string]string{"message": "Unable to find the clearing code"}
+		switch {
+		case errors.Is(err, service.ErrClearingCodeNotFound):
+			status = http.StatusNotFound
+			errResponse["message"] = "Clearing code not found"
+		case errors.Is(err, clearing.ErrUnknownSystem):
+			status = http.StatusBadRequest
+			errResponse["message"] = "Unknown clearing system"
+		case errors.Is(err, clearing.ErrInvalidFormat), errors.Is(err, clearing.ErrInvalidCheck):
+			status = http.StatusBadRequest
+			errResponse["message"] = "Invalid clearing code"
+		}
+		if IsAPIDebugActive() {
+			errResponse["message"] = err.Error()
+		}
+		w.WriteHeader(status)
+		rh.logger.Error("Error fetching clearing code: %v", err)
+		json.NewEncoder(w).Encode(errResponse)
+		return
+	}
+
+	localizeSwiftCodeResponse(r, response.SwiftEntry)
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
@@ -0,0 +1,67 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/Hbrtjm/SWIFT_API/backend/pkg/countryinfo"
+	"github.com/gorilla/mux"
+)
+
+// GetCountries handles GET request for the reference data of the countries, optionally limited to a region group
+func (rh *RequestsHandler) GetCountries(w http.ResponseWriter, r *http.Request) {
+	region := r.URL.Query().Get("region")
+
+	rh.logger.Debug("Listing countries, region: %s", region)
+
+	countries, err := rh.service.GetCountries(region)
+
+	w.Header().Set("Content-Type", "application/json")
+	if err != nil {
+		errResponse := map[string]string{"message": "Unable to list the countries"}
+		if errors.Is(err, countryinfo.ErrUnknownRegion) {
+			errResponse["message"] = "Unknown region"
+		}
+		if IsAPIDebugActive() {
+			errResponse["message"] = err.Error()
+		}
+		w.WriteHeader(http.StatusBadRequest)
+		rh.logger.Error("Error listing countries: %v", err)
+		json.NewEncoder(w).Encode(errResponse)
+		return
+	}
+
+	for i, country := range countries {
+		countries[i].DisplayName = localizedCountryName(r, country.CountryISO2, country.DisplayName)
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{"count": len(countries), "countries": countries})
+}
+
+// GetCountry handles GET request for the reference data of a country
+func (rh *RequestsHandler) GetCountry(w http.ResponseWriter, r *http.Request) {
+	countryISO2 := mux.Vars(r)["countryISO2code"]
+
+	rh.logger.Debug("Getting country: %s", countryISO2)
+
+	response, err := rh.service.GetCountryInfo(countryISO2)
+
+	w.Header().Set("Content-Type", "application/json")
+	if err != nil {
+		errResponse := map[string]string{"message": "Country code not found"}
+		if IsAPIDebugActive() {
+			errResponse["message"] = err.Error()
+		}
+		w.WriteHeader(http.StatusNotFound)
+		rh.logger.Error("Error fetching country: %v", err)
+		json.NewEncoder(w).Encode(errResponse)
+		return
+	}
+
+	response.DisplayName = localizedCountryName(r, response.CountryISO2, response.DisplayName)
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
@@ -2,8 +2,13 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
+	"strconv"
 
+	"github.com/Hbrtjm/SWIFT_API/backend/internal/service"
+	"github.com/Hbrtjm/SWIFT_API/backend/pkg/countryinfo"
+	"github.com/Hbrtjm/SWIFT_API/backend/pkg/schemes"
 	"github.com/gorilla/mux"
 )
 
@@ -20,21 +25,87 @@ func (rh *RequestsHandler) GetBySwiftCodesByCountry(w http.ResponseWriter, r *ht
 
 	rh.logger.Info("Getting SWIFT codes for country: %s", countryISO2)
 
-	response, err := rh.service.GetBySwiftCodesByCountry(countryISO2)
+	// Optional filters of the listing
+	filter := service.BankFilter{Scheme: r.URL.Query().Get("scheme"), Region: r.URL.Query().Get("region")}
+
+	response, err := rh.service.GetBySwiftCodesByCountryFiltered(countryISO2, filter)
 
 	w.Header().Set("Content-Type", "application/json")
 
 	if err != nil {
+		status := http.StatusNotFound
 		errResponse := map[string]string{"message": "Country code not found"}
+		if errors.Is(err, schemes.ErrUnknownScheme) {
+			status = http.StatusBadRequest
+			errResponse["message"] = "Unknown payment scheme"
+		} else if errors.Is(err, countryinfo.ErrUnknownRegion) {
+			status = http.StatusBadRequest
+			errResponse["message"] = "Unknown region"
+		}
 		if IsAPIDebugActive() {
 			errResponse["message"] = err.Error()
 		}
-		w.WriteHeader(http.StatusNotFound)
+		w.WriteHeader(status)
 		rh.logger.Error("Error fetching country code: %v", err)
 		json.NewEncoder(w).Encode(errResponse)
 		return
 	}
 
+	displayName, _ := response["countryDisplayName"].(string)
+	response["countryDisplayName"] = localizedCountryName(r, countryISO2, displayName)
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
+
+// GetBySwiftCodesByRegion handles GET request for a page of the SWIFT codes of the countries of a region group, with
+// the optional scheme, limit and cursor parameters
+func (rh *RequestsHandler) GetBySwiftCodesByRegion(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	region := vars["region"]
+	query := r.URL.Query()
+
+	w.Header().Set("Content-Type", "application/json")
+
+	limit := 0
+	if value := query.Get("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"message": "Limit must be a number"})
+			return
+		}
+		limit = parsed
+	}
+
+	rh.logger.Info("Getting SWIFT codes for region: %s", region)
+
+	filter := service.BankFilter{Scheme: query.Get("scheme")}
+	response, err := rh.service.GetBySwiftCodesByRegion(region, filter, limit, query.Get("cursor"))
+	if err != nil {
+		status := http.StatusBadRequest
+		errResponse := map[string]string{"message": "Error fetching SWIFT codes"}
+		switch {
+		case errors.Is(err, countryinfo.ErrUnknownRegion):
+			errResponse["message"] = "Unknown region"
+		case errors.Is(err, schemes.ErrUnknownScheme):
+			errResponse["message"] = "Unknown payment scheme"
+		case errors.Is(err, service.ErrInvalidLimit):
+			errResponse["message"] = "Invalid limit"
+		case errors.Is(err, service.ErrInvalidCursor):
+			errResponse["message"] = "Invalid cursor"
+		default:
+			status = http.StatusInternalServerError
+		}
+		if IsAPIDebugActive() {
+			errResponse["message"] = err.Error()
+		}
+		w.WriteHeader(status)
+		rh.logger.Error("Error fetching region SWIFT codes: %v", err)
+		json.NewEncoder(w).Encode(errResponse)
+		return
+	}
+
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(response)
 }
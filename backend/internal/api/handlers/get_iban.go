@@ -0,0 +1,35 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// GetIBAN handles GET request for the validation of an IBAN and the SWIFT entry of its bank
+func (rh *RequestsHandler) GetIBAN(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	value := vars["iban"]
+
+	rh.logger.Debug("Looking up IBAN: %s", value)
+
+	response, err := rh.service.GetIBANDetails(value)
+
+	w.Header().Set("Content-Type", "application/json")
+	if err != nil {
+		errResponse := map[string]string{"message": "Error looking up the IBAN"}
+		if IsAPIDebugActive() {
+			errResponse["message"] = err.Error()
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+		rh.logger.Error("Error looking up IBAN: %v", err)
+		json.NewEncoder(w).Encode(errResponse)
+		return
+	}
+
+	localizeSwiftCodeResponse(r, response.SwiftEntry)
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/Hbrtjm/SWIFT_API/backend/internal/service"
+	"github.com/Hbrtjm/SWIFT_API/backend/pkg/countryinfo"
+	"github.com/Hbrtjm/SWIFT_API/backend/pkg/schemes"
+	"github.com/gorilla/mux"
+)
+
+// GetInstitution handles GET request for every SWIFT code of an institution across the countries
+func (rh *RequestsHandler) GetInstitution(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	institutionCode := vars["bankCode"]
+
+	rh.logger.Debug("Getting institution: %s", institutionCode)
+
+	filter := service.BankFilter{Scheme: r.URL.Query().Get("scheme"), Region: r.URL.Query().Get("region")}
+
+	response, err := rh.service.GetInstitutionFiltered(institutionCode, filter)
+
+	w.Header().Set("Content-Type", "application/json")
+	if err != nil {
+		status := http.StatusNotFound
+		errResponse := map[string]string{"message": "Institution not found"}
+		if errors.Is(err, service.ErrInvalidInstitutionCode) {
+			status = http.StatusBadRequest
+			errResponse["message"] = "Invalid institution code"
+		} else if errors.Is(err, schemes.ErrUnknownScheme) {
+			status = http.StatusBadRequest
+			errResponse["message"] = "Unknown payment scheme"
+		} else if errors.Is(err, countryinfo.ErrUnknownRegion) {
+			status = http.StatusBadRequest
+			errResponse["message"] = "Unknown region"
+		}
+		if IsAPIDebugActive() {
+			errResponse["message"] = err.Error()
+		}
+		w.WriteHeader(status)
+		rh.logger.Error("Error fetching institution: %v", err)
+		json.NewEncoder(w).Encode(errResponse)
+		return
+	}
+
+	for i, country := range response.Countries {
+		response.Countries[i].CountryDisplayName = localizedCountryName(r, country.CountryISO2, country.CountryDisplayName)
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
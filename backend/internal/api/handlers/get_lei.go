@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/Hbrtjm/SWIFT_API/backend/pkg/lei"
+	"github.com/gorilla/mux"
+)
+
+// GetSwiftCodesByLEI handles GET request for the SWIFT codes of a legal entity
+func (rh *RequestsHandler) GetSwiftCodesByLEI(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	value := vars["lei"]
+
+	rh.logger.Debug("Getting SWIFT codes of LEI: %s", value)
+
+	response, err := rh.service.GetSwiftCodesByLEI(value)
+
+	w.Header().Set("Content-Type", "application/json")
+	if err != nil {
+		status := http.StatusNotFound
+		errResponse := map[string]string{"message": "LEI not found"}
+		if errors.Is(err, lei.ErrInvalidLength) || errors.Is(err, lei.ErrIllegalCharacter) || errors.Is(err, lei.ErrInvalidChecksum) {
+			status = http.StatusBadRequest
+			errResponse["message"] = "Invalid LEI"
+		}
+		if IsAPIDebugActive() {
+			errResponse["message"] = err.Error()
+		}
+		w.WriteHeader(status)
+		rh.logger.Error("Error fetching SWIFT codes of LEI: %v", err)
+		json.NewEncoder(w).Encode(errResponse)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
+
+// GetMissingLEIReport handles GET request for the SWIFT codes without a LEI, optionally limited to a country
+func (rh *RequestsHandler) GetMissingLEIReport(w http.ResponseWriter, r *http.Request) {
+	countryISO2 := r.URL.Query().Get("countryISO2")
+
+	rh.logger.Debug("Reporting SWIFT codes without LEI, country: %s", countryISO2)
+
+	response, err := rh.service.GetMissingLEIReport(countryISO2)
+
+	w.Header().Set("Content-Type", "application/json")
+	if err != nil {
+		errResponse := map[string]string{"message": "Unable to create the report"}
+		if IsAPIDebugActive() {
+			errResponse["message"] = err.Error()
+		}
+		w.WriteHeader(http.StatusBadRequest)
+		rh.logger.Error("Error reporting SWIFT codes without LEI: %v", err)
+		json.NewEncoder(w).Encode(errResponse)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
@@ -0,0 +1,31 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// GetOrphanReport handles GET request for the branches without a headquarter and the mismatched bank names,
+// optionally limited to a country
+func (rh *RequestsHandler) GetOrphanReport(w http.ResponseWriter, r *http.Request) {
+	countryISO2 := r.URL.Query().Get("countryISO2")
+
+	rh.logger.Debug("Reporting orphaned branches, country: %s", countryISO2)
+
+	response, err := rh.service.GetOrphanReport(countryISO2)
+
+	w.Header().Set("Content-Type", "application/json")
+	if err != nil {
+		errResponse := map[string]string{"message": "Unable to create the report"}
+		if IsAPIDebugActive() {
+			errResponse["message"] = err.Error()
+		}
+		w.WriteHeader(http.StatusBadRequest)
+		rh.logger.Error("Error reporting orphaned branches: %v", err)
+		json.NewEncoder(w).Encode(errResponse)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
@@ -0,0 +1,15 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/Hbrtjm/SWIFT_API/backend/pkg/schemes"
+)
+
+// GetSchemes handles GET request for the payment schemes accepted by the scheme filters
+func (rh *RequestsHandler) GetSchemes(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{"schemes": schemes.All()})
+}
@@ -2,8 +2,10 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 
+	"github.com/Hbrtjm/SWIFT_API/backend/internal/service"
 	"github.com/gorilla/mux"
 )
 
@@ -21,22 +23,63 @@ func (rh *RequestsHandler) GetBySwiftCode(w http.ResponseWriter, r *http.Request
 		return
 	}
 
+	// The entry can be rendered as an ISO 20022 block instead of the directory format
+	switch format := r.URL.Query().Get("format"); format {
+	case "":
+	case ISO20022Format:
+		rh.getFinancialInstitution(w, r, swiftCode)
+		return
+	default:
+		rh.logger.Error("Unsupported format: %s", format)
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{"message": "Unsupported format"})
+		return
+	}
+
 	rh.logger.Debug("Getting by SWIFT code: %s", swiftCode)
 
 	response, err := rh.service.GetBySwiftCode(swiftCode)
 
 	w.Header().Set("Content-Type", "application/json")
 	if err != nil {
-		errResponse := map[string]string{"message": "Country code not found"}
+		status := http.StatusInternalServerError
+		errResponse := map[string]interface{}{"message": "Unable to find the SWIFT code"}
+		// Only a missing code gets the suggestions, the other errors have nothing to do with the code
+		if errors.Is(err, service.ErrBankNotFound) {
+			status = http.StatusNotFound
+			errResponse["message"] = "SWIFT code not found"
+			errResponse["suggestions"] = rh.suggestions(swiftCode)
+		}
 		if IsAPIDebugActive() {
 			errResponse["message"] = err.Error()
 		}
-		w.WriteHeader(http.StatusNotFound)
+		w.WriteHeader(status)
 		rh.logger.Error("Error fetching SWIFT code: %v", err)
 		json.NewEncoder(w).Encode(errResponse)
 		return
 	}
 
+	localizeSwiftCodeResponse(r, response)
+
+	// Retired and merged codes are returned with a link to the successor, or redirected to it when requested
+	setSuccessorLink(w, response.Links.Successor)
+	if response.Links.Successor != "" && r.URL.Query().Get("redirect") == "true" {
+		w.Header().Set("Location", response.Links.Successor)
+		w.WriteHeader(http.StatusMovedPermanently)
+		json.NewEncoder(w).Encode(response)
+		return
+	}
+
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(response)
 }
+
+// suggestions lists the codes the missing code was likely meant to be, the 404 is still sent when they can't be found
+func (rh *RequestsHandler) suggestions(swiftCode string) []service.SwiftCodeSuggestion {
+	suggestions, err := rh.service.SuggestSwiftCodes(swiftCode)
+	if err != nil {
+		rh.logger.Error("Error suggesting SWIFT codes: %v", err)
+		return []service.SwiftCodeSuggestion{}
+	}
+	return suggestions
+}
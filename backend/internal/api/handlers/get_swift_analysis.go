@@ -0,0 +1,33 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// GetSwiftCodeAnalysis handles GET request for the structural analysis of a SWIFT code
+func (rh *RequestsHandler) GetSwiftCodeAnalysis(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	swiftCode := vars["swiftCode"]
+
+	rh.logger.Debug("Analyzing SWIFT code: %s", swiftCode)
+
+	response, err := rh.service.AnalyzeSwiftCode(swiftCode)
+
+	w.Header().Set("Content-Type", "application/json")
+	if err != nil {
+		errResponse := map[string]string{"message": "Invalid SWIFT code"}
+		if IsAPIDebugActive() {
+			errResponse["message"] = err.Error()
+		}
+		w.WriteHeader(http.StatusBadRequest)
+		rh.logger.Error("Error analyzing SWIFT code: %v", err)
+		json.NewEncoder(w).Encode(errResponse)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
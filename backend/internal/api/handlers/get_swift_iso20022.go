@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/Hbrtjm/SWIFT_API/backend/internal/service"
+	"github.com/Hbrtjm/SWIFT_API/backend/pkg/clearing"
+	"github.com/Hbrtjm/SWIFT_API/backend/pkg/iso20022"
+)
+
+// ISO20022Format is the value of the format parameter selecting the ISO 20022 FinInstnId output
+const ISO20022Format = "iso20022"
+
+// getFinancialInstitution writes the bank as the ISO 20022 FinInstnId block, in XML when the Accept header prefers it
+// and in JSON otherwise. The errors are written in JSON, as in the other endpoints
+func (rh *RequestsHandler) getFinancialInstitution(w http.ResponseWriter, r *http.Request, swiftCode string) {
+	rh.logger.Debug("Getting the ISO 20022 identification of SWIFT code: %s", swiftCode)
+
+	result, err := rh.service.GetFinancialInstitution(swiftCode, r.URL.Query().Get("clearingSystem"))
+	if err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		status := http.StatusInternalServerError
+		errResponse := map[string]string{"message": "Unable to render the SWIFT code"}
+		switch {
+		case errors.Is(err, service.ErrBankNotFound):
+			status = http.StatusNotFound
+			errResponse["message"] = "SWIFT code not found"
+		case errors.Is(err, clearing.ErrUnknownSystem):
+			status = http.StatusBadRequest
+			errResponse["message"] = "Unknown clearing system"
+		case errors.Is(err, iso20022.ErrInvalidDocument):
+			status = http.StatusInternalServerError
+			errResponse["message"] = "Entry cannot be rendered as ISO 20022"
+		}
+		if IsAPIDebugActive() {
+			errResponse["message"] = err.Error()
+		}
+		w.WriteHeader(status)
+		rh.logger.Error("Error rendering SWIFT code: %v", err)
+		json.NewEncoder(w).Encode(errResponse)
+		return
+	}
+
+	w.Header().Add("Vary", "Accept")
+	if prefersXML(r.Header.Get("Accept")) {
+		w.Header().Set("Content-Type", "application/xml")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(xml.Header))
+		xml.NewEncoder(w).Encode(result)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(result)
+}
+
+// prefersXML checks whether the Accept header gives XML a higher quality than JSON, JSON is kept on a tie
+func prefersXML(accept string) bool {
+	xmlQuality, jsonQuality := -1.0, -1.0
+	for _, mediaRange := range strings.Split(accept, ",") {
+		parts := strings.Split(mediaRange, ";")
+		mediaType := strings.ToLower(strings.TrimSpace(parts[0]))
+		quality := 1.0
+		for _, parameter := range parts[1:] {
+			if value, ok := strings.CutPrefix(strings.TrimSpace(parameter), "q="); ok {
+				if parsed, err := strconv.ParseFloat(value, 64); err == nil {
+					quality = parsed
+				}
+			}
+		}
+
+		switch mediaType {
+		case "application/xml", "text/xml":
+			if quality > xmlQuality {
+				xmlQuality = quality
+			}
+		case "application/json":
+			if quality > jsonQuality {
+				jsonQuality = quality
+			}
+		}
+	}
+	return xmlQuality > 0 && xmlQuality > jsonQuality
+}
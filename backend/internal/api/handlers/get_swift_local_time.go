@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/Hbrtjm/SWIFT_API/backend/internal/service"
+	"github.com/gorilla/mux"
+)
+
+// GetSwiftCodeLocalTime handles GET request for the local time and the business day status of a bank.
+// The optional "at" query parameter (RFC 3339) evaluates another moment than the current one
+func (rh *RequestsHandler) GetSwiftCodeLocalTime(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	swiftCode := vars["swiftCode"]
+
+	w.Header().Set("Content-Type", "application/json")
+
+	at := time.Now()
+	if value := r.URL.Query().Get("at"); value != "" {
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			errResponse := map[string]string{"message": "Invalid time, expected RFC 3339 (e.g. 2025-01-02T15:04:05Z)"}
+			if IsAPIDebugActive() {
+				errResponse["message"] = err.Error()
+			}
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(errResponse)
+			return
+		}
+		at = parsed
+	}
+
+	rh.logger.Debug("Getting local time of SWIFT code: %s", swiftCode)
+
+	response, err := rh.service.GetBankLocalTime(swiftCode, at)
+	if err != nil {
+		status := http.StatusInternalServerError
+		errResponse := map[string]string{"message": "Unable to get the local time"}
+		switch {
+		case errors.Is(err, service.ErrBankNotFound):
+			status = http.StatusNotFound
+			errResponse["message"] = "SWIFT code not found"
+		case errors.Is(err, service.ErrUnknownTimeZone):
+			// The bank exists, but its zone can't be told from the stored data
+			status = http.StatusUnprocessableEntity
+			errResponse["message"] = "Time zone of the bank is unknown"
+		}
+		if IsAPIDebugActive() {
+			errResponse["message"] = err.Error()
+		}
+		w.WriteHeader(status)
+		rh.logger.Error("Error getting local time: %v", err)
+		json.NewEncoder(w).Encode(errResponse)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
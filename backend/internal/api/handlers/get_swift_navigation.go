@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/Hbrtjm/SWIFT_API/backend/internal/service"
+	"github.com/gorilla/mux"
+)
+
+// GetHeadquarter handles GET request for the headquarter of a SWIFT code
+func (rh *RequestsHandler) GetHeadquarter(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	swiftCode := vars["swiftCode"]
+
+	rh.logger.Debug("Getting headquarter of SWIFT code: %s", swiftCode)
+
+	response, err := rh.service.GetHeadquarter(swiftCode)
+
+	w.Header().Set("Content-Type", "application/json")
+	if err != nil {
+		status := http.StatusNotFound
+		errResponse := map[string]string{"message": "Headquarter not found"}
+		if !errors.Is(err, service.ErrBankNotFound) {
+			status = http.StatusInternalServerError
+			errResponse["message"] = "Error fetching headquarter"
+		}
+		if IsAPIDebugActive() {
+			errResponse["message"] = err.Error()
+		}
+		w.WriteHeader(status)
+		rh.logger.Error("Error fetching headquarter: %v", err)
+		json.NewEncoder(w).Encode(errResponse)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
+
+// GetBranches handles GET request for a page of the branches of a SWIFT code, with the optional limit and cursor parameters
+func (rh *RequestsHandler) GetBranches(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	swiftCode := vars["swiftCode"]
+	query := r.URL.Query()
+
+	w.Header().Set("Content-Type", "application/json")
+
+	limit := 0
+	if value := query.Get("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"message": "Limit must be a number"})
+			return
+		}
+		limit = parsed
+	}
+
+	rh.logger.Debug("Getting branches of SWIFT code: %s", swiftCode)
+
+	response, err := rh.service.GetBranches(swiftCode, limit, query.Get("cursor"))
+	if err != nil {
+		status := http.StatusNotFound
+		errResponse := map[string]string{"message": "SWIFT code not found"}
+		switch {
+		case errors.Is(err, service.ErrInvalidLimit):
+			status = http.StatusBadRequest
+			errResponse["message"] = "Invalid limit"
+		case errors.Is(err, service.ErrInvalidCursor):
+			status = http.StatusBadRequest
+			errResponse["message"] = "Invalid cursor"
+		case !errors.Is(err, service.ErrBankNotFound):
+			status = http.StatusInternalServerError
+			errResponse["message"] = "Error fetching branches"
+		}
+		if IsAPIDebugActive() {
+			errResponse["message"] = err.Error()
+		}
+		w.WriteHeader(status)
+		rh.logger.Error("Error fetching branches: %v", err)
+		json.NewEncoder(w).Encode(errResponse)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
@@ -0,0 +1,30 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/Hbrtjm/SWIFT_API/backend/internal/service"
+	"github.com/Hbrtjm/SWIFT_API/backend/pkg/i18n"
+	"golang.org/x/text/language"
+)
+
+// localizedCountryName returns the name of the country in the language matched by the localization middleware.
+// English keeps the display name stored with the country, the other languages use the translation catalogs
+func localizedCountryName(r *http.Request, countryISO2, displayName string) string {
+	tag := i18n.FromContext(r.Context())
+	if tag == language.English {
+		return displayName
+	}
+	if name, ok := i18n.CountryName(tag, countryISO2); ok {
+		return name
+	}
+	return displayName
+}
+
+// localizeSwiftCodeResponse replaces the country display name of the bank, the response may be nil
+func localizeSwiftCodeResponse(r *http.Request, response *service.SwiftCodeResponse) {
+	if response == nil {
+		return
+	}
+	response.CountryDisplayName = localizedCountryName(r, response.CountryISO2, response.CountryDisplayName)
+}
@@ -2,10 +2,13 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"strings"
 
+	"github.com/Hbrtjm/SWIFT_API/backend/internal/service"
 	"github.com/Hbrtjm/SWIFT_API/backend/internal/util"
+	"github.com/Hbrtjm/SWIFT_API/backend/pkg/validators"
 )
 
 // PostBankEntry handles POST request to create a new SWIFT code
@@ -24,10 +27,11 @@ func (rh *RequestsHandler) PostBankEntry(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	// The country name is kept as written for its display name, the service uppercases the canonical one
 	bankData := map[string]interface{}{
 		"swiftCode":     strings.ToUpper(swiftCodeRequest.SwiftCode),
 		"countryISO2":   strings.ToUpper(swiftCodeRequest.CountryISO2),
-		"countryName":   strings.ToUpper(swiftCodeRequest.CountryName),
+		"countryName":   strings.TrimSpace(swiftCodeRequest.CountryName),
 		"bankName":      swiftCodeRequest.BankName,
 		"address":       swiftCodeRequest.Address,
 		"townName":      swiftCodeRequest.TownName,
@@ -43,11 +47,25 @@ func (rh *RequestsHandler) PostBankEntry(w http.ResponseWriter, r *http.Request)
 
 		rh.logger.Error("Error creating bank: %v", err)
 
+		// Field level errors are meant for the client, so they are returned regardless of the debug mode
+		var validationResult *validators.ValidationResult
+		if errors.As(err, &validationResult) {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"message": "Validation failed",
+				"errors":  validationResult.Errors,
+			})
+			return
+		}
+
 		statusCode := http.StatusInternalServerError
 		if strings.Contains(err.Error(), "validation error") ||
 			strings.Contains(err.Error(), "already exists") {
 			statusCode = http.StatusBadRequest
 		}
+		if errors.Is(err, service.ErrIntegrityViolation) {
+			statusCode = http.StatusConflict
+		}
 
 		errResponse := map[string]string{"message": "Error while creating a bank entry"}
 		if IsAPIDebugActive() {
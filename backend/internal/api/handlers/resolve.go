@@ -0,0 +1,47 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/Hbrtjm/SWIFT_API/backend/internal/service"
+)
+
+// Resolve handles POST request with the name, the town, the country and the address fragments of an institution,
+// and lists the directory entries matching them from the best
+func (rh *RequestsHandler) Resolve(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	var request service.ResolveRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		rh.logger.Error("Error decoding resolve request: %v", err)
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{"message": "Invalid request body"})
+		return
+	}
+
+	response, err := rh.service.Resolve(request)
+	if err != nil {
+		status := http.StatusInternalServerError
+		errResponse := map[string]string{"message": "Unable to resolve the institution"}
+		switch {
+		case errors.Is(err, service.ErrInvalidLimit):
+			status = http.StatusBadRequest
+			errResponse["message"] = "Invalid limit"
+		case errors.Is(err, service.ErrInvalidResolveRequest):
+			status = http.StatusBadRequest
+			errResponse["message"] = "Invalid request body"
+		}
+		if IsAPIDebugActive() {
+			errResponse["message"] = err.Error()
+		}
+		w.WriteHeader(status)
+		rh.logger.Error("Error resolving the institution: %v", err)
+		json.NewEncoder(w).Encode(errResponse)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
@@ -0,0 +1,40 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/Hbrtjm/SWIFT_API/backend/internal/service"
+)
+
+// GetScreeningHits handles GET request for the banks matching the sanctions lists, optionally filtered by the list,
+// the country and the match type
+func (rh *RequestsHandler) GetScreeningHits(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	list, countryISO2, matchType := query.Get("list"), query.Get("countryISO2"), query.Get("matchType")
+
+	rh.logger.Debug("Reporting screening hits, list: %s, country: %s, match type: %s", list, countryISO2, matchType)
+
+	response, err := rh.service.GetScreeningReport(list, countryISO2, matchType)
+
+	w.Header().Set("Content-Type", "application/json")
+	if err != nil {
+		status := http.StatusInternalServerError
+		errResponse := map[string]string{"message": "Unable to create the report"}
+		if errors.Is(err, service.ErrInvalidScreeningFilter) {
+			status = http.StatusBadRequest
+			errResponse["message"] = "Invalid screening filter"
+		}
+		if IsAPIDebugActive() {
+			errResponse["message"] = err.Error()
+		}
+		w.WriteHeader(status)
+		rh.logger.Error("Error reporting screening hits: %v", err)
+		json.NewEncoder(w).Encode(errResponse)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
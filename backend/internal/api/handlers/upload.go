@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/Hbrtjm/SWIFT_API/backend/internal/util"
+)
+
+// Default limit of the uploaded files, 64 MiB
+const defaultMaxUploadSize = 64 << 20
+
+// uploadFileField is the form field of the multipart uploads
+const uploadFileField = "file"
+
+var ErrMissingUploadFile = errors.New("missing uploaded file")
+
+// maxUploadSize reads the limit of the uploaded files in bytes from MAX_UPLOAD_SIZE
+func maxUploadSize() int64 {
+	size, err := strconv.ParseInt(util.GetEnvOrDefault("MAX_UPLOAD_SIZE", ""), 10, 64)
+	if err != nil || size <= 0 {
+		return defaultMaxUploadSize
+	}
+	return size
+}
+
+// uploadReader returns the uploaded file as a stream. The file is either the whole body, or the file field of a
+// multipart form, which is read without buffering the form. Reading past the size limit fails with
+// *http.MaxBytesError
+func uploadReader(w http.ResponseWriter, r *http.Request) (io.Reader, error) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxUploadSize())
+
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/form-data" {
+		return r.Body, nil
+	}
+
+	reader, err := r.MultipartReader()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			return nil, ErrMissingUploadFile
+		}
+		if err != nil {
+			return nil, err
+		}
+		if part.FormName() == uploadFileField {
+			return part, nil
+		}
+	}
+}
+
+// uploadErrorStatus maps the errors of reading the upload, the files over the limit give 413
+func uploadErrorStatus(err error) int {
+	var maxBytesError *http.MaxBytesError
+	if errors.As(err, &maxBytesError) {
+		return http.StatusRequestEntityTooLarge
+	}
+	return http.StatusBadRequest
+}
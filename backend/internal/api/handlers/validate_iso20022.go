@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/Hbrtjm/SWIFT_API/backend/pkg/iso20022"
+)
+
+// ValidateISO20022 handles POST request with a pain.001 or pacs.008 message, sent as the body or as the file field of
+// a multipart form
+func (rh *RequestsHandler) ValidateISO20022(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	message, err := uploadReader(w, r)
+	if err != nil {
+		rh.logger.Error("Invalid upload: %v", err)
+		w.WriteHeader(uploadErrorStatus(err))
+		json.NewEncoder(w).Encode(map[string]string{"message": "Invalid upload"})
+		return
+	}
+
+	report, err := rh.service.ValidateISO20022(message)
+	if err != nil {
+		status := http.StatusInternalServerError
+		errResponse := map[string]string{"message": "Unable to validate the message"}
+		switch {
+		case uploadErrorStatus(err) == http.StatusRequestEntityTooLarge:
+			status = http.StatusRequestEntityTooLarge
+			errResponse["message"] = "File is too large"
+		case errors.Is(err, iso20022.ErrUnsupportedMessage):
+			status = http.StatusBadRequest
+			errResponse["message"] = "Unsupported message type"
+		case errors.Is(err, iso20022.ErrMalformedMessage):
+			status = http.StatusBadRequest
+			errResponse["message"] = "Malformed message"
+		}
+		if IsAPIDebugActive() {
+			errResponse["message"] = err.Error()
+		}
+		w.WriteHeader(status)
+		rh.logger.Error("Error validating ISO 20022 message: %v", err)
+		json.NewEncoder(w).Encode(errResponse)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(report)
+}
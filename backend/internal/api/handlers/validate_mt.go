@@ -0,0 +1,47 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/Hbrtjm/SWIFT_API/backend/pkg/mt"
+)
+
+// ValidateMT handles POST request with SWIFT MT (FIN) messages, sent as the body or as the file field of a multipart
+// form
+func (rh *RequestsHandler) ValidateMT(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	messages, err := uploadReader(w, r)
+	if err != nil {
+		rh.logger.Error("Invalid upload: %v", err)
+		w.WriteHeader(uploadErrorStatus(err))
+		json.NewEncoder(w).Encode(map[string]string{"message": "Invalid upload"})
+		return
+	}
+
+	report, err := rh.service.ValidateMT(messages)
+	if err != nil {
+		status := http.StatusInternalServerError
+		errResponse := map[string]string{"message": "Unable to validate the message"}
+		switch {
+		case uploadErrorStatus(err) == http.StatusRequestEntityTooLarge:
+			status = http.StatusRequestEntityTooLarge
+			errResponse["message"] = "File is too large"
+		case errors.Is(err, mt.ErrMalformedMessage):
+			status = http.StatusBadRequest
+			errResponse["message"] = "Malformed message"
+		}
+		if IsAPIDebugActive() {
+			errResponse["message"] = err.Error()
+		}
+		w.WriteHeader(status)
+		rh.logger.Error("Error validating MT messages: %v", err)
+		json.NewEncoder(w).Encode(errResponse)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(report)
+}
@@ -0,0 +1,98 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+
+	"github.com/Hbrtjm/SWIFT_API/backend/pkg/i18n"
+	"golang.org/x/text/language"
+)
+
+// maxLocalizedBodySize limits the buffered responses, the message responses are small
+const maxLocalizedBodySize = 4096
+
+// LocalizationMiddleware matches the Accept-Language header against the translation catalogs. The language is put
+// in the request context for the handlers, and the message of the JSON message responses is translated
+func LocalizationMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tag := i18n.Match(r.Header.Get("Accept-Language"))
+		w.Header().Set("Content-Language", tag.String())
+		w.Header().Add("Vary", "Accept-Language")
+
+		r = r.WithContext(i18n.NewContext(r.Context(), tag))
+		if tag == language.English {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		lw := &localizingWriter{ResponseWriter: w, statusCode: http.StatusOK}
+		next.ServeHTTP(lw, r)
+		lw.flush(tag)
+	})
+}
+
+// localizingWriter buffers the beginning of the response, until it's clear whether it's a message response
+type localizingWriter struct {
+	http.ResponseWriter
+	statusCode int
+	body       bytes.Buffer
+	// Set once the response is too large to be a message, the rest is written through
+	passthrough bool
+}
+
+func (lw *localizingWriter) WriteHeader(statusCode int) {
+	lw.statusCode = statusCode
+}
+
+func (lw *localizingWriter) Write(b []byte) (int, error) {
+	if lw.passthrough {
+		return lw.ResponseWriter.Write(b)
+	}
+	lw.body.Write(b)
+	if lw.body.Len() > maxLocalizedBodySize {
+		lw.passthrough = true
+		lw.ResponseWriter.WriteHeader(lw.statusCode)
+		if _, err := lw.ResponseWriter.Write(lw.body.Bytes()); err != nil {
+			return 0, err
+		}
+	}
+	return len(b), nil
+}
+
+// Flush sends the buffered beginning of a streamed response, which can't be translated anymore
+func (lw *localizingWriter) Flush() {
+	if !lw.passthrough {
+		lw.passthrough = true
+		lw.ResponseWriter.WriteHeader(lw.statusCode)
+		lw.ResponseWriter.Write(lw.body.Bytes())
+		lw.body.Reset()
+	}
+	http.NewResponseController(lw.ResponseWriter).Flush()
+}
+
+// flush writes the buffered response, with the translated message
+func (lw *localizingWriter) flush(tag language.Tag) {
+	if lw.passthrough {
+		return
+	}
+
+	body := lw.body.Bytes()
+	var response map[string]json.RawMessage
+	if err := json.Unmarshal(body, &response); err == nil {
+		var message string
+		if err := json.Unmarshal(response["message"], &message); err == nil {
+			if translated := i18n.Message(tag, message); translated != message {
+				response["message"], _ = json.Marshal(translated)
+				var buffer bytes.Buffer
+				// Encoded in the same way as by the handlers
+				if err := json.NewEncoder(&buffer).Encode(response); err == nil {
+					body = buffer.Bytes()
+				}
+			}
+		}
+	}
+
+	lw.ResponseWriter.WriteHeader(lw.statusCode)
+	lw.ResponseWriter.Write(body)
+}
@@ -38,8 +38,9 @@ func LoggingMiddlewareWithConfig(logger *Logger, config *Config) func(http.Handl
 			// Capture request body request logging is enabled
 			var requestBody string
 			if config.LogRequestBody && r.Body != nil && r.Method != http.MethodGet {
-				bodyBytes, _ := io.ReadAll(r.Body)
-				r.Body = io.NopCloser(bytes.NewBuffer(bodyBytes))
+				// Only the logged part is read, the rest of the uploaded files is streamed to the handler
+				bodyBytes, _ := io.ReadAll(io.LimitReader(r.Body, int64(config.MaxBodySize)+1))
+				r.Body = &prefixedBody{Reader: io.MultiReader(bytes.NewReader(bodyBytes), r.Body), Closer: r.Body}
 
 				// Truncate if needed
 				requestBody = string(bodyBytes)
@@ -59,6 +60,7 @@ func LoggingMiddlewareWithConfig(logger *Logger, config *Config) func(http.Handl
 					ResponseWriter: w,
 					statusCode:     http.StatusOK,
 					body:           &bytes.Buffer{},
+					maxBodySize:    config.MaxBodySize,
 				}
 				next.ServeHTTP(rw, r)
 			} else {
@@ -95,6 +97,12 @@ func LoggingMiddlewareWithConfig(logger *Logger, config *Config) func(http.Handl
 	}
 }
 
+// prefixedBody puts the logged part of the request body back in front of the unread rest
+type prefixedBody struct {
+	io.Reader
+	io.Closer
+}
+
 // ContentTypeMiddleware sets the Content-Type header for all responses
 func ContentTypeMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -10,6 +10,8 @@ type responseWriter struct {
 	http.ResponseWriter
 	statusCode int
 	body       *bytes.Buffer
+	// Only the logged beginning of the body is captured, the streamed responses can be of any size
+	maxBodySize int
 }
 
 // WriteHeader captures the status code before writing it
@@ -19,6 +21,13 @@ func (rw *responseWriter) WriteHeader(statusCode int) {
 }
 
 func (rw *responseWriter) Write(b []byte) (int, error) {
-	rw.body.Write(b)
+	if remaining := rw.maxBodySize + 1 - rw.body.Len(); remaining > 0 {
+		rw.body.Write(b[:min(len(b), remaining)])
+	}
 	return rw.ResponseWriter.Write(b)
 }
+
+// Flush sends the written part of a streamed response
+func (rw *responseWriter) Flush() {
+	http.NewResponseController(rw.ResponseWriter).Flush()
+}
@@ -25,6 +25,7 @@ func NewRouter(service *service.SwiftCodeService, logger *middleware.Logger) (ro
 
 	router.Use(middleware.LoggingMiddlewareWithConfig(logger, config))
 	router.Use(middleware.ContentTypeMiddleware)
+	router.Use(middleware.LocalizationMiddleware)
 
 	version := util.GetEnvOrDefault("VERSION", "v1")
 
@@ -32,7 +33,32 @@ func NewRouter(service *service.SwiftCodeService, logger *middleware.Logger) (ro
 
 	// Define all API routes
 	api.HandleFunc("/swift-codes/{swiftCode}", swiftDatabaseResponseHandler.GetBySwiftCode).Methods(http.MethodGet)
+	api.HandleFunc("/swift-codes/{swiftCode}/analysis", swiftDatabaseResponseHandler.GetSwiftCodeAnalysis).Methods(http.MethodGet)
+	api.HandleFunc("/swift-codes/{swiftCode}/headquarter", swiftDatabaseResponseHandler.GetHeadquarter).Methods(http.MethodGet)
+	api.HandleFunc("/swift-codes/{swiftCode}/branches", swiftDatabaseResponseHandler.GetBranches).Methods(http.MethodGet)
+	api.HandleFunc("/swift-codes/{swiftCode}/status", swiftDatabaseResponseHandler.GetBankStatus).Methods(http.MethodGet)
+	api.HandleFunc("/swift-codes/{swiftCode}/status", swiftDatabaseResponseHandler.PutBankStatus).Methods(http.MethodPut)
+	api.HandleFunc("/swift-codes/{swiftCode}/correspondents", swiftDatabaseResponseHandler.GetCorrespondents).Methods(http.MethodGet)
+	api.HandleFunc("/swift-codes/{swiftCode}/local-time", swiftDatabaseResponseHandler.GetSwiftCodeLocalTime).Methods(http.MethodGet)
 	api.HandleFunc("/swift-codes/country/{countryISO2code}", swiftDatabaseResponseHandler.GetBySwiftCodesByCountry).Methods(http.MethodGet)
+	api.HandleFunc("/swift-codes/region/{region}", swiftDatabaseResponseHandler.GetBySwiftCodesByRegion).Methods(http.MethodGet)
+	api.HandleFunc("/countries", swiftDatabaseResponseHandler.GetCountries).Methods(http.MethodGet)
+	api.HandleFunc("/countries/{countryISO2code}", swiftDatabaseResponseHandler.GetCountry).Methods(http.MethodGet)
+	api.HandleFunc("/institutions/{bankCode}", swiftDatabaseResponseHandler.GetInstitution).Methods(http.MethodGet)
+	api.HandleFunc("/clearing-codes/{system}/{id}", swiftDatabaseResponseHandler.GetByClearingCode).Methods(http.MethodGet)
+	api.HandleFunc("/leis/{lei}/swift-codes", swiftDatabaseResponseHandler.GetSwiftCodesByLEI).Methods(http.MethodGet)
+	api.HandleFunc("/reports/missing-leis", swiftDatabaseResponseHandler.GetMissingLEIReport).Methods(http.MethodGet)
+	api.HandleFunc("/reports/orphans", swiftDatabaseResponseHandler.GetOrphanReport).Methods(http.MethodGet)
+	api.HandleFunc("/screening/hits", swiftDatabaseResponseHandler.GetScreeningHits).Methods(http.MethodGet)
+	api.HandleFunc("/correspondents", swiftDatabaseResponseHandler.PostCorrespondent).Methods(http.MethodPost)
+	api.HandleFunc("/correspondents/{swiftCode}/{correspondentSwiftCode}/{currency}", swiftDatabaseResponseHandler.DeleteCorrespondent).Methods(http.MethodDelete)
+	api.HandleFunc("/routes", swiftDatabaseResponseHandler.GetRoutes).Methods(http.MethodGet)
+	api.HandleFunc("/schemes", swiftDatabaseResponseHandler.GetSchemes).Methods(http.MethodGet)
+	api.HandleFunc("/ibans/{iban}", swiftDatabaseResponseHandler.GetIBAN).Methods(http.MethodGet)
+	api.HandleFunc("/validate/iso20022", swiftDatabaseResponseHandler.ValidateISO20022).Methods(http.MethodPost)
+	api.HandleFunc("/validate/mt", swiftDatabaseResponseHandler.ValidateMT).Methods(http.MethodPost)
+	api.HandleFunc("/enrich", swiftDatabaseResponseHandler.Enrich).Methods(http.MethodPost)
+	api.HandleFunc("/resolve", swiftDatabaseResponseHandler.Resolve).Methods(http.MethodPost)
 	api.HandleFunc("/swift-codes", swiftDatabaseResponseHandler.PostBankEntry).Methods(http.MethodPost)
 	api.HandleFunc("/swift-codes/{swiftCode}", swiftDatabaseResponseHandler.DeleteSwiftCode).Methods(http.MethodDelete)
 
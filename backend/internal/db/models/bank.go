@@ -1,5 +1,11 @@
 package models
 
+import (
+	"time"
+
+	"github.com/Hbrtjm/SWIFT_API/backend/pkg/address"
+)
+
 // Renamed from models, since we have a models package already
 type Bank struct {
 	CountryISO2 string `bson:"countryISO2" json:"countryISO2"`
@@ -7,9 +13,22 @@ type Bank struct {
 	CodeType    string `bson:"codeType" json:"codeType"` // This could be a constant, it can be "BIC11" for all of the rows in the CSV file
 	BankName    string `bson:"bankName" json:"bankName"`
 	Address     string `bson:"address" json:"address"` // This should be optional since some rows in the CSV file don't have an address
-	TownName    string `bson:"townName" json:"townName"`
+	// Address split into the ISO 20022 fields at ingestion, the raw address stays the source
+	StructuredAddress address.PostalAddress `bson:"structuredAddress,omitempty" json:"structuredAddress"`
+	TownName          string                `bson:"townName" json:"townName"`
 	// CountryName   string `bson:"countryName" json:"countryName"` // Deprecated, in the final patch I will remove this field
-	// TimeZone      string `bson:"timeZone" json:"timeZone"` // Deprecated, in the final patch I will remove this field
+	TimeZone      string `bson:"timeZone" json:"timeZone"` // Countries like the US span multiple zones, so the zone is kept per bank
 	IsHeadquarter bool   `bson:"isHeadquarter" json:"isHeadquarter"`
 	BranchCode    string `bson:"branchCode" json:"branchCode"` // This would either specify a branch or the head office (XXX)
+	// First four characters of the SWIFT code, identifying the institution across the countries
+	InstitutionCode string `bson:"institutionCode" json:"institutionCode"`
+	// Flags derived from the BIC structure (ISO 9362) at ingestion
+	IsTestAndTraining    bool `bson:"isTestAndTraining" json:"isTestAndTraining"`
+	IsPassiveParticipant bool `bson:"isPassiveParticipant" json:"isPassiveParticipant"`
+	// Lifecycle status, an empty status is active (banks imported before the statuses were introduced)
+	Status string `bson:"status,omitempty" json:"status,omitempty"`
+	// Set once the status was changed
+	StatusEffectiveDate *time.Time `bson:"statusEffectiveDate,omitempty" json:"statusEffectiveDate,omitempty"`
+	// Code taking over the retired or merged bank
+	SuccessorSwiftCode string `bson:"successorSwiftCode,omitempty" json:"successorSwiftCode,omitempty"`
 }
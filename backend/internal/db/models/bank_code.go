@@ -0,0 +1,9 @@
+package models
+
+// BankCode maps a national bank code, as found in the IBANs of the country, to a SWIFT code
+type BankCode struct {
+	CountryISO2 string `bson:"countryISO2" json:"countryISO2"`
+	// Bank identifier followed by the branch identifier where the IBAN of the country contains one
+	BankCode  string `bson:"bankCode" json:"bankCode"`
+	SwiftCode string `bson:"swiftCode" json:"swiftCode"`
+}
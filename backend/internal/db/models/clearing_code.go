@@ -0,0 +1,9 @@
+package models
+
+// ClearingCode links the member id of a national clearing system (sort code, ABA routing number, BLZ) to a SWIFT code
+type ClearingCode struct {
+	// ISO 20022 code of the clearing system, e.g. GBDSC
+	ClearingSystem string `bson:"clearingSystem" json:"clearingSystem"`
+	MemberID       string `bson:"memberId" json:"memberId"`
+	SwiftCode      string `bson:"swiftCode" json:"swiftCode"`
+}
@@ -0,0 +1,9 @@
+package models
+
+// Correspondent is an account relationship in a currency, the correspondent holds an account of the bank
+type Correspondent struct {
+	SwiftCode              string `bson:"swiftCode" json:"swiftCode"`
+	CorrespondentSwiftCode string `bson:"correspondentSwiftCode" json:"correspondentSwiftCode"`
+	// ISO 4217 code of the currency of the account
+	Currency string `bson:"currency" json:"currency"`
+}
@@ -1,7 +1,13 @@
 package models
 
 type Country struct {
-	CountryISO2 string `bson:"countryISO2" json:"countryISO2"`
+	CountryISO2    string `bson:"countryISO2" json:"countryISO2"`
+	CountryISO3    string `bson:"countryISO3" json:"countryISO3"`
+	CountryNumeric string `bson:"countryNumeric" json:"countryNumeric"`
+	// Canonical name, the uppercase ISO 3166 name used for the matching
 	CountryName string `bson:"countryName" json:"countryName"`
-	TimeZone    string `bson:"timeZone" json:"timeZone"`
+	// Name in the casing of the source, e.g. Poland, the localized names come from the translation catalogs
+	DisplayName string `bson:"displayName,omitempty" json:"displayName,omitempty"`
+	// Zones of the banks in the country, the zone of a particular bank is stored with the bank
+	TimeZones []string `bson:"timeZones" json:"timeZones"`
 }
@@ -0,0 +1,8 @@
+package models
+
+// LEIMapping links a SWIFT code to the Legal Entity Identifier of its owner, as published by GLEIF
+type LEIMapping struct {
+	// Always the 11 character form, the 8 character BICs of the GLEIF file refer to the primary office
+	SwiftCode string `bson:"swiftCode" json:"swiftCode"`
+	LEI       string `bson:"lei" json:"lei"`
+}
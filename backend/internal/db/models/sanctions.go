@@ -0,0 +1,46 @@
+package models
+
+import "time"
+
+// SanctionEntry is an entry of a sanctions list, see pkg/sanctions
+type SanctionEntry struct {
+	// List the entry comes from, ofac or eu
+	List    string `bson:"list" json:"list"`
+	EntryID string `bson:"entryId" json:"entryId"`
+	// The primary name first, followed by the aliases
+	Names     []string `bson:"names" json:"names"`
+	Type      string   `bson:"type" json:"type"`
+	Programs  []string `bson:"programs" json:"programs"`
+	BICs      []string `bson:"bics" json:"bics"`
+	Countries []string `bson:"countries" json:"countries"`
+	// Time of the import the entry comes from, the entries of the previous import are deleted after the new ones are
+	// inserted
+	LoadedAt time.Time `bson:"loadedAt" json:"loadedAt"`
+}
+
+// SanctionList records the last import of a list
+type SanctionList struct {
+	List string `bson:"list" json:"list"`
+	// File the list was imported from
+	Source   string    `bson:"source" json:"source"`
+	Entries  int       `bson:"entries" json:"entries"`
+	LoadedAt time.Time `bson:"loadedAt" json:"loadedAt"`
+}
+
+// ScreeningHit is a bank matching an entry of a sanctions list, the hits are replaced at every screening
+type ScreeningHit struct {
+	SwiftCode   string `bson:"swiftCode" json:"swiftCode"`
+	BankName    string `bson:"bankName" json:"bankName"`
+	CountryISO2 string `bson:"countryISO2" json:"countryISO2"`
+	List        string `bson:"list" json:"list"`
+	EntryID     string `bson:"entryId" json:"entryId"`
+	// Name of the entry which matched
+	EntryName string `bson:"entryName" json:"entryName"`
+	// bic or name
+	MatchType string  `bson:"matchType" json:"matchType"`
+	Score     float64 `bson:"score" json:"score"`
+	// Whether the bank is in a country of the entry
+	CountryMatch bool      `bson:"countryMatch" json:"countryMatch"`
+	Programs     []string  `bson:"programs" json:"programs"`
+	ScreenedAt   time.Time `bson:"screenedAt" json:"screenedAt"`
+}
@@ -0,0 +1,11 @@
+package models
+
+import "time"
+
+// SchemeParticipation records that a bank is reachable through a payment scheme from the adherence date
+type SchemeParticipation struct {
+	SwiftCode string `bson:"swiftCode" json:"swiftCode"`
+	// Code of the scheme, see pkg/schemes
+	Scheme        string    `bson:"scheme" json:"scheme"`
+	AdherenceDate time.Time `bson:"adherenceDate" json:"adherenceDate"`
+}
@@ -0,0 +1,21 @@
+package models
+
+import "time"
+
+// Lifecycle statuses of a bank
+const (
+	BankStatusActive   = "active"
+	BankStatusInactive = "inactive"
+	BankStatusRetired  = "retired"
+	BankStatusMerged   = "merged"
+)
+
+// StatusChange is a change of the status of a bank taking effect at a date, future changes are applied by the scheduler
+type StatusChange struct {
+	SwiftCode          string    `bson:"swiftCode" json:"swiftCode"`
+	Status             string    `bson:"status" json:"status"`
+	SuccessorSwiftCode string    `bson:"successorSwiftCode,omitempty" json:"successorSwiftCode,omitempty"`
+	EffectiveDate      time.Time `bson:"effectiveDate" json:"effectiveDate"`
+	// Zero until the change is applied
+	AppliedAt time.Time `bson:"appliedAt,omitempty" json:"appliedAt"`
+}
@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Hbrtjm/SWIFT_API/backend/internal/db/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func (r *MongoRepository) BankCodesCollection() *mongo.Collection {
+	return r.bankCodeCollection
+}
+
+// UpsertManyBankCodes inserts the bank code mappings, replacing the existing mappings of the same codes
+func (r *MongoRepository) UpsertManyBankCodes(bankCodes []models.BankCode) error {
+	if len(bankCodes) == 0 {
+		return nil
+	}
+
+	writes := make([]mongo.WriteModel, len(bankCodes))
+	for i, bankCode := range bankCodes {
+		filter := bson.M{"countryISO2": bankCode.CountryISO2, "bankCode": bankCode.BankCode}
+		writes[i] = mongo.NewReplaceOneModel().SetFilter(filter).SetReplacement(bankCode).SetUpsert(true)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	_, err := r.bankCodeCollection.BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false))
+	if err != nil {
+		return fmt.Errorf("database error: %w", err)
+	}
+	return nil
+}
+
+// FindBankCode finds the mapping of a national bank code
+func (r *MongoRepository) FindBankCode(countryISO2, bankCode string) (models.BankCode, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 6*time.Second)
+	defer cancel()
+
+	var result models.BankCode
+	filter := bson.M{"countryISO2": countryISO2, "bankCode": bankCode}
+	err := r.bankCodeCollection.FindOne(ctx, filter).Decode(&result)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return models.BankCode{}, ErrBankCodeNotFound
+		}
+		return models.BankCode{}, fmt.Errorf("database error retrieving bank code: %w", err)
+	}
+	return result, nil
+}
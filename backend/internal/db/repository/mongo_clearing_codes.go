@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Hbrtjm/SWIFT_API/backend/internal/db/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func (r *MongoRepository) ClearingCodesCollection() *mongo.Collection {
+	return r.clearingCodeCollection
+}
+
+// UpsertManyClearingCodes inserts the clearing codes, replacing the existing entries of the same member ids
+func (r *MongoRepository) UpsertManyClearingCodes(clearingCodes []models.ClearingCode) error {
+	if len(clearingCodes) == 0 {
+		return nil
+	}
+
+	writes := make([]mongo.WriteModel, len(clearingCodes))
+	for i, clearingCode := range clearingCodes {
+		filter := bson.M{"clearingSystem": clearingCode.ClearingSystem, "memberId": clearingCode.MemberID}
+		writes[i] = mongo.NewReplaceOneModel().SetFilter(filter).SetReplacement(clearingCode).SetUpsert(true)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	_, err := r.clearingCodeCollection.BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false))
+	if err != nil {
+		return fmt.Errorf("database error: %w", err)
+	}
+	return nil
+}
+
+// FindClearingCode finds the entry of a member id of a clearing system
+func (r *MongoRepository) FindClearingCode(clearingSystem, memberID string) (models.ClearingCode, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 6*time.Second)
+	defer cancel()
+
+	var result models.ClearingCode
+	filter := bson.M{"clearingSystem": clearingSystem, "memberId": memberID}
+	err := r.clearingCodeCollection.FindOne(ctx, filter).Decode(&result)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return models.ClearingCode{}, ErrClearingCodeNotFound
+		}
+		return models.ClearingCode{}, fmt.Errorf("database error retrieving clearing code: %w", err)
+	}
+	return result, nil
+}
+
+// FindClearingCodesBySwiftCode lists the clearing codes of a bank
+func (r *MongoRepository) FindClearingCodesBySwiftCode(swiftCode string) ([]models.ClearingCode, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 6*time.Second)
+	defer cancel()
+
+	filter := bson.M{"swiftCode": swiftCode}
+	opts := options.Find().SetSort(bson.D{{Key: "clearingSystem", Value: 1}, {Key: "memberId", Value: 1}})
+
+	cursor, err := r.clearingCodeCollection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	results := []models.ClearingCode{}
+	if err = cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Hbrtjm/SWIFT_API/backend/internal/db/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func (r *MongoRepository) CorrespondentsCollection() *mongo.Collection {
+	return r.correspondentCollection
+}
+
+// UpsertManyCorrespondents inserts the relationships, the existing ones are left as they are
+func (r *MongoRepository) UpsertManyCorrespondents(correspondents []models.Correspondent) error {
+	if len(correspondents) == 0 {
+		return nil
+	}
+
+	writes := make([]mongo.WriteModel, len(correspondents))
+	for i, correspondent := range correspondents {
+		writes[i] = mongo.NewReplaceOneModel().SetFilter(correspondentFilter(correspondent)).SetReplacement(correspondent).SetUpsert(true)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	_, err := r.correspondentCollection.BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false))
+	if err != nil {
+		return fmt.Errorf("database error: %w", err)
+	}
+	return nil
+}
+
+// DeleteCorrespondent deletes a relationship
+func (r *MongoRepository) DeleteCorrespondent(correspondent models.Correspondent) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 6*time.Second)
+	defer cancel()
+
+	result, err := r.correspondentCollection.DeleteOne(ctx, correspondentFilter(correspondent))
+	if err != nil {
+		return fmt.Errorf("database delete error: %w", err)
+	}
+	if result.DeletedCount == 0 {
+		return ErrCorrespondentNotFound
+	}
+	return nil
+}
+
+// FindCorrespondentsBySwiftCode lists the relationships of a bank on either side, optionally in a single currency
+func (r *MongoRepository) FindCorrespondentsBySwiftCode(swiftCode, currency string) ([]models.Correspondent, error) {
+	filter := bson.M{"$or": bson.A{bson.M{"swiftCode": swiftCode}, bson.M{"correspondentSwiftCode": swiftCode}}}
+	if currency != "" {
+		filter["currency"] = currency
+	}
+	return r.findCorrespondents(filter)
+}
+
+// FindCorrespondentsByCurrency lists every relationship in the currency
+func (r *MongoRepository) FindCorrespondentsByCurrency(currency string) ([]models.Correspondent, error) {
+	return r.findCorrespondents(bson.M{"currency": currency})
+}
+
+func (r *MongoRepository) findCorrespondents(filter bson.M) ([]models.Correspondent, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{{Key: "currency", Value: 1}, {Key: "swiftCode", Value: 1}, {Key: "correspondentSwiftCode", Value: 1}})
+	cursor, err := r.correspondentCollection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("database error retrieving correspondents: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	results := []models.Correspondent{}
+	if err = cursor.All(ctx, &results); err != nil {
+		return nil, fmt.Errorf("database error retrieving correspondents: %w", err)
+	}
+	return results, nil
+}
+
+func correspondentFilter(correspondent models.Correspondent) bson.M {
+	return bson.M{
+		"swiftCode":              correspondent.SwiftCode,
+		"correspondentSwiftCode": correspondent.CorrespondentSwiftCode,
+		"currency":               correspondent.Currency,
+	}
+}
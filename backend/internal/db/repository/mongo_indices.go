@@ -44,6 +44,26 @@ func (r *MongoRepository) CreateIndices(logger *middleware.Logger) error {
 		return err
 	}
 
+	// Create index on institutionCode field to group the banks of an institution across the countries
+	_, err = r.BanksCollection().Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "institutionCode", Value: 1}, {Key: "swiftCode", Value: 1}},
+	})
+
+	if err != nil {
+		logger.Error("Error creating institutionCode index in banks collection: %v", err)
+		return err
+	}
+
+	// Create index on the name prefixes to look the banks up by the words of their names
+	_, err = r.BanksCollection().Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "namePrefixes", Value: 1}, {Key: "countryISO2", Value: 1}},
+	})
+
+	if err != nil {
+		logger.Error("Error creating namePrefixes index in banks collection: %v", err)
+		return err
+	}
+
 	_, err = r.CountriesCollection().Indexes().CreateOne(ctx, mongo.IndexModel{
 		Keys: bson.D{{Key: "countryISO2", Value: 1}},
 	})
@@ -53,6 +73,175 @@ func (r *MongoRepository) CreateIndices(logger *middleware.Logger) error {
 		return err
 	}
 
+	// Bank codes are unique within a country
+	_, err = r.BankCodesCollection().Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "countryISO2", Value: 1}, {Key: "bankCode", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+
+	if err != nil {
+		logger.Error("Error creating bank code index in bank codes collection: %v", err)
+		return err
+	}
+
+	// A member id identifies a single bank within its clearing system
+	_, err = r.ClearingCodesCollection().Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "clearingSystem", Value: 1}, {Key: "memberId", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+
+	if err != nil {
+		logger.Error("Error creating clearing code index in clearing codes collection: %v", err)
+		return err
+	}
+
+	// Create index on swiftCode field to list the clearing codes of a bank
+	_, err = r.ClearingCodesCollection().Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "swiftCode", Value: 1}},
+	})
+
+	if err != nil {
+		logger.Error("Error creating swiftCode index in clearing codes collection: %v", err)
+		return err
+	}
+
+	// A SWIFT code belongs to a single legal entity
+	_, err = r.LEIMappingsCollection().Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "swiftCode", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+
+	if err != nil {
+		logger.Error("Error creating swiftCode index in LEI mappings collection: %v", err)
+		return err
+	}
+
+	// Create index on lei field to optimize the reverse lookups
+	_, err = r.LEIMappingsCollection().Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "lei", Value: 1}},
+	})
+
+	if err != nil {
+		logger.Error("Error creating lei index in LEI mappings collection: %v", err)
+		return err
+	}
+
+	// A bank has a single change per effective date
+	_, err = r.StatusChangesCollection().Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "swiftCode", Value: 1}, {Key: "effectiveDate", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+
+	if err != nil {
+		logger.Error("Error creating status change index in status changes collection: %v", err)
+		return err
+	}
+
+	// Create index on effectiveDate field to find the due changes
+	_, err = r.StatusChangesCollection().Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "effectiveDate", Value: 1}},
+	})
+
+	if err != nil {
+		logger.Error("Error creating effectiveDate index in status changes collection: %v", err)
+		return err
+	}
+
+	// A relationship is stored once per currency
+	_, err = r.CorrespondentsCollection().Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "swiftCode", Value: 1}, {Key: "correspondentSwiftCode", Value: 1}, {Key: "currency", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+
+	if err != nil {
+		logger.Error("Error creating relationship index in correspondents collection: %v", err)
+		return err
+	}
+
+	// Create index on currency field to load the graph of a currency
+	_, err = r.CorrespondentsCollection().Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "currency", Value: 1}},
+	})
+
+	if err != nil {
+		logger.Error("Error creating currency index in correspondents collection: %v", err)
+		return err
+	}
+
+	// Create index on correspondentSwiftCode field to list the accounts held by a bank
+	_, err = r.CorrespondentsCollection().Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "correspondentSwiftCode", Value: 1}},
+	})
+
+	if err != nil {
+		logger.Error("Error creating correspondentSwiftCode index in correspondents collection: %v", err)
+		return err
+	}
+
+	// A bank adheres to a scheme once
+	_, err = r.SchemeParticipationsCollection().Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "swiftCode", Value: 1}, {Key: "scheme", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+
+	if err != nil {
+		logger.Error("Error creating participation index in scheme participations collection: %v", err)
+		return err
+	}
+
+	// Create index on scheme field to filter the listings by a scheme
+	_, err = r.SchemeParticipationsCollection().Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "scheme", Value: 1}, {Key: "adherenceDate", Value: 1}},
+	})
+
+	if err != nil {
+		logger.Error("Error creating scheme index in scheme participations collection: %v", err)
+		return err
+	}
+
+	// An entry is listed once per import of a list, two imports coexist until the older one is deleted. The index
+	// without the load time is dropped from the older databases, the error when it doesn't exist is ignored
+	_, _ = r.SanctionEntriesCollection().Indexes().DropOne(ctx, "list_1_entryId_1")
+	_, err = r.SanctionEntriesCollection().Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "list", Value: 1}, {Key: "entryId", Value: 1}, {Key: "loadedAt", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+
+	if err != nil {
+		logger.Error("Error creating entry index in sanction entries collection: %v", err)
+		return err
+	}
+
+	_, err = r.SanctionListsCollection().Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "list", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+
+	if err != nil {
+		logger.Error("Error creating list index in sanction lists collection: %v", err)
+		return err
+	}
+
+	// Create index on swiftCode field to add the hits to the bank responses
+	_, err = r.ScreeningHitsCollection().Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "swiftCode", Value: 1}},
+	})
+
+	if err != nil {
+		logger.Error("Error creating swiftCode index in screening hits collection: %v", err)
+		return err
+	}
+
+	// Create index on screenedAt field to replace the hits of the older screenings
+	_, err = r.ScreeningHitsCollection().Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "screenedAt", Value: 1}},
+	})
+
+	if err != nil {
+		logger.Error("Error creating screenedAt index in screening hits collection: %v", err)
+		return err
+	}
+
 	logger.Info("Successfully created database indices")
 	return nil
 }
@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Hbrtjm/SWIFT_API/backend/internal/db/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// BranchHeadquarter pairs a branch with its headquarter, the headquarter is nil when it doesn't exist
+type BranchHeadquarter struct {
+	Branch      models.Bank  `bson:"branch"`
+	Headquarter *models.Bank `bson:"headquarter,omitempty"`
+}
+
+// CountBranches counts the branches of the 8-character code, the headquarter itself isn't counted
+func (r *MongoRepository) CountBranches(bic8 string) (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 6*time.Second)
+	defer cancel()
+
+	filter := bson.M{"branchCode": bic8, "swiftCode": bson.M{"$ne": bic8 + "XXX"}}
+	count, err := r.bankCollection.CountDocuments(ctx, filter)
+	if err != nil {
+		return 0, fmt.Errorf("database error counting branches: %w", err)
+	}
+	return count, nil
+}
+
+// DeleteBranches deletes the branches of the 8-character code and returns how many were deleted
+func (r *MongoRepository) DeleteBranches(bic8 string) (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 6*time.Second)
+	defer cancel()
+
+	filter := bson.M{"branchCode": bic8, "swiftCode": bson.M{"$ne": bic8 + "XXX"}}
+	result, err := r.bankCollection.DeleteMany(ctx, filter)
+	if err != nil {
+		return 0, fmt.Errorf("database delete error: %w", err)
+	}
+	return result.DeletedCount, nil
+}
+
+// FindBranchHeadquarters pairs every branch with its headquarter, an empty country covers every country
+func (r *MongoRepository) FindBranchHeadquarters(countryISO2 string) ([]BranchHeadquarter, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	match := bson.M{"isHeadquarter": false}
+	if countryISO2 != "" {
+		match["countryISO2"] = countryISO2
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		{{Key: "$sort", Value: bson.M{"swiftCode": 1}}},
+		{{Key: "$lookup", Value: bson.M{
+			"from": r.bankCollection.Name(),
+			"let": bson.M{
+				"headquarter": bson.M{"$concat": bson.A{bson.M{"$substrCP": bson.A{"$swiftCode", 0, 8}}, "XXX"}},
+			},
+			"pipeline": mongo.Pipeline{
+				{{Key: "$match", Value: bson.M{"$expr": bson.M{"$eq": bson.A{"$swiftCode", "$$headquarter"}}}}},
+				{{Key: "$limit", Value: 1}},
+			},
+			"as": "headquarters",
+		}}},
+		{{Key: "$project", Value: bson.M{
+			"_id":         0,
+			"branch":      "$$ROOT",
+			"headquarter": bson.M{"$arrayElemAt": bson.A{"$headquarters", 0}},
+		}}},
+	}
+
+	cursor, err := r.bankCollection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("database error pairing branches with headquarters: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	pairs := []BranchHeadquarter{}
+	if err = cursor.All(ctx, &pairs); err != nil {
+		return nil, fmt.Errorf("database error pairing branches with headquarters: %w", err)
+	}
+	return pairs, nil
+}
@@ -0,0 +1,132 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Hbrtjm/SWIFT_API/backend/internal/db/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func (r *MongoRepository) LEIMappingsCollection() *mongo.Collection {
+	return r.leiMappingCollection
+}
+
+// UpsertManyLEIMappings inserts the LEI mappings, replacing the existing mappings of the same SWIFT codes
+func (r *MongoRepository) UpsertManyLEIMappings(mappings []models.LEIMapping) error {
+	if len(mappings) == 0 {
+		return nil
+	}
+
+	writes := make([]mongo.WriteModel, len(mappings))
+	for i, mapping := range mappings {
+		filter := bson.M{"swiftCode": mapping.SwiftCode}
+		writes[i] = mongo.NewReplaceOneModel().SetFilter(filter).SetReplacement(mapping).SetUpsert(true)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	_, err := r.leiMappingCollection.BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false))
+	if err != nil {
+		return fmt.Errorf("database error: %w", err)
+	}
+	return nil
+}
+
+// FindLEIBySwiftCodes returns the first mapping found for the codes, in the order of the codes
+func (r *MongoRepository) FindLEIBySwiftCodes(swiftCodes ...string) (models.LEIMapping, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 6*time.Second)
+	defer cancel()
+
+	filter := bson.M{"swiftCode": bson.M{"$in": swiftCodes}}
+	cursor, err := r.leiMappingCollection.Find(ctx, filter)
+	if err != nil {
+		return models.LEIMapping{}, fmt.Errorf("database error retrieving LEI: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var mappings []models.LEIMapping
+	if err = cursor.All(ctx, &mappings); err != nil {
+		return models.LEIMapping{}, fmt.Errorf("database error retrieving LEI: %w", err)
+	}
+
+	for _, swiftCode := range swiftCodes {
+		for _, mapping := range mappings {
+			if mapping.SwiftCode == swiftCode {
+				return mapping, nil
+			}
+		}
+	}
+	return models.LEIMapping{}, ErrLEINotFound
+}
+
+// FindSwiftCodesByLEI lists the SWIFT codes mapped to the LEI
+func (r *MongoRepository) FindSwiftCodesByLEI(lei string) ([]string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 6*time.Second)
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{{Key: "swiftCode", Value: 1}})
+	cursor, err := r.leiMappingCollection.Find(ctx, bson.M{"lei": lei}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var mappings []models.LEIMapping
+	if err = cursor.All(ctx, &mappings); err != nil {
+		return nil, err
+	}
+
+	swiftCodes := make([]string, len(mappings))
+	for i, mapping := range mappings {
+		swiftCodes[i] = mapping.SwiftCode
+	}
+	return swiftCodes, nil
+}
+
+// FindBanksWithoutLEI lists the banks which have no LEI mapping, neither for their own code nor for their headquarter.
+// An empty country lists the banks of every country
+func (r *MongoRepository) FindBanksWithoutLEI(countryISO2 string) ([]models.Bank, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	match := bson.M{}
+	if countryISO2 != "" {
+		match["countryISO2"] = countryISO2
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		{{Key: "$lookup", Value: bson.M{
+			"from": r.leiMappingCollection.Name(),
+			"let": bson.M{
+				"code":        "$swiftCode",
+				"headquarter": bson.M{"$concat": bson.A{bson.M{"$substrCP": bson.A{"$swiftCode", 0, 8}}, "XXX"}},
+			},
+			"pipeline": mongo.Pipeline{
+				{{Key: "$match", Value: bson.M{"$expr": bson.M{"$in": bson.A{"$swiftCode", bson.A{"$$code", "$$headquarter"}}}}}},
+				{{Key: "$limit", Value: 1}},
+			},
+			"as": "leiMappings",
+		}}},
+		{{Key: "$match", Value: bson.M{"leiMappings": bson.M{"$size": 0}}}},
+		{{Key: "$project", Value: bson.M{"leiMappings": 0}}},
+		{{Key: "$sort", Value: bson.M{"swiftCode": 1}}},
+	}
+
+	cursor, err := r.bankCollection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("database error listing banks without LEI: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	banks := []models.Bank{}
+	if err = cursor.All(ctx, &banks); err != nil {
+		return nil, fmt.Errorf("database error listing banks without LEI: %w", err)
+	}
+	return banks, nil
+}
@@ -3,12 +3,25 @@ package repository
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/Hbrtjm/SWIFT_API/backend/internal/db/models"
+	"github.com/Hbrtjm/SWIFT_API/backend/pkg/iso3166"
+	"github.com/Hbrtjm/SWIFT_API/backend/pkg/names"
 	"go.mongodb.org/mongo-driver/bson"
 )
 
+// bankDocument is a bank as it's stored, with the prefixes of the words of its name and town to look it up by name
+type bankDocument struct {
+	models.Bank  `bson:",inline"`
+	NamePrefixes []string `bson:"namePrefixes"`
+}
+
+func newBankDocument(bank models.Bank) bankDocument {
+	return bankDocument{Bank: bank, NamePrefixes: names.Prefixes(bank.BankName, bank.TownName)}
+}
+
 // Delete deletes a document by SWIFT code
 func (r *MongoRepository) Delete(code string) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 6*time.Second)
@@ -21,7 +34,7 @@ func (r *MongoRepository) Delete(code string) error {
 	}
 
 	if result.DeletedCount == 0 {
-		return fmt.Errorf("%s SWIFT code not found", code)
+		return fmt.Errorf("%s SWIFT code not found: %w", code, ErrBankNotFound)
 	}
 
 	return nil
@@ -39,7 +52,7 @@ func (r *MongoRepository) InsertBank(bank models.Bank) error {
 	}
 
 	// Insert the bank
-	_, err = r.bankCollection.InsertOne(ctx, bank)
+	_, err = r.bankCollection.InsertOne(ctx, newBankDocument(bank))
 	if err != nil {
 		return fmt.Errorf("database error during bank insertion: %w", err)
 	}
@@ -56,7 +69,7 @@ func (r *MongoRepository) InsertManyBanks(banks []models.Bank) error {
 	// Convert to interface slice
 	data := make([]interface{}, len(banks))
 	for i := range banks {
-		data[i] = banks[i]
+		data[i] = newBankDocument(banks[i])
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
@@ -78,7 +91,7 @@ func (r *MongoRepository) InsertManyCountries(countries []models.Country) error
 	// Convert to interface slice
 	data := make([]interface{}, len(countries))
 	for i := range countries {
-		data[i] = countries[i]
+		data[i] = canonicalCountry(countries[i])
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
@@ -96,6 +109,8 @@ func (r *MongoRepository) InsertCountry(country models.Country) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 6*time.Second)
 	defer cancel()
 
+	country = canonicalCountry(country)
+
 	exists, err := r.CountryExists(country.CountryISO2)
 	if err != nil {
 		return err
@@ -107,11 +122,31 @@ func (r *MongoRepository) InsertCountry(country models.Country) error {
 			return err
 		}
 
-		// If country name is different and the new one is not empty, update it
-		if country.CountryName != "" && country.CountryName != existingCountry.CountryName {
-			filter := bson.M{"countryISO2": country.CountryISO2}
-			update := bson.M{"$set": bson.M{"countryName": country.CountryName}}
+		update := bson.M{}
+		set := bson.M{}
+
+		// If country name is different and the new one is not empty, update it, along with the codes of the entries stored before the registry was introduced
+		if (country.CountryName != "" && country.CountryName != existingCountry.CountryName) || existingCountry.CountryISO3 != country.CountryISO3 {
+			set["countryName"] = country.CountryName
+			set["countryISO3"] = country.CountryISO3
+			set["countryNumeric"] = country.CountryNumeric
+		}
+
+		// The entries stored before the display names were introduced get one too
+		if country.DisplayName != "" && country.DisplayName != existingCountry.DisplayName {
+			set["displayName"] = country.DisplayName
+		}
+		if len(set) > 0 {
+			update["$set"] = set
+		}
+
+		// Zones of the new banks are added to the list of the country
+		if len(country.TimeZones) > 0 {
+			update["$addToSet"] = bson.M{"timeZones": bson.M{"$each": country.TimeZones}}
+		}
 
+		if len(update) > 0 {
+			filter := bson.M{"countryISO2": country.CountryISO2}
 			_, err := r.countryCollection.UpdateOne(ctx, filter, update)
 			if err != nil {
 				return fmt.Errorf("database error updating country: %w", err)
@@ -128,3 +163,22 @@ func (r *MongoRepository) InsertCountry(country models.Country) error {
 
 	return nil
 }
+
+// canonicalCountry replaces the name of the country with its ISO 3166 name and fills in the alpha-3 and numeric codes.
+// A display name without the casing of the source, e.g. from the uppercase SWIFT file, is replaced by the ISO 3166 name
+func canonicalCountry(country models.Country) models.Country {
+	country.CountryISO2 = strings.ToUpper(country.CountryISO2)
+
+	registered, ok := iso3166.Lookup(country.CountryISO2)
+	if !ok {
+		return country
+	}
+
+	country.CountryISO3 = registered.Alpha3
+	country.CountryNumeric = registered.Numeric
+	country.CountryName = strings.ToUpper(registered.Name)
+	if country.DisplayName == strings.ToUpper(country.DisplayName) || !registered.MatchesName(country.DisplayName) {
+		country.DisplayName = registered.Name
+	}
+	return country
+}
@@ -13,9 +13,27 @@ import (
 
 // Define custom errors for better error handling
 var (
-	ErrCountryExists   = errors.New("country already exists")
-	ErrCountryNotFound = errors.New("country not found")
-	ErrBankExists      = errors.New("bank already exists")
+	ErrCountryExists         = errors.New("country already exists")
+	ErrCountryNotFound       = errors.New("country not found")
+	ErrBankExists            = errors.New("bank already exists")
+	ErrBankCodeNotFound      = errors.New("bank code not found")
+	ErrClearingCodeNotFound  = errors.New("clearing code not found")
+	ErrLEINotFound           = errors.New("LEI not found")
+	ErrBankNotFound          = errors.New("bank not found")
+	ErrCorrespondentNotFound = errors.New("correspondent relationship not found")
+)
+
+// Collections without a configurable name
+const (
+	BankCodesCollectionName            = "bankCodes"
+	ClearingCodesCollectionName        = "clearingCodes"
+	LEIMappingsCollectionName          = "leiMappings"
+	StatusChangesCollectionName        = "statusChanges"
+	CorrespondentsCollectionName       = "correspondents"
+	SchemeParticipationsCollectionName = "schemeParticipations"
+	SanctionEntriesCollectionName      = "sanctionEntries"
+	SanctionListsCollectionName        = "sanctionLists"
+	ScreeningHitsCollectionName        = "screeningHits"
 )
 
 // MongoRepository handles database operations
@@ -24,6 +42,24 @@ type MongoRepository struct {
 	database          *mongo.Database
 	bankCollection    *mongo.Collection
 	countryCollection *mongo.Collection
+	// National bank codes to SWIFT codes, used to resolve IBANs
+	bankCodeCollection *mongo.Collection
+	// National clearing system member ids to SWIFT codes
+	clearingCodeCollection *mongo.Collection
+	// SWIFT codes to the LEIs of their owners
+	leiMappingCollection *mongo.Collection
+	// Scheduled and applied changes of the bank statuses
+	statusChangeCollection *mongo.Collection
+	// Correspondent account relationships per currency
+	correspondentCollection *mongo.Collection
+	// Reachability of the banks through the payment schemes
+	schemeParticipationCollection *mongo.Collection
+	// Entries of the imported sanctions lists
+	sanctionEntryCollection *mongo.Collection
+	// Last import of every sanctions list
+	sanctionListCollection *mongo.Collection
+	// Banks matching the sanctions lists
+	screeningHitCollection *mongo.Collection
 }
 
 // NewMongoRepository creates a new MongoRepository instance
@@ -59,10 +95,19 @@ func NewMongoRepository(uri, dbName, bankCollectionName, countriesCollectionName
 	countriesCollection := GetMongoCollection(db, countriesCollectionName)
 
 	return &MongoRepository{
-		client:            client,
-		database:          db,
-		bankCollection:    bankCollection,
-		countryCollection: countriesCollection,
+		client:                        client,
+		database:                      db,
+		bankCollection:                bankCollection,
+		countryCollection:             countriesCollection,
+		bankCodeCollection:            GetMongoCollection(db, BankCodesCollectionName),
+		clearingCodeCollection:        GetMongoCollection(db, ClearingCodesCollectionName),
+		leiMappingCollection:          GetMongoCollection(db, LEIMappingsCollectionName),
+		statusChangeCollection:        GetMongoCollection(db, StatusChangesCollectionName),
+		correspondentCollection:       GetMongoCollection(db, CorrespondentsCollectionName),
+		schemeParticipationCollection: GetMongoCollection(db, SchemeParticipationsCollectionName),
+		sanctionEntryCollection:       GetMongoCollection(db, SanctionEntriesCollectionName),
+		sanctionListCollection:        GetMongoCollection(db, SanctionListsCollectionName),
+		screeningHitCollection:        GetMongoCollection(db, ScreeningHitsCollectionName),
 	}, nil
 }
 
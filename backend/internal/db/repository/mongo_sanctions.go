@@ -0,0 +1,184 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/Hbrtjm/SWIFT_API/backend/internal/db/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func (r *MongoRepository) SanctionEntriesCollection() *mongo.Collection {
+	return r.sanctionEntryCollection
+}
+
+func (r *MongoRepository) SanctionListsCollection() *mongo.Collection {
+	return r.sanctionListCollection
+}
+
+func (r *MongoRepository) ScreeningHitsCollection() *mongo.Collection {
+	return r.screeningHitCollection
+}
+
+// ReplaceSanctionList replaces the entries of the list with the imported ones and records the import. The entries are
+// tagged with the load time of the list, the new ones are inserted before the older ones are deleted, so that a failed
+// import keeps the previous entries
+func (r *MongoRepository) ReplaceSanctionList(list models.SanctionList, entries []models.SanctionEntry) error {
+	// The lists hold tens of thousands of entries
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	if len(entries) > 0 {
+		data := make([]interface{}, len(entries))
+		for i := range entries {
+			data[i] = entries[i]
+		}
+		if _, err := r.sanctionEntryCollection.InsertMany(ctx, data, options.InsertMany().SetOrdered(false)); err != nil {
+			return fmt.Errorf("database error: %w", err)
+		}
+	}
+	filter := bson.M{"list": list.List, "loadedAt": bson.M{"$ne": list.LoadedAt}}
+	if _, err := r.sanctionEntryCollection.DeleteMany(ctx, filter); err != nil {
+		return fmt.Errorf("database delete error: %w", err)
+	}
+
+	_, err := r.sanctionListCollection.ReplaceOne(ctx, bson.M{"list": list.List}, list, options.Replace().SetUpsert(true))
+	if err != nil {
+		return fmt.Errorf("database error recording the list: %w", err)
+	}
+	return nil
+}
+
+// FindSanctionEntries lists the entries of every list
+func (r *MongoRepository) FindSanctionEntries() ([]models.SanctionEntry, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	cursor, err := r.sanctionEntryCollection.Find(ctx, bson.M{})
+	if err != nil {
+		return nil, fmt.Errorf("database error retrieving sanction entries: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	entries := []models.SanctionEntry{}
+	if err = cursor.All(ctx, &entries); err != nil {
+		return nil, fmt.Errorf("database error retrieving sanction entries: %w", err)
+	}
+	return entries, nil
+}
+
+// FindSanctionLists lists the imported lists sorted by the name
+func (r *MongoRepository) FindSanctionLists() ([]models.SanctionList, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 6*time.Second)
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{{Key: "list", Value: 1}})
+	cursor, err := r.sanctionListCollection.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("database error retrieving sanction lists: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	lists := []models.SanctionList{}
+	if err = cursor.All(ctx, &lists); err != nil {
+		return nil, fmt.Errorf("database error retrieving sanction lists: %w", err)
+	}
+	return lists, nil
+}
+
+// ReplaceScreeningHits replaces every hit with the hits of a screening of the whole directory. The new hits are
+// inserted before the older ones are deleted, so that the hits never disappear during the screening
+func (r *MongoRepository) ReplaceScreeningHits(hits []models.ScreeningHit, screenedAt time.Time) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	if err := r.insertScreeningHits(ctx, hits); err != nil {
+		return err
+	}
+	if _, err := r.screeningHitCollection.DeleteMany(ctx, bson.M{"screenedAt": bson.M{"$ne": screenedAt}}); err != nil {
+		return fmt.Errorf("database delete error: %w", err)
+	}
+	return nil
+}
+
+// ReplaceScreeningHitsOf replaces the hits of a single bank
+func (r *MongoRepository) ReplaceScreeningHitsOf(swiftCode string, hits []models.ScreeningHit) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 6*time.Second)
+	defer cancel()
+
+	if _, err := r.screeningHitCollection.DeleteMany(ctx, bson.M{"swiftCode": swiftCode}); err != nil {
+		return fmt.Errorf("database delete error: %w", err)
+	}
+	return r.insertScreeningHits(ctx, hits)
+}
+
+func (r *MongoRepository) insertScreeningHits(ctx context.Context, hits []models.ScreeningHit) error {
+	if len(hits) == 0 {
+		return nil
+	}
+
+	data := make([]interface{}, len(hits))
+	for i := range hits {
+		data[i] = hits[i]
+	}
+	if _, err := r.screeningHitCollection.InsertMany(ctx, data, options.InsertMany().SetOrdered(false)); err != nil {
+		return fmt.Errorf("database error: %w", err)
+	}
+	return nil
+}
+
+// DeleteScreeningHitsByPrefix deletes the hits of the SWIFT codes starting with the prefix, an 8 character prefix
+// covers a headquarter and its branches
+func (r *MongoRepository) DeleteScreeningHitsByPrefix(prefix string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 6*time.Second)
+	defer cancel()
+
+	filter := bson.M{"swiftCode": bson.M{"$regex": "^" + regexp.QuoteMeta(prefix)}}
+	if _, err := r.screeningHitCollection.DeleteMany(ctx, filter); err != nil {
+		return fmt.Errorf("database delete error: %w", err)
+	}
+	return nil
+}
+
+// FindScreeningHitsBySwiftCode lists the hits of a bank, the best first
+func (r *MongoRepository) FindScreeningHitsBySwiftCode(swiftCode string) ([]models.ScreeningHit, error) {
+	return r.findScreeningHits(bson.M{"swiftCode": swiftCode})
+}
+
+// FindScreeningHits lists the hits filtered by the list, the country and the match type, the empty filters are
+// ignored
+func (r *MongoRepository) FindScreeningHits(list, countryISO2, matchType string) ([]models.ScreeningHit, error) {
+	filter := bson.M{}
+	if list != "" {
+		filter["list"] = list
+	}
+	if countryISO2 != "" {
+		filter["countryISO2"] = countryISO2
+	}
+	if matchType != "" {
+		filter["matchType"] = matchType
+	}
+	return r.findScreeningHits(filter)
+}
+
+func (r *MongoRepository) findScreeningHits(filter bson.M) ([]models.ScreeningHit, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{{Key: "swiftCode", Value: 1}, {Key: "score", Value: -1}, {Key: "list", Value: 1}, {Key: "entryId", Value: 1}})
+	cursor, err := r.screeningHitCollection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("database error retrieving screening hits: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	hits := []models.ScreeningHit{}
+	if err = cursor.All(ctx, &hits); err != nil {
+		return nil, fmt.Errorf("database error retrieving screening hits: %w", err)
+	}
+	return hits, nil
+}
@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Hbrtjm/SWIFT_API/backend/internal/db/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func (r *MongoRepository) SchemeParticipationsCollection() *mongo.Collection {
+	return r.schemeParticipationCollection
+}
+
+// UpsertManySchemeParticipations inserts the participations, replacing the adherence date of the existing ones
+func (r *MongoRepository) UpsertManySchemeParticipations(participations []models.SchemeParticipation) error {
+	if len(participations) == 0 {
+		return nil
+	}
+
+	writes := make([]mongo.WriteModel, len(participations))
+	for i, participation := range participations {
+		filter := bson.M{"swiftCode": participation.SwiftCode, "scheme": participation.Scheme}
+		writes[i] = mongo.NewReplaceOneModel().SetFilter(filter).SetReplacement(participation).SetUpsert(true)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	_, err := r.schemeParticipationCollection.BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false))
+	if err != nil {
+		return fmt.Errorf("database error: %w", err)
+	}
+	return nil
+}
+
+// FindSchemeParticipations lists the participations of the SWIFT codes sorted by the scheme
+func (r *MongoRepository) FindSchemeParticipations(swiftCodes ...string) ([]models.SchemeParticipation, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 6*time.Second)
+	defer cancel()
+
+	filter := bson.M{"swiftCode": bson.M{"$in": swiftCodes}}
+	opts := options.Find().SetSort(bson.D{{Key: "scheme", Value: 1}, {Key: "swiftCode", Value: 1}})
+
+	cursor, err := r.schemeParticipationCollection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("database error retrieving scheme participations: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	results := []models.SchemeParticipation{}
+	if err = cursor.All(ctx, &results); err != nil {
+		return nil, fmt.Errorf("database error retrieving scheme participations: %w", err)
+	}
+	return results, nil
+}
+
+// FindSchemeParticipants lists the SWIFT codes adhering to the scheme at the time
+func (r *MongoRepository) FindSchemeParticipants(scheme string, at time.Time) ([]string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	filter := bson.M{"scheme": scheme, "adherenceDate": bson.M{"$lte": at}}
+	values, err := r.schemeParticipationCollection.Distinct(ctx, "swiftCode", filter)
+	if err != nil {
+		return nil, fmt.Errorf("database error retrieving scheme participants: %w", err)
+	}
+
+	swiftCodes := make([]string, 0, len(values))
+	for _, value := range values {
+		if swiftCode, ok := value.(string); ok {
+			swiftCodes = append(swiftCodes, swiftCode)
+		}
+	}
+	return swiftCodes, nil
+}
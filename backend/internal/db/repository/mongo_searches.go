@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"regexp"
 	"time"
 
 	"github.com/Hbrtjm/SWIFT_API/backend/internal/db/models"
@@ -14,7 +15,7 @@ import (
 )
 
 // FindBySwiftCode finds a document by SWIFT code
-// FindBySwiftCode finds a bank by SWIFT code
+// FindBySwiftCode finds a bank by SWIFT code, a missing bank is ErrBankNotFound
 func (r *MongoRepository) FindBySwiftCode(swiftCode string) (models.Bank, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 6*time.Second)
 	defer cancel()
@@ -24,13 +25,32 @@ func (r *MongoRepository) FindBySwiftCode(swiftCode string) (models.Bank, error)
 	err := r.bankCollection.FindOne(ctx, filter).Decode(&bank)
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
-			return models.Bank{}, fmt.Errorf("no bank found with SWIFT code %s", swiftCode)
+			return models.Bank{}, fmt.Errorf("no bank found with SWIFT code %s: %w", swiftCode, ErrBankNotFound)
 		}
-		return models.Bank{}, err
+		return models.Bank{}, fmt.Errorf("database error retrieving bank: %w", err)
 	}
 	return bank, nil
 }
 
+// FindBySwiftCodes finds the banks of the SWIFT codes with a single query, the codes without a bank are left out
+func (r *MongoRepository) FindBySwiftCodes(swiftCodes ...string) ([]models.Bank, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 6*time.Second)
+	defer cancel()
+
+	filter := bson.M{"swiftCode": bson.M{"$in": swiftCodes}}
+	cursor, err := r.bankCollection.Find(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("database error retrieving banks: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	banks := []models.Bank{}
+	if err = cursor.All(ctx, &banks); err != nil {
+		return nil, fmt.Errorf("database error retrieving banks: %w", err)
+	}
+	return banks, nil
+}
+
 func (r *MongoRepository) FindByBranchCode(branchCode string) ([]map[string]interface{}, error) {
 	if r == nil {
 		return nil, errors.New("repository is nil")
@@ -95,6 +115,68 @@ func (r *MongoRepository) FindByCountry(countryISO2 string) ([]models.Bank, erro
 	return banks, nil
 }
 
+// FindByNamePrefixes finds at most limit banks with a word of the name or of the town starting with one of the
+// prefixes, in a single country unless it's empty. The banks sharing the most prefixes come first, so that a prefix
+// common to many banks doesn't crowd out the ones matching the whole name. Without prefixes all of the banks of the
+// country are found. The banks stored without the prefixes are found as well, after the matching ones
+func (r *MongoRepository) FindByNamePrefixes(countryISO2 string, prefixes []string, limit int64) ([]models.Bank, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	filter := bson.M{}
+	if countryISO2 != "" {
+		filter["countryISO2"] = countryISO2
+	}
+	if prefixes == nil {
+		prefixes = []string{}
+	}
+	if len(prefixes) > 0 {
+		filter["$or"] = bson.A{
+			bson.M{"namePrefixes": bson.M{"$in": prefixes}},
+			bson.M{"namePrefixes": bson.M{"$exists": false}},
+		}
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: filter}},
+		{{Key: "$addFields", Value: bson.M{"matchedPrefixes": bson.M{"$size": bson.M{"$setIntersection": bson.A{
+			bson.M{"$ifNull": bson.A{"$namePrefixes", bson.A{}}}, prefixes,
+		}}}}}},
+		{{Key: "$sort", Value: bson.D{{Key: "matchedPrefixes", Value: -1}, {Key: "swiftCode", Value: 1}}}},
+		{{Key: "$limit", Value: limit}},
+	}
+	cursor, err := r.bankCollection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("database error retrieving banks: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	banks := []models.Bank{}
+	if err = cursor.All(ctx, &banks); err != nil {
+		return nil, fmt.Errorf("database error retrieving banks: %w", err)
+	}
+	return banks, nil
+}
+
+// FindAllBanks lists every bank of the directory sorted by the SWIFT code
+func (r *MongoRepository) FindAllBanks() ([]models.Bank, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{{Key: "swiftCode", Value: 1}})
+	cursor, err := r.bankCollection.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("database error retrieving banks: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	banks := []models.Bank{}
+	if err = cursor.All(ctx, &banks); err != nil {
+		return nil, fmt.Errorf("database error retrieving banks: %w", err)
+	}
+	return banks, nil
+}
+
 // Count returns the total number of documents in the collection
 func (r *MongoRepository) Count() (int64, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 6*time.Second)
@@ -164,3 +246,101 @@ func (r *MongoRepository) CountryExists(countryISO2 string) (bool, error) {
 
 	return count > 0, nil
 }
+
+// FindHeadquartersByInstitution finds the headquarters whose SWIFT code starts with the institution and the country code
+func (r *MongoRepository) FindHeadquartersByInstitution(institutionCode, countryISO2 string) ([]models.Bank, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 6*time.Second)
+	defer cancel()
+
+	// An anchored prefix can use the swiftCode index
+	prefix := regexp.QuoteMeta(institutionCode + countryISO2)
+	filter := bson.M{"swiftCode": bson.M{"$regex": "^" + prefix}, "isHeadquarter": true}
+	opts := options.Find().SetSort(bson.D{{Key: "swiftCode", Value: 1}})
+
+	cursor, err := r.bankCollection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var banks []models.Bank
+	if err = cursor.All(ctx, &banks); err != nil {
+		return nil, err
+	}
+	return banks, nil
+}
+
+// FindByInstitutionCode finds all banks of an institution, sorted by the SWIFT code
+func (r *MongoRepository) FindByInstitutionCode(institutionCode string) ([]models.Bank, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	// Banks inserted before the institution code was stored are matched by the prefix of their code
+	filter := bson.M{"$or": bson.A{
+		bson.M{"institutionCode": institutionCode},
+		bson.M{"institutionCode": bson.M{"$exists": false}, "swiftCode": bson.M{"$regex": "^" + regexp.QuoteMeta(institutionCode)}},
+	}}
+	opts := options.Find().SetSort(bson.D{{Key: "swiftCode", Value: 1}})
+
+	cursor, err := r.bankCollection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	banks := []models.Bank{}
+	if err = cursor.All(ctx, &banks); err != nil {
+		return nil, err
+	}
+	return banks, nil
+}
+
+// FindBranchesPage finds the branches of a BIC8 whose codes follow the given code, sorted by the code.
+// An empty code starts from the first branch
+func (r *MongoRepository) FindBranchesPage(bic8, after string, limit int) ([]models.Bank, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 6*time.Second)
+	defer cancel()
+
+	filter := bson.M{
+		"branchCode": bic8,
+		"swiftCode":  bson.M{"$gt": after, "$ne": bic8 + "XXX"},
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "swiftCode", Value: 1}}).SetLimit(int64(limit))
+
+	cursor, err := r.bankCollection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	banks := []models.Bank{}
+	if err = cursor.All(ctx, &banks); err != nil {
+		return nil, err
+	}
+	return banks, nil
+}
+
+// FindByCountriesPage finds the banks of the countries whose codes follow the given code, sorted by the code.
+// An empty code starts from the first bank
+func (r *MongoRepository) FindByCountriesPage(countries []string, after string, limit int) ([]models.Bank, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{
+		"countryISO2": bson.M{"$in": countries},
+		"swiftCode":   bson.M{"$gt": after},
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "swiftCode", Value: 1}}).SetLimit(int64(limit))
+
+	cursor, err := r.bankCollection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	banks := []models.Bank{}
+	if err = cursor.All(ctx, &banks); err != nil {
+		return nil, err
+	}
+	return banks, nil
+}
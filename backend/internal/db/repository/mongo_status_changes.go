@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Hbrtjm/SWIFT_API/backend/internal/db/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func (r *MongoRepository) StatusChangesCollection() *mongo.Collection {
+	return r.statusChangeCollection
+}
+
+// UpdateBankStatus sets the status of a bank, an empty successor removes the successor
+func (r *MongoRepository) UpdateBankStatus(swiftCode, status, successorSwiftCode string, effectiveDate time.Time) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 6*time.Second)
+	defer cancel()
+
+	update := bson.M{"$set": bson.M{"status": status, "statusEffectiveDate": effectiveDate}}
+	if successorSwiftCode != "" {
+		update["$set"].(bson.M)["successorSwiftCode"] = successorSwiftCode
+	} else {
+		update["$unset"] = bson.M{"successorSwiftCode": ""}
+	}
+
+	result, err := r.bankCollection.UpdateOne(ctx, bson.M{"swiftCode": swiftCode}, update)
+	if err != nil {
+		return fmt.Errorf("database error updating status: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return ErrBankNotFound
+	}
+	return nil
+}
+
+// UpsertStatusChange schedules a status change, replacing the change of the bank with the same effective date
+func (r *MongoRepository) UpsertStatusChange(change models.StatusChange) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 6*time.Second)
+	defer cancel()
+
+	filter := bson.M{"swiftCode": change.SwiftCode, "effectiveDate": change.EffectiveDate}
+	_, err := r.statusChangeCollection.ReplaceOne(ctx, filter, change, options.Replace().SetUpsert(true))
+	if err != nil {
+		return fmt.Errorf("database error scheduling status change: %w", err)
+	}
+	return nil
+}
+
+// FindPendingStatusChanges lists the changes of a bank which weren't applied yet, the earliest first
+func (r *MongoRepository) FindPendingStatusChanges(swiftCode string) ([]models.StatusChange, error) {
+	return r.findStatusChanges(bson.M{"swiftCode": swiftCode, "appliedAt": bson.M{"$exists": false}})
+}
+
+// FindDueStatusChanges lists the changes which weren't applied and take effect before the time, the earliest first
+func (r *MongoRepository) FindDueStatusChanges(before time.Time) ([]models.StatusChange, error) {
+	return r.findStatusChanges(bson.M{"effectiveDate": bson.M{"$lte": before}, "appliedAt": bson.M{"$exists": false}})
+}
+
+func (r *MongoRepository) findStatusChanges(filter bson.M) ([]models.StatusChange, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 6*time.Second)
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{{Key: "effectiveDate", Value: 1}, {Key: "swiftCode", Value: 1}})
+	cursor, err := r.statusChangeCollection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("database error retrieving status changes: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	changes := []models.StatusChange{}
+	if err = cursor.All(ctx, &changes); err != nil {
+		return nil, fmt.Errorf("database error retrieving status changes: %w", err)
+	}
+	return changes, nil
+}
+
+// MarkStatusChangeApplied records the time the change was applied at
+func (r *MongoRepository) MarkStatusChangeApplied(change models.StatusChange, appliedAt time.Time) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 6*time.Second)
+	defer cancel()
+
+	filter := bson.M{"swiftCode": change.SwiftCode, "effectiveDate": change.EffectiveDate}
+	_, err := r.statusChangeCollection.UpdateOne(ctx, filter, bson.M{"$set": bson.M{"appliedAt": appliedAt}})
+	if err != nil {
+		return fmt.Errorf("database error updating status change: %w", err)
+	}
+	return nil
+}
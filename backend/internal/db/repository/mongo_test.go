@@ -187,6 +187,7 @@ func TestFindBySwiftCode(t *testing.T) {
 	assert.Error(t, err)
 	assert.Equal(t, models.Bank{}, result)
 	assert.Contains(t, err.Error(), "no bank found with SWIFT code NONEXISTENT")
+	assert.ErrorIs(t, err, ErrBankNotFound)
 }
 
 // TestFindByCountry tests finding documents by country code
@@ -238,4 +239,5 @@ func TestDelete(t *testing.T) {
 	err = repo.Delete("NONEXISTENT")
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "not found")
+	assert.ErrorIs(t, err, ErrBankNotFound)
 }
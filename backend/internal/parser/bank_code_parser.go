@@ -0,0 +1,54 @@
+package parser
+
+import (
+	"encoding/csv"
+	"os"
+	"strings"
+
+	"github.com/Hbrtjm/SWIFT_API/backend/internal/db/models"
+	"github.com/Hbrtjm/SWIFT_API/backend/pkg/clearing"
+)
+
+// ParseBankCodeFile parses a CSV file mapping the national bank codes to SWIFT codes.
+// Expected headers: COUNTRY ISO2 CODE;BANK CODE;SWIFT CODE, rows with a malformed SWIFT code are skipped
+func (p *SwiftFileParser) ParseBankCodeFile(filename string) ([]models.BankCode, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.Comma = ';'
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(records) < 2 {
+		return []models.BankCode{}, nil
+	}
+
+	headerMap := make(map[string]int)
+	for i, header := range records[0] {
+		headerMap[strings.ToUpper(strings.TrimSpace(header))] = i
+	}
+
+	results := make([]models.BankCode, 0, len(records)-1)
+	for _, record := range records[1:] {
+		bankCode := models.BankCode{
+			CountryISO2: strings.ToUpper(getFieldValue(record, headerMap, "COUNTRY ISO2 CODE")),
+			BankCode:    clearing.Normalize(getFieldValue(record, headerMap, "BANK CODE")),
+			SwiftCode:   strings.ToUpper(getFieldValue(record, headerMap, "SWIFT CODE")),
+		}
+		if bankCode.CountryISO2 == "" || bankCode.BankCode == "" {
+			continue
+		}
+		if _, err := p.bicAnalyzer.Analyze(bankCode.SwiftCode); err != nil {
+			continue
+		}
+		results = append(results, bankCode)
+	}
+
+	return results, nil
+}
@@ -0,0 +1,62 @@
+package parser
+
+import (
+	"encoding/csv"
+	"os"
+	"strings"
+
+	"github.com/Hbrtjm/SWIFT_API/backend/internal/db/models"
+	"github.com/Hbrtjm/SWIFT_API/backend/pkg/clearing"
+)
+
+// ParseClearingCodeFile parses a CSV file linking the clearing system member ids to SWIFT codes.
+// Expected headers: CLEARING SYSTEM;MEMBER ID;SWIFT CODE. The system is either the ISO 20022 code (GBDSC, USABA, DEBLZ)
+// or its alias (SORTCODE, ABA, BLZ). Rows with an unknown system, a malformed member id or SWIFT code are skipped
+func (p *SwiftFileParser) ParseClearingCodeFile(filename string) ([]models.ClearingCode, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.Comma = ';'
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(records) < 2 {
+		return []models.ClearingCode{}, nil
+	}
+
+	headerMap := make(map[string]int)
+	for i, header := range records[0] {
+		headerMap[strings.ToUpper(strings.TrimSpace(header))] = i
+	}
+
+	results := make([]models.ClearingCode, 0, len(records)-1)
+	for _, record := range records[1:] {
+		system, ok := clearing.LookupSystem(getFieldValue(record, headerMap, "CLEARING SYSTEM"))
+		if !ok {
+			continue
+		}
+		memberID, err := system.Validate(getFieldValue(record, headerMap, "MEMBER ID"))
+		if err != nil {
+			continue
+		}
+
+		swiftCode, ok := p.parseSwiftCode(getFieldValue(record, headerMap, "SWIFT CODE"))
+		if !ok {
+			continue
+		}
+
+		results = append(results, models.ClearingCode{
+			ClearingSystem: system.Code,
+			MemberID:       memberID,
+			SwiftCode:      swiftCode,
+		})
+	}
+
+	return results, nil
+}
@@ -0,0 +1,61 @@
+package parser
+
+import (
+	"encoding/csv"
+	"os"
+	"strings"
+
+	"github.com/Hbrtjm/SWIFT_API/backend/internal/db/models"
+	"github.com/Hbrtjm/SWIFT_API/backend/pkg/iso4217"
+)
+
+// ParseCorrespondentFile parses a CSV file of the correspondent account relationships.
+// Expected headers: SWIFT CODE;CORRESPONDENT SWIFT CODE;CURRENCY. Rows with a malformed SWIFT code, an unknown currency
+// or a bank being its own correspondent are skipped
+func (p *SwiftFileParser) ParseCorrespondentFile(filename string) ([]models.Correspondent, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.Comma = ';'
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(records) < 2 {
+		return []models.Correspondent{}, nil
+	}
+
+	headerMap := make(map[string]int)
+	for i, header := range records[0] {
+		headerMap[strings.ToUpper(strings.TrimSpace(header))] = i
+	}
+
+	results := make([]models.Correspondent, 0, len(records)-1)
+	for _, record := range records[1:] {
+		swiftCode, ok := p.parseSwiftCode(getFieldValue(record, headerMap, "SWIFT CODE"))
+		if !ok {
+			continue
+		}
+		correspondentSwiftCode, ok := p.parseSwiftCode(getFieldValue(record, headerMap, "CORRESPONDENT SWIFT CODE"))
+		if !ok || correspondentSwiftCode == swiftCode {
+			continue
+		}
+		currency, ok := iso4217.Lookup(getFieldValue(record, headerMap, "CURRENCY"))
+		if !ok {
+			continue
+		}
+
+		results = append(results, models.Correspondent{
+			SwiftCode:              swiftCode,
+			CorrespondentSwiftCode: correspondentSwiftCode,
+			Currency:               currency.Code,
+		})
+	}
+
+	return results, nil
+}
@@ -0,0 +1,85 @@
+package parser
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/Hbrtjm/SWIFT_API/backend/internal/db/models"
+	"github.com/Hbrtjm/SWIFT_API/backend/pkg/lei"
+)
+
+// ParseLEIMappingFile streams the GLEIF BIC-to-LEI relationship file (headers LEI,BIC) and hands the mappings over
+// in batches of batchSize, so the file is never held in memory. Semicolon separated files with the same headers are
+// accepted as well. Rows with an invalid LEI or BIC are skipped, the number of the parsed mappings is returned
+func (p *SwiftFileParser) ParseLEIMappingFile(filename string, batchSize int, handle func([]models.LEIMapping) error) (int, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+
+	buffered := bufio.NewReader(file)
+	reader := csv.NewReader(buffered)
+	// The separator is told by the header line, peeked without consuming it
+	peeked, _ := buffered.Peek(4096)
+	header, _, _ := bytes.Cut(peeked, []byte("\n"))
+	if bytes.Count(header, []byte(";")) > bytes.Count(header, []byte(",")) {
+		reader.Comma = ';'
+	}
+
+	headers, err := reader.Read()
+	if err == io.EOF {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	headerMap := make(map[string]int)
+	for i, header := range headers {
+		// The GLEIF file starts with a byte order mark
+		headerMap[strings.ToUpper(strings.TrimSpace(strings.TrimPrefix(header, "\ufeff")))] = i
+	}
+
+	count := 0
+	batch := make([]models.LEIMapping, 0, batchSize)
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return count, err
+		}
+
+		validLEI, err := lei.Validate(getFieldValue(record, headerMap, "LEI"))
+		if err != nil {
+			continue
+		}
+
+		swiftCode, ok := p.parseSwiftCode(getFieldValue(record, headerMap, "BIC"))
+		if !ok {
+			continue
+		}
+
+		batch = append(batch, models.LEIMapping{SwiftCode: swiftCode, LEI: validLEI})
+		if len(batch) == batchSize {
+			if err := handle(batch); err != nil {
+				return count, err
+			}
+			count += len(batch)
+			batch = batch[:0]
+		}
+	}
+
+	if len(batch) > 0 {
+		if err := handle(batch); err != nil {
+			return count, err
+		}
+		count += len(batch)
+	}
+	return count, nil
+}
@@ -0,0 +1,92 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Hbrtjm/SWIFT_API/backend/internal/db/models"
+	"github.com/Hbrtjm/SWIFT_API/backend/pkg/sanctions"
+)
+
+// Files published by OFAC along with sdn.csv, read from the same directory when present
+const (
+	ofacAliasesFile   = "alt.csv"
+	ofacAddressesFile = "add.csv"
+)
+
+// ParseSanctionsFile parses a sanctions list, the OFAC SDN file (sdn.csv) or the EU consolidated list (XML). The BICs
+// which aren't valid are dropped, the 8 character BICs are kept as they cover the branches
+func (p *SwiftFileParser) ParseSanctionsFile(list, filename string) ([]models.SanctionEntry, error) {
+	var entries []sanctions.Entry
+	var err error
+	switch list {
+	case sanctions.ListOFAC:
+		entries, err = p.parseOFAC(filename)
+	case sanctions.ListEU:
+		entries, err = parseEU(filename)
+	default:
+		return nil, fmt.Errorf("unknown sanctions list %s", list)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]models.SanctionEntry, 0, len(entries))
+	for _, entry := range entries {
+		bics := []string{}
+		for _, bic := range entry.BICs {
+			bic = strings.ToUpper(bic)
+			if _, err := p.bicAnalyzer.Analyze(bic); err == nil {
+				bics = append(bics, bic)
+			}
+		}
+		results = append(results, models.SanctionEntry{
+			List:      entry.List,
+			EntryID:   entry.ID,
+			Names:     entry.Names,
+			Type:      entry.Type,
+			Programs:  entry.Programs,
+			BICs:      bics,
+			Countries: entry.Countries,
+		})
+	}
+	return results, nil
+}
+
+func (p *SwiftFileParser) parseOFAC(filename string) ([]sanctions.Entry, error) {
+	sdn, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer sdn.Close()
+
+	companions := []io.Reader{}
+	for _, name := range []string{ofacAliasesFile, ofacAddressesFile} {
+		file, err := os.Open(filepath.Join(filepath.Dir(filename), name))
+		if errors.Is(err, os.ErrNotExist) {
+			companions = append(companions, nil)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		defer file.Close()
+		companions = append(companions, file)
+	}
+
+	return sanctions.ParseOFAC(sdn, companions[0], companions[1])
+}
+
+func parseEU(filename string) ([]sanctions.Entry, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return sanctions.ParseEU(file)
+}
@@ -0,0 +1,74 @@
+package parser
+
+import (
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Hbrtjm/SWIFT_API/backend/internal/db/models"
+	"github.com/Hbrtjm/SWIFT_API/backend/pkg/schemes"
+)
+
+// ParseSchemeParticipantFile parses a CSV file of the participants of the payment schemes.
+// Expected headers: SCHEME;SWIFT CODE;ADHERENCE DATE. Without the SCHEME column the file name (e.g. SCT_INST.csv) names
+// the scheme of every row. The adherence date is YYYY-MM-DD, an empty date means the bank has always been reachable.
+// Rows with an unknown scheme, a malformed SWIFT code or date are skipped
+func (p *SwiftFileParser) ParseSchemeParticipantFile(filename string) ([]models.SchemeParticipation, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.Comma = ';'
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(records) < 2 {
+		return []models.SchemeParticipation{}, nil
+	}
+
+	headerMap := make(map[string]int)
+	for i, header := range records[0] {
+		headerMap[strings.ToUpper(strings.TrimSpace(header))] = i
+	}
+	fileScheme := strings.TrimSuffix(filepath.Base(filename), filepath.Ext(filename))
+
+	results := make([]models.SchemeParticipation, 0, len(records)-1)
+	for _, record := range records[1:] {
+		schemeName := fileScheme
+		if _, exists := headerMap["SCHEME"]; exists {
+			schemeName = getFieldValue(record, headerMap, "SCHEME")
+		}
+		scheme, ok := schemes.Lookup(schemeName)
+		if !ok {
+			continue
+		}
+
+		swiftCode, ok := p.parseSwiftCode(getFieldValue(record, headerMap, "SWIFT CODE"))
+		if !ok {
+			continue
+		}
+
+		adherenceDate := time.Time{}
+		if value := getFieldValue(record, headerMap, "ADHERENCE DATE"); value != "" {
+			adherenceDate, err = time.Parse(time.DateOnly, value)
+			if err != nil {
+				continue
+			}
+		}
+
+		results = append(results, models.SchemeParticipation{
+			SwiftCode:     swiftCode,
+			Scheme:        scheme.Code,
+			AdherenceDate: adherenceDate,
+		})
+	}
+
+	return results, nil
+}
@@ -5,16 +5,24 @@ package parser
 import (
 	"encoding/csv"
 	"os"
+	"slices"
 	"strings"
 
 	"github.com/Hbrtjm/SWIFT_API/backend/internal/db/models"
+	"github.com/Hbrtjm/SWIFT_API/backend/pkg/address"
+	"github.com/Hbrtjm/SWIFT_API/backend/pkg/timezones"
+	"github.com/Hbrtjm/SWIFT_API/backend/pkg/validators"
 )
 
 // SwiftFileParser parses SWIFT code data
-type SwiftFileParser struct{}
+type SwiftFileParser struct {
+	bicAnalyzer *validators.BICAnalyzer
+}
 
 func NewSwiftFileParser() *SwiftFileParser {
-	return &SwiftFileParser{}
+	return &SwiftFileParser{
+		bicAnalyzer: validators.NewBICAnalyzer(),
+	}
 }
 
 // ParseFile parses a CSV file containing SWIFT codes and returns array of models.Bank and an array of countries, that is models.Country
@@ -63,29 +71,56 @@ func (p *SwiftFileParser) ParseFile(filename string) ([]models.Bank, []models.Co
 		}
 
 		countryISO2 := strings.ToUpper(getFieldValue(record, headerMap, "COUNTRY ISO2 CODE"))
-		countryName := strings.ToUpper(getFieldValue(record, headerMap, "COUNTRY NAME"))
+		countryName := getFieldValue(record, headerMap, "COUNTRY NAME")
+		townName := getFieldValue(record, headerMap, "TOWN NAME")
+
+		// The zone of the row is used when it's valid and used in the country of the row, otherwise it's inferred
+		// from the town
 		timeZone := getFieldValue(record, headerMap, "TIME ZONE")
+		if canonical, deprecated := timezones.Canonical(timeZone); deprecated {
+			timeZone = canonical
+		}
+		if !rowZoneValid(timeZone, countryISO2) {
+			timeZone = timezones.InferZone(countryISO2, townName)
+		}
 
 		// Add country to the map if it doesn't exist
 		if _, exists := countryMap[countryISO2]; !exists && countryISO2 != "" {
 			countryMap[countryISO2] = models.Country{
 				CountryISO2: countryISO2,
-				CountryName: countryName,
-				TimeZone:    timeZone,
+				CountryName: strings.ToUpper(countryName),
+				DisplayName: strings.TrimSpace(countryName),
+				TimeZones:   []string{},
 			}
 		}
 
+		// Collect every zone used by the banks of the country
+		if country, exists := countryMap[countryISO2]; exists && timeZone != "" && !slices.Contains(country.TimeZones, timeZone) {
+			country.TimeZones = append(country.TimeZones, timeZone)
+			countryMap[countryISO2] = country
+		}
+
 		bank := models.Bank{
-			CountryISO2: countryISO2,
-			SwiftCode:   swiftCode,
-			CodeType:    getFieldValue(record, headerMap, "CODE TYPE"),
-			BankName:    getFieldValue(record, headerMap, "NAME"),
-			Address:     getFieldValue(record, headerMap, "ADDRESS"),
-			TownName:    getFieldValue(record, headerMap, "TOWN NAME"),
+			CountryISO2:   countryISO2,
+			SwiftCode:     swiftCode,
+			CodeType:      getFieldValue(record, headerMap, "CODE TYPE"),
+			BankName:      getFieldValue(record, headerMap, "NAME"),
+			Address:       getFieldValue(record, headerMap, "ADDRESS"),
+			TownName:      townName,
+			TimeZone:      timeZone,
 			IsHeadquarter: isHeadquarter,
 			BranchCode:    branchCode,
 		}
 
+		bank.StructuredAddress = address.Parse(bank.Address, townName, countryISO2)
+
+		// Rows with a malformed code are still loaded, just without the derived flags
+		if analysis, err := p.bicAnalyzer.Analyze(swiftCode); err == nil {
+			bank.InstitutionCode = analysis.InstitutionCode
+			bank.IsTestAndTraining = analysis.IsTestAndTraining
+			bank.IsPassiveParticipant = analysis.IsPassiveParticipant
+		}
+
 		bankResults = append(bankResults, bank)
 	}
 
@@ -104,3 +139,20 @@ func getFieldValue(record []string, headerMap map[string]int, fieldName string)
 	}
 	return ""
 }
+
+// rowZoneValid checks the time zone of a row, the countries missing from the tz tables cannot be checked
+func rowZoneValid(timeZone, countryISO2 string) bool {
+	if _, err := timezones.Load(timeZone); err != nil {
+		return false
+	}
+	return len(timezones.ZonesForCountry(countryISO2)) == 0 || timezones.BelongsToCountry(timeZone, countryISO2)
+}
+
+// parseSwiftCode validates the code and returns it in the 11 character form the banks are stored with
+func (p *SwiftFileParser) parseSwiftCode(value string) (string, bool) {
+	swiftCode := validators.NormalizeSwiftCode(value)
+	if _, err := p.bicAnalyzer.Analyze(swiftCode); err != nil {
+		return "", false
+	}
+	return swiftCode, true
+}
@@ -0,0 +1,32 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Hbrtjm/SWIFT_API/backend/pkg/validators"
+)
+
+// SwiftCodeAnalysisResponse is the structural analysis of a code along with its directory status
+type SwiftCodeAnalysisResponse struct {
+	*validators.BICAnalysis
+	InDirectory bool `json:"inDirectory"`
+}
+
+// AnalyzeSwiftCode decomposes a SWIFT code, it doesn't require the code to be present in the database
+func (s *SwiftCodeService) AnalyzeSwiftCode(code string) (*SwiftCodeAnalysisResponse, error) {
+	code = strings.ToUpper(code)
+
+	analysis, err := validators.NewBICAnalyzer().Analyze(code)
+	if err != nil {
+		return nil, fmt.Errorf("invalid SWIFT code %s: %v", code, err)
+	}
+
+	// A lookup failure only means that the code is not registered
+	_, err = s.repo.FindBySwiftCode(code)
+
+	return &SwiftCodeAnalysisResponse{
+		BICAnalysis: analysis,
+		InDirectory: err == nil,
+	}, nil
+}
@@ -0,0 +1,248 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Hbrtjm/SWIFT_API/backend/internal/db/models"
+	"github.com/Hbrtjm/SWIFT_API/backend/internal/db/repository"
+)
+
+var (
+	ErrInvalidBankStatus     = errors.New("invalid bank status")
+	ErrInvalidEffectiveDate  = errors.New("invalid effective date")
+	ErrInvalidSuccessorSwift = errors.New("invalid successor SWIFT code")
+)
+
+// BankStatusRequest changes the status of a bank, the change is scheduled when the effective date is in the future
+type BankStatusRequest struct {
+	Status             string `json:"status"`
+	SuccessorSwiftCode string `json:"successorSwiftCode"`
+	// YYYY-MM-DD or RFC 3339, empty for an immediate change
+	EffectiveDate string `json:"effectiveDate"`
+}
+
+// BankStatusResponse is the current status of a bank with the changes waiting for their effective date
+type BankStatusResponse struct {
+	SwiftCode           string              `json:"swiftCode"`
+	Status              string              `json:"status"`
+	StatusEffectiveDate *time.Time          `json:"statusEffectiveDate,omitempty"`
+	SuccessorSwiftCode  string              `json:"successorSwiftCode,omitempty"`
+	PendingChanges      []StatusChangeEntry `json:"pendingChanges"`
+	Links               Links               `json:"links"`
+}
+
+// StatusChangeEntry is a scheduled change of the status
+type StatusChangeEntry struct {
+	Status             string    `json:"status"`
+	SuccessorSwiftCode string    `json:"successorSwiftCode,omitempty"`
+	EffectiveDate      time.Time `json:"effectiveDate"`
+}
+
+// IsBankStatus checks that the status is one of active, inactive, retired or merged
+func IsBankStatus(status string) bool {
+	switch status {
+	case models.BankStatusActive, models.BankStatusInactive, models.BankStatusRetired, models.BankStatusMerged:
+		return true
+	}
+	return false
+}
+
+// statusOf returns the status of the bank, the banks without a status are active
+func statusOf(bank *models.Bank) string {
+	if bank.Status == "" {
+		return models.BankStatusActive
+	}
+	return bank.Status
+}
+
+// successorOf returns the successor of a retired or merged bank
+func successorOf(bank *models.Bank) string {
+	if bank.Status == models.BankStatusRetired || bank.Status == models.BankStatusMerged {
+		return bank.SuccessorSwiftCode
+	}
+	return ""
+}
+
+// GetBankStatus returns the status of the bank and its pending changes
+func (s *SwiftCodeService) GetBankStatus(code string) (*BankStatusResponse, error) {
+	bank, err := s.findBank(code)
+	if err != nil {
+		return nil, err
+	}
+
+	pending, err := s.repo.FindPendingStatusChanges(bank.SwiftCode)
+	if err != nil {
+		return nil, fmt.Errorf("error finding status changes: %w", err)
+	}
+
+	response := &BankStatusResponse{
+		SwiftCode:          bank.SwiftCode,
+		Status:             statusOf(&bank),
+		SuccessorSwiftCode: successorOf(&bank),
+		PendingChanges:     make([]StatusChangeEntry, 0, len(pending)),
+		Links:              s.bankLinks(bank.SwiftCode, bank.CountryISO2, bank.IsHeadquarter),
+	}
+	if bank.StatusEffectiveDate != nil {
+		effectiveDate := bank.StatusEffectiveDate.UTC()
+		response.StatusEffectiveDate = &effectiveDate
+	}
+	if response.SuccessorSwiftCode != "" {
+		response.Links.Successor = s.apiPath("/swift-codes/%s", response.SuccessorSwiftCode)
+	}
+	for _, change := range pending {
+		response.PendingChanges = append(response.PendingChanges, StatusChangeEntry{
+			Status:             change.Status,
+			SuccessorSwiftCode: change.SuccessorSwiftCode,
+			EffectiveDate:      change.EffectiveDate.UTC(),
+		})
+	}
+	return response, nil
+}
+
+// SetBankStatus changes the status of the bank at the effective date. A change effective now or in the past is
+// applied at once, a future change is scheduled and true is returned
+func (s *SwiftCodeService) SetBankStatus(code string, request BankStatusRequest, now time.Time) (*BankStatusResponse, bool, error) {
+	bank, err := s.findBank(code)
+	if err != nil {
+		return nil, false, err
+	}
+
+	change, err := s.newStatusChange(bank.SwiftCode, request, now)
+	if err != nil {
+		return nil, false, err
+	}
+
+	scheduled := change.EffectiveDate.After(now)
+	if !scheduled {
+		if err := s.applyStatusChange(change, now); err != nil {
+			return nil, false, err
+		}
+	} else if err := s.repo.UpsertStatusChange(change); err != nil {
+		return nil, false, err
+	}
+
+	response, err := s.GetBankStatus(bank.SwiftCode)
+	return response, scheduled, err
+}
+
+// newStatusChange validates the request
+func (s *SwiftCodeService) newStatusChange(swiftCode string, request BankStatusRequest, now time.Time) (models.StatusChange, error) {
+	status := strings.ToLower(strings.TrimSpace(request.Status))
+	if !IsBankStatus(status) {
+		return models.StatusChange{}, fmt.Errorf("%w: %q, must be one of active, inactive, retired or merged", ErrInvalidBankStatus, request.Status)
+	}
+
+	successor := strings.ToUpper(strings.TrimSpace(request.SuccessorSwiftCode))
+	if successor != "" {
+		if status != models.BankStatusRetired && status != models.BankStatusMerged {
+			return models.StatusChange{}, fmt.Errorf("%w: only retired or merged banks have a successor", ErrInvalidSuccessorSwift)
+		}
+		var err error
+		if successor, err = validSwiftCode(successor); err != nil {
+			return models.StatusChange{}, fmt.Errorf("%w: %v", ErrInvalidSuccessorSwift, err)
+		}
+		if successor == swiftCode {
+			return models.StatusChange{}, fmt.Errorf("%w: a bank cannot succeed itself", ErrInvalidSuccessorSwift)
+		}
+		// The successor can be added after the change is scheduled
+		if _, err := s.repo.FindBySwiftCode(successor); err != nil {
+			s.logger.Warning("Successor %s of %s is not in the database", successor, swiftCode)
+		}
+	}
+
+	effectiveDate := now.UTC()
+	if request.EffectiveDate != "" {
+		parsed, err := parseEffectiveDate(request.EffectiveDate)
+		if err != nil {
+			return models.StatusChange{}, err
+		}
+		effectiveDate = parsed
+	}
+
+	return models.StatusChange{
+		SwiftCode:          swiftCode,
+		Status:             status,
+		SuccessorSwiftCode: successor,
+		// Mongo keeps milliseconds, the truncated date matches the stored one
+		EffectiveDate: effectiveDate.Truncate(time.Millisecond),
+	}, nil
+}
+
+// parseEffectiveDate accepts a date, taken as midnight UTC, or an RFC 3339 time
+func parseEffectiveDate(value string) (time.Time, error) {
+	if date, err := time.Parse(time.DateOnly, value); err == nil {
+		return date, nil
+	}
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: %q, expected YYYY-MM-DD or RFC 3339", ErrInvalidEffectiveDate, value)
+	}
+	return parsed.UTC(), nil
+}
+
+// applyStatusChange updates the bank and records the change as applied
+func (s *SwiftCodeService) applyStatusChange(change models.StatusChange, now time.Time) error {
+	err := s.repo.UpdateBankStatus(change.SwiftCode, change.Status, change.SuccessorSwiftCode, change.EffectiveDate)
+	if err != nil {
+		if errors.Is(err, repository.ErrBankNotFound) {
+			return fmt.Errorf("%w: %s", ErrBankNotFound, change.SwiftCode)
+		}
+		return err
+	}
+
+	change.AppliedAt = now.UTC().Truncate(time.Millisecond)
+	return s.repo.UpsertStatusChange(change)
+}
+
+// ApplyDueStatusChanges applies the scheduled changes effective at the time and returns how many were applied.
+// The changes of the deleted banks are dropped. A failing change doesn't stop the others, it stays due and is retried
+// on the next run, the failures are returned joined
+func (s *SwiftCodeService) ApplyDueStatusChanges(now time.Time) (int, error) {
+	changes, err := s.repo.FindDueStatusChanges(now)
+	if err != nil {
+		return 0, err
+	}
+
+	applied := 0
+	var failures []error
+	for _, change := range changes {
+		err := s.applyStatusChange(change, now)
+		if errors.Is(err, ErrBankNotFound) {
+			s.logger.Warning("Dropping the status change of %s, the bank no longer exists", change.SwiftCode)
+			err = s.repo.MarkStatusChangeApplied(change, now.UTC())
+			if err == nil {
+				continue
+			}
+		}
+		if err != nil {
+			s.logger.Error("Error applying the status change of %s: %v", change.SwiftCode, err)
+			failures = append(failures, fmt.Errorf("status change of %s: %w", change.SwiftCode, err))
+			continue
+		}
+		s.logger.Info("Status of %s changed to %s", change.SwiftCode, change.Status)
+		applied++
+	}
+	return applied, errors.Join(failures...)
+}
+
+// RunStatusScheduler applies the due status changes at every interval until the context is done
+func (s *SwiftCodeService) RunStatusScheduler(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := s.ApplyDueStatusChanges(time.Now()); err != nil {
+			s.logger.Error("Error applying status changes: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
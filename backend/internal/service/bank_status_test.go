@@ -0,0 +1,267 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/Hbrtjm/SWIFT_API/backend/internal/api/middleware"
+	"github.com/Hbrtjm/SWIFT_API/backend/internal/db/models"
+	"github.com/Hbrtjm/SWIFT_API/backend/internal/db/repository"
+	"github.com/Hbrtjm/SWIFT_API/backend/internal/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeRepository keeps the banks and the status changes in memory. The other methods of the Repository aren't used
+// by the tested code and panic through the nil embedded interface
+type fakeRepository struct {
+	Repository
+	banks   map[string]models.Bank
+	changes []models.StatusChange
+	// Errors returned by UpdateBankStatus, by SWIFT code
+	updateErrors map[string]error
+	// Error returned by the bank lookups, as when the database is down
+	findError error
+}
+
+func newFakeRepository(banks ...models.Bank) *fakeRepository {
+	repo := &fakeRepository{banks: map[string]models.Bank{}, updateErrors: map[string]error{}}
+	for _, bank := range banks {
+		repo.banks[bank.SwiftCode] = bank
+	}
+	return repo
+}
+
+func (r *fakeRepository) FindBySwiftCode(swiftCode string) (models.Bank, error) {
+	if r.findError != nil {
+		return models.Bank{}, r.findError
+	}
+	bank, ok := r.banks[swiftCode]
+	if !ok {
+		return models.Bank{}, repository.ErrBankNotFound
+	}
+	return bank, nil
+}
+
+func (r *fakeRepository) UpdateBankStatus(swiftCode, status, successorSwiftCode string, effectiveDate time.Time) error {
+	if err := r.updateErrors[swiftCode]; err != nil {
+		return err
+	}
+	bank, ok := r.banks[swiftCode]
+	if !ok {
+		return repository.ErrBankNotFound
+	}
+	bank.Status, bank.SuccessorSwiftCode, bank.StatusEffectiveDate = status, successorSwiftCode, &effectiveDate
+	r.banks[swiftCode] = bank
+	return nil
+}
+
+func (r *fakeRepository) UpsertStatusChange(change models.StatusChange) error {
+	for i := range r.changes {
+		if r.changes[i].SwiftCode == change.SwiftCode && r.changes[i].EffectiveDate.Equal(change.EffectiveDate) {
+			r.changes[i] = change
+			return nil
+		}
+	}
+	r.changes = append(r.changes, change)
+	return nil
+}
+
+func (r *fakeRepository) FindPendingStatusChanges(swiftCode string) ([]models.StatusChange, error) {
+	return r.findStatusChanges(func(change models.StatusChange) bool {
+		return change.SwiftCode == swiftCode
+	}), nil
+}
+
+func (r *fakeRepository) FindDueStatusChanges(before time.Time) ([]models.StatusChange, error) {
+	return r.findStatusChanges(func(change models.StatusChange) bool {
+		return !change.EffectiveDate.After(before)
+	}), nil
+}
+
+func (r *fakeRepository) findStatusChanges(matches func(models.StatusChange) bool) []models.StatusChange {
+	changes := []models.StatusChange{}
+	for _, change := range r.changes {
+		if change.AppliedAt.IsZero() && matches(change) {
+			changes = append(changes, change)
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].EffectiveDate.Before(changes[j].EffectiveDate)
+	})
+	return changes
+}
+
+func (r *fakeRepository) MarkStatusChangeApplied(change models.StatusChange, appliedAt time.Time) error {
+	for i := range r.changes {
+		if r.changes[i].SwiftCode == change.SwiftCode && r.changes[i].EffectiveDate.Equal(change.EffectiveDate) {
+			r.changes[i].AppliedAt = appliedAt
+		}
+	}
+	return nil
+}
+
+func newStatusTestService(repo *fakeRepository) *SwiftCodeService {
+	return NewSwiftCodeService(repo, parser.NewSwiftFileParser(), middleware.NewNoLogger(), "v1")
+}
+
+func testBank(swiftCode string) models.Bank {
+	return models.Bank{
+		SwiftCode:     swiftCode,
+		BankName:      "PEKAO TOWARZYSTWO FUNDUSZY INWESTYCYJNYCH SPOLKA AKCYJNA",
+		CountryISO2:   "PL",
+		TownName:      "WARSZAWA",
+		IsHeadquarter: swiftCode[8:] == "XXX",
+		BranchCode:    swiftCode[:8],
+	}
+}
+
+func TestSetBankStatusImmediately(t *testing.T) {
+	repo := newFakeRepository(testBank("TPEOPLPWXXX"))
+	service := newStatusTestService(repo)
+	now := time.Date(2026, time.March, 2, 12, 0, 0, 0, time.UTC)
+
+	// The 8 character code is the code of the headquarter
+	response, scheduled, err := service.SetBankStatus("tpeoplpw", BankStatusRequest{Status: "Merged", SuccessorSwiftCode: "BREXPLPW"}, now)
+	require.NoError(t, err)
+	assert.False(t, scheduled)
+	assert.Equal(t, "TPEOPLPWXXX", response.SwiftCode)
+	assert.Equal(t, models.BankStatusMerged, response.Status)
+	assert.Equal(t, "BREXPLPWXXX", response.SuccessorSwiftCode)
+	assert.Equal(t, "/v1/swift-codes/BREXPLPWXXX", response.Links.Successor)
+	require.NotNil(t, response.StatusEffectiveDate)
+	assert.Equal(t, now, *response.StatusEffectiveDate)
+	assert.Empty(t, response.PendingChanges)
+
+	// The applied change is recorded
+	require.Len(t, repo.changes, 1)
+	assert.Equal(t, now, repo.changes[0].AppliedAt)
+
+	// Back to active, the successor is dropped
+	response, _, err = service.SetBankStatus("TPEOPLPWXXX", BankStatusRequest{Status: "active"}, now.Add(time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, models.BankStatusActive, response.Status)
+	assert.Empty(t, response.SuccessorSwiftCode)
+	assert.Empty(t, response.Links.Successor)
+}
+
+func TestSetBankStatusScheduled(t *testing.T) {
+	repo := newFakeRepository(testBank("TPEOPLPWXXX"), testBank("TPEOPLPWP65"))
+	service := newStatusTestService(repo)
+	now := time.Date(2026, time.March, 2, 12, 0, 0, 0, time.UTC)
+	effectiveDate := time.Date(2026, time.April, 1, 0, 0, 0, 0, time.UTC)
+
+	response, scheduled, err := service.SetBankStatus("TPEOPLPWP65", BankStatusRequest{Status: "retired", SuccessorSwiftCode: "TPEOPLPWXXX", EffectiveDate: "2026-04-01"}, now)
+	require.NoError(t, err)
+	assert.True(t, scheduled)
+	// The bank keeps its status until the effective date
+	assert.Equal(t, models.BankStatusActive, response.Status)
+	assert.Nil(t, response.StatusEffectiveDate)
+	require.Len(t, response.PendingChanges, 1)
+	assert.Equal(t, StatusChangeEntry{Status: models.BankStatusRetired, SuccessorSwiftCode: "TPEOPLPWXXX", EffectiveDate: effectiveDate}, response.PendingChanges[0])
+
+	applied, err := service.ApplyDueStatusChanges(effectiveDate.Add(-time.Second))
+	require.NoError(t, err)
+	assert.Equal(t, 0, applied)
+
+	applied, err = service.ApplyDueStatusChanges(effectiveDate)
+	require.NoError(t, err)
+	assert.Equal(t, 1, applied)
+
+	response, err = service.GetBankStatus("TPEOPLPWP65")
+	require.NoError(t, err)
+	assert.Equal(t, models.BankStatusRetired, response.Status)
+	assert.Equal(t, "TPEOPLPWXXX", response.SuccessorSwiftCode)
+	assert.Equal(t, effectiveDate, *response.StatusEffectiveDate)
+	assert.Empty(t, response.PendingChanges)
+
+	// The applied change isn't applied again
+	applied, err = service.ApplyDueStatusChanges(effectiveDate.Add(time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 0, applied)
+}
+
+func TestSetBankStatusValidation(t *testing.T) {
+	service := newStatusTestService(newFakeRepository(testBank("TPEOPLPWXXX")))
+	now := time.Date(2026, time.March, 2, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		code    string
+		request BankStatusRequest
+		err     error
+	}{
+		{"unknown status", "TPEOPLPWXXX", BankStatusRequest{Status: "closed"}, ErrInvalidBankStatus},
+		{"successor of an inactive bank", "TPEOPLPWXXX", BankStatusRequest{Status: "inactive", SuccessorSwiftCode: "BREXPLPWXXX"}, ErrInvalidSuccessorSwift},
+		{"malformed successor", "TPEOPLPWXXX", BankStatusRequest{Status: "merged", SuccessorSwiftCode: "BREX"}, ErrInvalidSuccessorSwift},
+		{"own successor", "TPEOPLPWXXX", BankStatusRequest{Status: "merged", SuccessorSwiftCode: "TPEOPLPW"}, ErrInvalidSuccessorSwift},
+		{"malformed date", "TPEOPLPWXXX", BankStatusRequest{Status: "inactive", EffectiveDate: "01.04.2026"}, ErrInvalidEffectiveDate},
+		{"unknown bank", "BREXPLPWXXX", BankStatusRequest{Status: "inactive"}, ErrBankNotFound},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, _, err := service.SetBankStatus(test.code, test.request, now)
+			assert.ErrorIs(t, err, test.err)
+		})
+	}
+}
+
+func TestApplyDueStatusChangesContinuesAfterFailures(t *testing.T) {
+	repo := newFakeRepository(testBank("TPEOPLPWXXX"), testBank("TPEOPLPWP65"), testBank("TPEOPLPWPAE"))
+	service := newStatusTestService(repo)
+	effectiveDate := time.Date(2026, time.April, 1, 0, 0, 0, 0, time.UTC)
+
+	for i, swiftCode := range []string{"TPEOPLPWP65", "TPEOPLPWPAE", "TPEOPLPWPFI", "TPEOPLPWXXX"} {
+		change := models.StatusChange{SwiftCode: swiftCode, Status: models.BankStatusInactive, EffectiveDate: effectiveDate.Add(time.Duration(i) * time.Minute)}
+		require.NoError(t, repo.UpsertStatusChange(change))
+	}
+	errUnavailable := errors.New("database unavailable")
+	repo.updateErrors["TPEOPLPWPAE"] = errUnavailable
+
+	applied, err := service.ApplyDueStatusChanges(effectiveDate.Add(time.Hour))
+	// The change of the missing TPEOPLPWPFI is dropped, the failing one doesn't stop the last one
+	assert.Equal(t, 2, applied)
+	require.Error(t, err)
+	assert.ErrorIs(t, err, errUnavailable)
+	assert.Contains(t, err.Error(), "TPEOPLPWPAE")
+	assert.Equal(t, models.BankStatusInactive, repo.banks["TPEOPLPWP65"].Status)
+	assert.Equal(t, models.BankStatusInactive, repo.banks["TPEOPLPWXXX"].Status)
+	assert.Empty(t, repo.banks["TPEOPLPWPAE"].Status)
+
+	// The failed change stays due and is retried
+	delete(repo.updateErrors, "TPEOPLPWPAE")
+	applied, err = service.ApplyDueStatusChanges(effectiveDate.Add(time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 1, applied)
+	assert.Equal(t, models.BankStatusInactive, repo.banks["TPEOPLPWPAE"].Status)
+}
+
+func TestRunStatusScheduler(t *testing.T) {
+	repo := newFakeRepository(testBank("TPEOPLPWXXX"))
+	service := newStatusTestService(repo)
+	require.NoError(t, repo.UpsertStatusChange(models.StatusChange{
+		SwiftCode:     "TPEOPLPWXXX",
+		Status:        models.BankStatusInactive,
+		EffectiveDate: time.Now().Add(-time.Minute).UTC(),
+	}))
+
+	// The due changes are applied at once, the scheduler returns when the context is done
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	done := make(chan struct{})
+	go func() {
+		service.RunStatusScheduler(ctx, time.Hour)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the scheduler didn't stop")
+	}
+	assert.Equal(t, models.BankStatusInactive, repo.banks["TPEOPLPWXXX"].Status)
+	assert.False(t, repo.changes[0].AppliedAt.IsZero())
+}
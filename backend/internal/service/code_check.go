@@ -0,0 +1,176 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Hbrtjm/SWIFT_API/backend/internal/db/models"
+	"github.com/Hbrtjm/SWIFT_API/backend/pkg/validators"
+)
+
+// Outcomes of the checks of the BICs and IBANs found in the validated files
+const (
+	// The BIC is in the directory and active
+	OutcomeFound = "found"
+	// The BIC is well formed, but not in the directory
+	OutcomeUnknown = "unknown"
+	// The bank was retired or merged, the successor is given when known
+	OutcomeRetired  = "retired"
+	OutcomeInactive = "inactive"
+	// The BIC or the IBAN is malformed
+	OutcomeInvalid = "invalid"
+	// The IBAN is well formed
+	OutcomeValid = "valid"
+	// The IBAN belongs to another country than the BIC of its agent
+	OutcomeCountryMismatch = "countryMismatch"
+)
+
+// CodeCheck is the outcome of checking a BIC against the structural rules and the directory, or of an IBAN
+// validation
+type CodeCheck struct {
+	Outcome string `json:"outcome"`
+	// Directory entry of the BIC, the BIC8 codes match their primary office
+	SwiftCode          string   `json:"swiftCode,omitempty"`
+	BankName           string   `json:"bankName,omitempty"`
+	TownName           string   `json:"townName,omitempty"`
+	CountryISO2        string   `json:"countryISO2,omitempty"`
+	SuccessorSwiftCode string   `json:"successorSwiftCode,omitempty"`
+	Issues             []string `json:"issues,omitempty"`
+}
+
+// Passed checks whether the outcome allows the payment to be sent
+func (c CodeCheck) Passed() bool {
+	return c.Outcome == OutcomeFound || c.Outcome == OutcomeValid
+}
+
+// bicChecker checks the BICs of a single file, the files repeat the same agents so the lookups are cached
+type bicChecker struct {
+	service  *SwiftCodeService
+	analyzer *validators.BICAnalyzer
+	checked  map[string]CodeCheck
+}
+
+func (s *SwiftCodeService) newBICChecker() *bicChecker {
+	return &bicChecker{service: s, analyzer: validators.NewBICAnalyzer(), checked: map[string]CodeCheck{}}
+}
+
+// check validates the structure of the BIC and looks it up in the directory. A failed lookup is returned rather
+// than reported as an unknown BIC
+func (c *bicChecker) check(code string) (CodeCheck, error) {
+	code = normalizeBIC(code)
+	if result, ok := c.checked[code]; ok {
+		return result, nil
+	}
+
+	result, swiftCode, ok := c.analyze(code)
+	if !ok {
+		c.checked[code] = result
+		return result, nil
+	}
+	bank, err := c.service.lookupBank(swiftCode)
+	if errors.Is(err, ErrBankNotFound) {
+		result.Outcome = OutcomeUnknown
+		c.checked[code] = result
+		return result, nil
+	}
+	if err != nil {
+		return CodeCheck{}, fmt.Errorf("error checking %s: %w", code, err)
+	}
+	result = withBank(result, &bank)
+	c.checked[code] = result
+	return result, nil
+}
+
+// prefetch checks the codes not checked yet with a single directory query, so that the following calls of check
+// don't reach the database
+func (c *bicChecker) prefetch(codes []string) error {
+	pending := map[string]CodeCheck{}
+	swiftCodes := map[string]string{}
+	for _, code := range codes {
+		code = normalizeBIC(code)
+		if _, ok := c.checked[code]; ok {
+			continue
+		}
+		if _, ok := pending[code]; ok {
+			continue
+		}
+		result, swiftCode, ok := c.analyze(code)
+		if !ok {
+			c.checked[code] = result
+			continue
+		}
+		pending[code] = result
+		swiftCodes[code] = swiftCode
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+
+	lookup := make([]string, 0, len(swiftCodes))
+	for _, swiftCode := range swiftCodes {
+		lookup = append(lookup, swiftCode)
+	}
+	banks, err := c.service.repo.FindBySwiftCodes(lookup...)
+	if err != nil {
+		return fmt.Errorf("error looking up the SWIFT codes: %w", err)
+	}
+	found := make(map[string]*models.Bank, len(banks))
+	for i := range banks {
+		found[banks[i].SwiftCode] = &banks[i]
+	}
+
+	for code, result := range pending {
+		if bank, ok := found[swiftCodes[code]]; ok {
+			result = withBank(result, bank)
+		} else {
+			result.Outcome = OutcomeUnknown
+		}
+		c.checked[code] = result
+	}
+	return nil
+}
+
+// reset forgets the checked codes, so that the cache of a long file stays bounded
+func (c *bicChecker) reset() {
+	c.checked = map[string]CodeCheck{}
+}
+
+// analyze validates the structure of the BIC and gives the code of its directory entry. A malformed BIC gives the
+// invalid outcome and false
+func (c *bicChecker) analyze(code string) (CodeCheck, string, bool) {
+	result := CodeCheck{}
+	analysis, err := c.analyzer.Analyze(code)
+	if err != nil {
+		result.Outcome = OutcomeInvalid
+		result.Issues = []string{err.Error()}
+		return result, "", false
+	}
+	if len(analysis.Issues) > 0 {
+		result.Issues = analysis.Issues
+	}
+
+	return result, validators.NormalizeSwiftCode(code), true
+}
+
+// withBank fills the check with the directory entry and its status
+func withBank(result CodeCheck, bank *models.Bank) CodeCheck {
+	result.SwiftCode = bank.SwiftCode
+	result.BankName = bank.BankName
+	result.TownName = bank.TownName
+	result.CountryISO2 = bank.CountryISO2
+	switch statusOf(bank) {
+	case models.BankStatusRetired, models.BankStatusMerged:
+		result.Outcome = OutcomeRetired
+		result.SuccessorSwiftCode = successorOf(bank)
+	case models.BankStatusInactive:
+		result.Outcome = OutcomeInactive
+	default:
+		result.Outcome = OutcomeFound
+	}
+	return result
+}
+
+func normalizeBIC(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
@@ -0,0 +1,223 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Hbrtjm/SWIFT_API/backend/internal/db/models"
+	"github.com/Hbrtjm/SWIFT_API/backend/internal/db/repository"
+	"github.com/Hbrtjm/SWIFT_API/backend/pkg/iso4217"
+	"github.com/Hbrtjm/SWIFT_API/backend/pkg/routing"
+	"github.com/Hbrtjm/SWIFT_API/backend/pkg/validators"
+)
+
+const (
+	DefaultRouteMaxHops = 4
+	MaxRouteMaxHops     = 6
+	// Number of the shortest routes returned at most
+	MaxRoutes = 10
+)
+
+var (
+	ErrInvalidCurrency      = errors.New("invalid currency")
+	ErrInvalidCorrespondent = errors.New("invalid correspondent relationship")
+	ErrInvalidMaxHops       = errors.New("invalid maxHops")
+	// ErrCorrespondentNotFound is returned when the relationship to delete doesn't exist
+	ErrCorrespondentNotFound = errors.New("correspondent relationship not found")
+)
+
+// CorrespondentsResponse lists the relationships of a bank, both the accounts it holds with its correspondents and
+// the accounts it services for other banks
+type CorrespondentsResponse struct {
+	SwiftCode      string                 `json:"swiftCode"`
+	Currency       string                 `json:"currency,omitempty"`
+	Correspondents []models.Correspondent `json:"correspondents"`
+}
+
+// RoutesResponse lists the shortest correspondent chains between two banks
+type RoutesResponse struct {
+	From     string `json:"from"`
+	To       string `json:"to"`
+	Currency string `json:"currency"`
+	// Number of the relationships on every route, zero when there is no route
+	Hops   int     `json:"hops"`
+	Routes []Route `json:"routes"`
+}
+
+// Route is a chain of banks from the ordering to the beneficiary bank
+type Route struct {
+	Path []RouteBank `json:"path"`
+}
+
+// RouteBank is a bank on a route, correspondents imported without their directory entry have no name
+type RouteBank struct {
+	SwiftCode   string `json:"swiftCode"`
+	BankName    string `json:"bankName,omitempty"`
+	CountryISO2 string `json:"countryISO2"`
+}
+
+// GetCorrespondents lists the relationships of the bank, an empty currency lists every currency
+func (s *SwiftCodeService) GetCorrespondents(code, currency string) (*CorrespondentsResponse, error) {
+	bank, err := s.findBank(code)
+	if err != nil {
+		return nil, err
+	}
+	if currency != "" {
+		if currency, err = lookupCurrency(currency); err != nil {
+			return nil, err
+		}
+	}
+
+	correspondents, err := s.repo.FindCorrespondentsBySwiftCode(bank.SwiftCode, currency)
+	if err != nil {
+		return nil, err
+	}
+	return &CorrespondentsResponse{SwiftCode: bank.SwiftCode, Currency: currency, Correspondents: correspondents}, nil
+}
+
+// AddCorrespondent stores a relationship between two banks of the database, adding an existing one does nothing
+func (s *SwiftCodeService) AddCorrespondent(correspondent models.Correspondent) (models.Correspondent, error) {
+	correspondent, err := normalizeCorrespondent(correspondent)
+	if err != nil {
+		return models.Correspondent{}, err
+	}
+	for _, code := range []string{correspondent.SwiftCode, correspondent.CorrespondentSwiftCode} {
+		if _, err := s.findBank(code); err != nil {
+			return models.Correspondent{}, err
+		}
+	}
+
+	if err := s.repo.UpsertManyCorrespondents([]models.Correspondent{correspondent}); err != nil {
+		return models.Correspondent{}, fmt.Errorf("error inserting correspondent: %w", err)
+	}
+	return correspondent, nil
+}
+
+// DeleteCorrespondent removes a relationship
+func (s *SwiftCodeService) DeleteCorrespondent(correspondent models.Correspondent) error {
+	correspondent, err := normalizeCorrespondent(correspondent)
+	if err != nil {
+		return err
+	}
+
+	err = s.repo.DeleteCorrespondent(correspondent)
+	if errors.Is(err, repository.ErrCorrespondentNotFound) {
+		return fmt.Errorf("%w: %s at %s in %s", ErrCorrespondentNotFound, correspondent.SwiftCode, correspondent.CorrespondentSwiftCode, correspondent.Currency)
+	}
+	return err
+}
+
+// FindRoutes finds the shortest chains of correspondents between two banks of the database in the currency.
+// A relationship carries payments both ways, maxHops limits the number of the relationships on a route (zero for the default)
+func (s *SwiftCodeService) FindRoutes(from, to, currency string, maxHops int) (*RoutesResponse, error) {
+	if maxHops == 0 {
+		maxHops = DefaultRouteMaxHops
+	}
+	if maxHops < 0 || maxHops > MaxRouteMaxHops {
+		return nil, fmt.Errorf("%w: must be between 1 and %d", ErrInvalidMaxHops, MaxRouteMaxHops)
+	}
+
+	currency, err := lookupCurrency(currency)
+	if err != nil {
+		return nil, err
+	}
+
+	// Both ends have to be in the database, the codes are replaced with their 11 character form
+	banks := make(map[string]models.Bank)
+	for _, code := range []*string{&from, &to} {
+		normalized, err := validSwiftCode(*code)
+		if err != nil {
+			return nil, fmt.Errorf("%w %s: %v", ErrInvalidSwiftCode, *code, err)
+		}
+		bank, err := s.findBank(normalized)
+		if err != nil {
+			return nil, err
+		}
+		banks[bank.SwiftCode] = bank
+		*code = bank.SwiftCode
+	}
+
+	correspondents, err := s.repo.FindCorrespondentsByCurrency(currency)
+	if err != nil {
+		return nil, err
+	}
+	graph := routing.NewGraph()
+	for _, correspondent := range correspondents {
+		graph.AddEdge(correspondent.SwiftCode, correspondent.CorrespondentSwiftCode)
+	}
+
+	response := &RoutesResponse{From: from, To: to, Currency: currency, Routes: []Route{}}
+	for _, path := range graph.ShortestPaths(from, to, maxHops, MaxRoutes) {
+		response.Hops = len(path) - 1
+		route := Route{Path: make([]RouteBank, 0, len(path))}
+		for _, code := range path {
+			route.Path = append(route.Path, s.routeBank(code, banks))
+		}
+		response.Routes = append(response.Routes, route)
+	}
+	return response, nil
+}
+
+// routeBank describes a bank on a route, the banks already looked up are reused
+func (s *SwiftCodeService) routeBank(code string, banks map[string]models.Bank) RouteBank {
+	bank, ok := banks[code]
+	if !ok {
+		found, err := s.repo.FindBySwiftCode(code)
+		if err == nil {
+			bank = found
+		}
+		banks[code] = bank
+	}
+	return RouteBank{SwiftCode: code, BankName: bank.BankName, CountryISO2: code[4:6]}
+}
+
+// LoadCorrespondents imports the correspondent relationships from a file
+func (s *SwiftCodeService) LoadCorrespondents(filename string) error {
+	correspondents, err := s.parser.ParseCorrespondentFile(filename)
+	if err != nil {
+		return fmt.Errorf("error parsing correspondent file: %w", err)
+	}
+
+	s.logger.Info("Inserting %d correspondent relationships into database", len(correspondents))
+	if err := s.repo.UpsertManyCorrespondents(correspondents); err != nil {
+		return fmt.Errorf("error inserting correspondents: %w", err)
+	}
+	return nil
+}
+
+func normalizeCorrespondent(correspondent models.Correspondent) (models.Correspondent, error) {
+	swiftCode, err := validSwiftCode(correspondent.SwiftCode)
+	if err != nil {
+		return models.Correspondent{}, fmt.Errorf("%w: %v", ErrInvalidCorrespondent, err)
+	}
+	correspondentSwiftCode, err := validSwiftCode(correspondent.CorrespondentSwiftCode)
+	if err != nil {
+		return models.Correspondent{}, fmt.Errorf("%w: %v", ErrInvalidCorrespondent, err)
+	}
+	if swiftCode == correspondentSwiftCode {
+		return models.Correspondent{}, fmt.Errorf("%w: a bank cannot be its own correspondent", ErrInvalidCorrespondent)
+	}
+	currency, err := lookupCurrency(correspondent.Currency)
+	if err != nil {
+		return models.Correspondent{}, err
+	}
+
+	return models.Correspondent{SwiftCode: swiftCode, CorrespondentSwiftCode: correspondentSwiftCode, Currency: currency}, nil
+}
+
+// validSwiftCode validates the code and returns it in the 11 character form the banks are stored with
+func validSwiftCode(code string) (string, error) {
+	code = validators.NormalizeSwiftCode(code)
+	if err := validators.NewSwiftCodeValidator().Validate(code, nil); err != nil {
+		return "", err
+	}
+	return code, nil
+}
+
+func lookupCurrency(code string) (string, error) {
+	currency, ok := iso4217.Lookup(code)
+	if !ok {
+		return "", fmt.Errorf("%w: %q is not an ISO 4217 code", ErrInvalidCurrency, code)
+	}
+	return currency.Code, nil
+}
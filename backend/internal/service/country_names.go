@@ -0,0 +1,24 @@
+package service
+
+import (
+	"github.com/Hbrtjm/SWIFT_API/backend/pkg/iso3166"
+)
+
+// countryNames returns the canonical and the display name of a country. The display name falls back to the ISO 3166
+// name for the entries stored before the display names were introduced, and for the countries missing from the
+// database
+func (s *SwiftCodeService) countryNames(countryISO2 string) (string, string, error) {
+	displayName := ""
+	if registered, ok := iso3166.Lookup(countryISO2); ok {
+		displayName = registered.Name
+	}
+
+	country, err := s.repo.GetCountry(countryISO2)
+	if err != nil {
+		return "", displayName, err
+	}
+	if country.DisplayName != "" {
+		displayName = country.DisplayName
+	}
+	return country.CountryName, displayName, nil
+}
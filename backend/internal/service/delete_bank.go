@@ -1,19 +1,85 @@
 package service
 
 import (
+	"errors"
 	"fmt"
+	"strings"
 
+	"github.com/Hbrtjm/SWIFT_API/backend/internal/db/repository"
 	"github.com/Hbrtjm/SWIFT_API/backend/pkg/validators"
 )
 
-// DeleteSwiftCode deletes a SWIFT code from the database
+// DeleteSwiftCode deletes a SWIFT code from the database, a headquarter is deleted with the configured mode
 func (s *SwiftCodeService) DeleteSwiftCode(code string) error {
+	_, err := s.DeleteSwiftCodeWithMode(code, "")
+	return err
+}
+
+// DeleteSwiftCodeWithMode deletes a SWIFT code, the mode overrides the configured handling of the headquarter branches.
+// The number of the branches deleted along with a headquarter is returned
+func (s *SwiftCodeService) DeleteSwiftCodeWithMode(code, mode string) (int64, error) {
 	swiftValidator := validators.NewSwiftCodeValidator()
-	swiftValidator.Validate(code)
 
-	if err := swiftValidator.Validate(code); err != nil {
-		return fmt.Errorf("invalid SWIFT code %s: %v", code, err)
+	if err := swiftValidator.Validate(code, nil); err != nil {
+		return 0, fmt.Errorf("%w %s: %v", ErrInvalidSwiftCode, code, err)
+	}
+	// A BIC8 is the code of the headquarter
+	code = validators.NormalizeSwiftCode(code)
+
+	mode = strings.ToLower(mode)
+	if mode == "" {
+		mode = s.integrity.HeadquarterDeleteMode
+	}
+	if !IsDeleteMode(mode) {
+		return 0, fmt.Errorf("%w: %s", ErrInvalidDeleteMode, mode)
+	}
+
+	if !strings.HasSuffix(code, validators.PrimaryOfficeBranchCode) || mode == DeleteModeDetach {
+		if err := s.deleteBank(code); err != nil {
+			return 0, err
+		}
+		s.unscreen(code)
+		return 0, nil
+	}
+
+	// The headquarter has to exist before its branches are checked or removed
+	if _, err := s.lookupBank(code); err != nil {
+		return 0, err
+	}
+
+	bic8 := code[:8]
+	if mode == DeleteModeReject {
+		count, err := s.repo.CountBranches(bic8)
+		if err != nil {
+			return 0, err
+		}
+		if count > 0 {
+			return 0, fmt.Errorf("%w: headquarter %s still has %d branches", ErrIntegrityViolation, code, count)
+		}
+		if err := s.deleteBank(code); err != nil {
+			return 0, err
+		}
+		s.unscreen(code)
+		return 0, nil
+	}
+
+	// The branches go first, so that a failure never leaves them without their headquarter
+	deleted, err := s.repo.DeleteBranches(bic8)
+	if err != nil {
+		return 0, fmt.Errorf("error deleting the branches of %s: %w", code, err)
+	}
+	if err := s.deleteBank(code); err != nil {
+		return deleted, fmt.Errorf("branches of %s deleted, but the headquarter was not: %w", code, err)
 	}
+	s.unscreen(bic8)
+	return deleted, nil
+}
 
-	return s.repo.Delete(code)
+// deleteBank deletes a single code, only a missing code is ErrBankNotFound
+func (s *SwiftCodeService) deleteBank(code string) error {
+	err := s.repo.Delete(code)
+	if errors.Is(err, repository.ErrBankNotFound) {
+		return fmt.Errorf("%w: %s", ErrBankNotFound, code)
+	}
+	return err
 }
@@ -0,0 +1,208 @@
+package service
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+var (
+	ErrInvalidDelimiter = errors.New("invalid delimiter")
+	ErrColumnNotFound   = errors.New("BIC column not found")
+	ErrMalformedCSV     = errors.New("malformed CSV")
+)
+
+// OutcomeMissing is the status of the rows without a BIC
+const OutcomeMissing = "missing"
+
+const (
+	// Rows looked up with a single query
+	enrichBatchSize = 500
+	// Checked codes kept across the batches, the cache starts over once it's full
+	enrichCacheSize = 10000
+)
+
+// EnrichedColumns are appended to every row of the enriched CSV
+var EnrichedColumns = []string{"bankName", "townName", "countryISO2", "countryName", "status"}
+
+// defaultBICColumns are the headers recognized when the column isn't given, compared by normalizeHeader
+var defaultBICColumns = []string{"swiftcode", "bic", "swift", "biccode", "swiftbic", "bicfi"}
+
+// EnrichOptions describe the uploaded CSV
+type EnrichOptions struct {
+	// Header of the BIC column, the usual names are looked for when empty
+	Column string
+	// Single character separating the fields, "tab" for the tab, the comma by default
+	Delimiter string
+}
+
+// EnrichCSV copies the CSV with the bank name, the town, the country and the status of the BIC of each row appended.
+// The rows are read and written in batches, each with a single directory query, so the file is never held in memory.
+// The errors of the header (ErrColumnNotFound, ErrMalformedCSV or ErrInvalidDelimiter) are returned before anything
+// is written
+func (s *SwiftCodeService) EnrichCSV(r io.Reader, w io.Writer, options EnrichOptions) error {
+	comma, err := parseDelimiter(options.Delimiter)
+	if err != nil {
+		return err
+	}
+
+	reader := csv.NewReader(r)
+	reader.Comma = comma
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return fmt.Errorf("%w: the file is empty", ErrMalformedCSV)
+	}
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrMalformedCSV, err)
+	}
+	// Spreadsheets save UTF-8 with a byte order mark
+	header[0] = strings.TrimPrefix(header[0], "\ufeff")
+	column, err := bicColumn(header, options.Column)
+	if err != nil {
+		return err
+	}
+
+	writer := csv.NewWriter(w)
+	writer.Comma = comma
+	enricher := &csvEnricher{service: s, checker: s.newBICChecker(), countries: map[string]string{}, column: column}
+
+	writer.Write(append(header, EnrichedColumns...))
+	batch := make([][]string, 0, enrichBatchSize)
+	for {
+		record, err := reader.Read()
+		if err != nil && err != io.EOF {
+			return fmt.Errorf("%w: %w", ErrMalformedCSV, err)
+		}
+		if record != nil {
+			batch = append(batch, record)
+		}
+		if len(batch) == enrichBatchSize || (err == io.EOF && len(batch) > 0) {
+			if err := enricher.writeBatch(writer, batch); err != nil {
+				return err
+			}
+			batch = batch[:0]
+		}
+		if err == io.EOF {
+			break
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// csvEnricher holds the caches of a single file
+type csvEnricher struct {
+	service   *SwiftCodeService
+	checker   *bicChecker
+	countries map[string]string
+	column    int
+}
+
+// writeBatch looks up the BICs of the rows at once and writes the enriched rows
+func (e *csvEnricher) writeBatch(writer *csv.Writer, batch [][]string) error {
+	if len(e.checker.checked) > enrichCacheSize {
+		e.checker.reset()
+	}
+
+	codes := make([]string, 0, len(batch))
+	for _, record := range batch {
+		if code := e.code(record); code != "" {
+			codes = append(codes, code)
+		}
+	}
+	if err := e.checker.prefetch(codes); err != nil {
+		return err
+	}
+
+	for _, record := range batch {
+		code := e.code(record)
+		if code == "" {
+			writer.Write(append(record, "", "", "", "", OutcomeMissing))
+			continue
+		}
+		// The codes have been prefetched, so the check doesn't reach the database
+		check, err := e.checker.check(code)
+		if err != nil {
+			return err
+		}
+		writer.Write(append(record, check.BankName, check.TownName, check.CountryISO2, e.countryName(check.CountryISO2), check.Outcome))
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// code gives the BIC of the row without the spaces used to group its characters
+func (e *csvEnricher) code(record []string) string {
+	if e.column >= len(record) {
+		return ""
+	}
+	return strings.Join(strings.Fields(record[e.column]), "")
+}
+
+// countryName gives the canonical name of the country, or its ISO 3166 name when it's missing from the database
+func (e *csvEnricher) countryName(countryISO2 string) string {
+	if countryISO2 == "" {
+		return ""
+	}
+	if name, ok := e.countries[countryISO2]; ok {
+		return name
+	}
+	name, displayName, err := e.service.countryNames(countryISO2)
+	if err != nil || name == "" {
+		name = displayName
+	}
+	e.countries[countryISO2] = name
+	return name
+}
+
+func parseDelimiter(delimiter string) (rune, error) {
+	switch delimiter {
+	case "":
+		return ',', nil
+	case "tab", `\t`:
+		return '\t', nil
+	}
+	comma, size := utf8.DecodeRuneInString(delimiter)
+	if size != len(delimiter) || comma == utf8.RuneError || comma == '"' || comma == '\r' || comma == '\n' {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidDelimiter, delimiter)
+	}
+	return comma, nil
+}
+
+// bicColumn finds the index of the BIC column in the header
+func bicColumn(header []string, column string) (int, error) {
+	names := defaultBICColumns
+	if column != "" {
+		names = []string{normalizeHeader(column)}
+	}
+	for _, name := range names {
+		for i, field := range header {
+			if normalizeHeader(field) == name {
+				return i, nil
+			}
+		}
+	}
+	if column == "" {
+		return 0, fmt.Errorf("%w: none of the headers is a BIC column, name it with the column parameter", ErrColumnNotFound)
+	}
+	return 0, fmt.Errorf("%w: %q", ErrColumnNotFound, column)
+}
+
+// normalizeHeader compares the headers regardless of the case and the separators, e.g. "SWIFT code" and "swift_code"
+func normalizeHeader(header string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '_', '-', '.', '\t':
+			return -1
+		}
+		return r
+	}, strings.ToLower(strings.TrimSpace(header)))
+}
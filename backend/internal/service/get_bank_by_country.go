@@ -3,19 +3,26 @@ package service
 import (
 	"fmt"
 	"strings"
+	"time"
 
+	"github.com/Hbrtjm/SWIFT_API/backend/pkg/countryinfo"
 	"github.com/Hbrtjm/SWIFT_API/backend/pkg/validators"
 )
 
 // GetBySwiftCodesByCountry returns all SWIFT codes for a given country
 func (s *SwiftCodeService) GetBySwiftCodesByCountry(countryISO2 string) (map[string]interface{}, error) {
+	return s.GetBySwiftCodesByCountryFiltered(countryISO2, BankFilter{})
+}
+
+// GetBySwiftCodesByCountryFiltered returns the SWIFT codes of a country matching the filter
+func (s *SwiftCodeService) GetBySwiftCodesByCountryFiltered(countryISO2 string, filter BankFilter) (map[string]interface{}, error) {
 	countryISO2Validator := validators.NewCountryISO2CodeValidator()
-	if err := countryISO2Validator.Validate(countryISO2); err != nil {
+	if err := countryISO2Validator.Validate(countryISO2, nil); err != nil {
 		return nil, fmt.Errorf("invalid country code %s: %v", countryISO2, err)
 	}
 
 	countryISO2 = strings.ToUpper(countryISO2)
-	countryName, err := s.repo.LookupCountryName(countryISO2)
+	countryName, displayName, err := s.countryNames(countryISO2)
 	if err != nil {
 		return nil, fmt.Errorf("country lookup failed: %w", err)
 	}
@@ -24,17 +31,40 @@ func (s *SwiftCodeService) GetBySwiftCodesByCountry(countryISO2 string) (map[str
 	if err != nil {
 		return nil, fmt.Errorf("bank lookup failed: %w", err)
 	}
+	banks, err = s.filterBanks(banks, filter, time.Now())
+	if err != nil {
+		return nil, err
+	}
 
 	// Map bank data to response format
 	swiftCodes := make([]map[string]interface{}, 0, len(banks))
 	for _, bank := range banks {
-		swiftCodes = append(swiftCodes, mapBankToMap(&bank))
+		swiftCodes = append(swiftCodes, s.mapBankToMap(&bank))
 	}
 
+	// The code has passed the validator, so it's present in the reference data
+	info, _ := countryinfo.Lookup(countryISO2)
+
 	response := map[string]interface{}{
-		"countryISO2": countryISO2,
-		"countryName": countryName,
-		"swiftCodes":  swiftCodes,
+		"countryISO2":    countryISO2,
+		"countryISO3":    info.CountryISO3,
+		"countryNumeric": info.CountryNumeric,
+		"countryName":    countryName,
+		// Replaced by the name in the language of the request
+		"countryDisplayName": displayName,
+		"currencies":         info.Currencies,
+		"region":             info.Region,
+		"subRegion":          info.SubRegion,
+		"callingCode":        info.CallingCode,
+		"eu":                 info.EU,
+		"eea":                info.EEA,
+		"sepa":               info.SEPA,
+		"usesIBAN":           info.UsesIBAN,
+		"swiftCodes":         swiftCodes,
+		"links": map[string]string{
+			"self":    s.apiPath("/swift-codes/country/%s", countryISO2),
+			"country": s.apiPath("/countries/%s", countryISO2),
+		},
 	}
 
 	return response, nil
@@ -0,0 +1,104 @@
+package service
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/Hbrtjm/SWIFT_API/backend/internal/db/models"
+	"github.com/Hbrtjm/SWIFT_API/backend/pkg/countryinfo"
+)
+
+// RegionPageResponse is a page of the SWIFT codes of the countries of a region group
+type RegionPageResponse struct {
+	Region string `json:"region"`
+	// Countries of the group, with or without SWIFT codes
+	Countries  []string                 `json:"countries"`
+	SwiftCodes []map[string]interface{} `json:"swiftCodes"`
+	// Empty on the last page
+	NextCursor string      `json:"nextCursor,omitempty"`
+	Links      RegionLinks `json:"links"`
+}
+
+// RegionLinks point to the current and the next page
+type RegionLinks struct {
+	Self string `json:"self"`
+	Next string `json:"next,omitempty"`
+}
+
+// GetBySwiftCodesByRegion lists the SWIFT codes of every country of a region group (e.g. SEPA) in pages sorted by the
+// code, the cursor is taken from the previous page. The scheme of the filter narrows the listing down, the region
+// of the filter isn't used
+func (s *SwiftCodeService) GetBySwiftCodesByRegion(regionName string, filter BankFilter, limit int, cursor string) (*RegionPageResponse, error) {
+	region, err := countryinfo.ParseRegion(regionName)
+	if err != nil {
+		return nil, err
+	}
+	limit, err = pageLimit(limit)
+	if err != nil {
+		return nil, err
+	}
+	after, err := decodeCursor(cursor)
+	if err != nil {
+		return nil, err
+	}
+	filter.Region = ""
+
+	countries := []string{}
+	for _, country := range countryinfo.All() {
+		if region.Contains(country.CountryISO2) {
+			countries = append(countries, country.CountryISO2)
+		}
+	}
+
+	// The pages are filled up when the scheme drops some of the banks, one more than requested tells whether
+	// there is a next page
+	now := time.Now()
+	banks := []models.Bank{}
+	for len(banks) <= limit {
+		found, err := s.repo.FindByCountriesPage(countries, after, limit+1)
+		if err != nil {
+			return nil, fmt.Errorf("bank lookup failed: %w", err)
+		}
+		if len(found) == 0 {
+			break
+		}
+		after = found[len(found)-1].SwiftCode
+
+		filtered, err := s.filterBanks(found, filter, now)
+		if err != nil {
+			return nil, err
+		}
+		banks = append(banks, filtered...)
+		if len(found) <= limit {
+			break
+		}
+	}
+
+	response := &RegionPageResponse{
+		Region:     region.Name,
+		Countries:  countries,
+		SwiftCodes: make([]map[string]interface{}, 0, limit),
+		Links:      RegionLinks{Self: s.regionPagePath(region.Name, filter.Scheme, limit, cursor)},
+	}
+	if len(banks) > limit {
+		banks = banks[:limit]
+		response.NextCursor = encodeCursor(banks[len(banks)-1].SwiftCode)
+		response.Links.Next = s.regionPagePath(region.Name, filter.Scheme, limit, response.NextCursor)
+	}
+	for _, bank := range banks {
+		response.SwiftCodes = append(response.SwiftCodes, s.mapBankToMap(&bank))
+	}
+	return response, nil
+}
+
+func (s *SwiftCodeService) regionPagePath(region, scheme string, limit int, cursor string) string {
+	path := s.apiPath("/swift-codes/region/%s?limit=%d", url.PathEscape(region), limit)
+	if scheme != "" {
+		path += "&scheme=" + url.QueryEscape(scheme)
+	}
+	if cursor != "" {
+		path += "&cursor=" + cursor
+	}
+	return path
+}
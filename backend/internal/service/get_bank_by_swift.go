@@ -1,51 +1,54 @@
 package service
 
 import (
-	"errors"
-
-	"github.com/Hbrtjm/SWIFT_API/backend/internal/db/models"
+	"time"
 )
 
 func (s *SwiftCodeService) GetBySwiftCode(code string) (*SwiftCodeResponse, error) {
-	bank, err := s.repo.FindBySwiftCode(code)
-	emptyBank := models.Bank{}
-	if err != nil || bank == emptyBank {
-		return nil, errors.New("no bank found with the given SWIFT code")
+	bank, err := s.lookupBank(code)
+	if err != nil {
+		return nil, err
 	}
 
 	// Get country name for the response
-	countryName, err := s.repo.LookupCountryName(bank.CountryISO2)
+	countryName, displayName, err := s.countryNames(bank.CountryISO2)
 	if err != nil {
 		s.logger.Error("Error looking up country name: %v", err)
 		countryName = "" // Continue even if country name lookup fails
 	}
 
-	if bank.IsHeadquarter {
-		if bank.BranchCode != "" {
-			branches, err := s.repo.FindByBranchCode(bank.BranchCode)
-			if err != nil {
-				s.logger.Error("Error finding branches: %v", err)
-				// Return the headquarter info even if there was an error finding branches
-				return bankToResponse(&bank, countryName), nil
-			}
-
-			// Filter out the headquarter itself from the branch list
-			filteredBranches := make([]map[string]interface{}, 0)
-			for _, branch := range branches {
-				if branch["swiftCode"] != bank.SwiftCode {
-					filteredBranches = append(filteredBranches, mapBranchValues(branch))
-				}
-			}
+	response := s.bankToResponse(&bank, countryName, displayName)
+	// The related data is part of the answer, a failed lookup fails the request rather than leaving it out
+	if response.LEI, err = s.leiOf(bank.SwiftCode); err != nil {
+		return nil, err
+	}
+	if response.ClearingCodes, err = s.clearingCodesOf(bank.SwiftCode); err != nil {
+		return nil, err
+	}
+	if response.Schemes, err = s.schemesOf(bank.SwiftCode, time.Now()); err != nil {
+		return nil, err
+	}
+	if response.Screening, err = s.screeningOf(bank.SwiftCode); err != nil {
+		return nil, err
+	}
 
-			// Create a new SwiftCodeResponse with the branches included
-			response := bankToResponse(&bank, countryName)
-			if len(filteredBranches) > 0 {
-				response.Branches = filteredBranches
-			}
+	// Only the first page of the branches is embedded, the rest is listed by links.branches
+	if bank.IsHeadquarter && bank.BranchCode != "" {
+		branches, err := s.repo.FindBranchesPage(bank.BranchCode, "", DefaultBranchPageLimit+1)
+		if err != nil {
+			s.logger.Error("Error finding branches: %v", err)
+			// Return the headquarter info even if there was an error finding branches
 			return response, nil
 		}
+
+		if len(branches) > DefaultBranchPageLimit {
+			branches = branches[:DefaultBranchPageLimit]
+			response.BranchesTruncated = true
+		}
+		for _, branch := range branches {
+			response.Branches = append(response.Branches, s.mapBankToMap(&branch))
+		}
 	}
 
-	// Return the original value if it's not a headquarter or has no branches
-	return bankToResponse(&bank, countryName), nil
+	return response, nil
 }
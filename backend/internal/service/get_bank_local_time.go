@@ -0,0 +1,94 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Hbrtjm/SWIFT_API/backend/pkg/holidays"
+	"github.com/Hbrtjm/SWIFT_API/backend/pkg/timezones"
+)
+
+// ErrUnknownTimeZone is returned when the zone of a bank is neither stored nor inferred from its town
+var ErrUnknownTimeZone = errors.New("unknown time zone")
+
+// BusinessCalendarStatus is the state of a calendar at a given moment
+type BusinessCalendarStatus struct {
+	Calendar              string                 `json:"calendar"`
+	TimeZone              string                 `json:"timeZone"`
+	LocalTime             string                 `json:"localTime"`
+	IsBusinessDay         bool                   `json:"isBusinessDay"`
+	IsWithinBusinessHours bool                   `json:"isWithinBusinessHours"`
+	Holiday               string                 `json:"holiday,omitempty"`
+	BusinessHours         holidays.BusinessHours `json:"businessHours"`
+	NextBusinessDay       string                 `json:"nextBusinessDay"`
+}
+
+// BankLocalTimeResponse is the local time of a bank along with its business day status
+type BankLocalTimeResponse struct {
+	SwiftCode string `json:"swiftCode"`
+	BusinessCalendarStatus
+	// False when there is no holiday calendar for the country, only the weekends are taken into account then
+	HolidayCalendarAvailable bool                    `json:"holidayCalendarAvailable"`
+	TARGET2                  *BusinessCalendarStatus `json:"target2,omitempty"`
+}
+
+// GetBankLocalTime evaluates the local time and the business day of the bank at the given moment
+func (s *SwiftCodeService) GetBankLocalTime(code string, at time.Time) (*BankLocalTimeResponse, error) {
+	bank, err := s.findBank(code)
+	if err != nil {
+		return nil, err
+	}
+
+	zone := bank.TimeZone
+	if zone == "" {
+		// Entries inserted before the time zones were stored per bank
+		zone = timezones.InferZone(bank.CountryISO2, bank.TownName)
+	}
+	if zone == "" {
+		return nil, fmt.Errorf("%w of %s: town %s not recognized", ErrUnknownTimeZone, bank.SwiftCode, bank.TownName)
+	}
+	location, err := timezones.Load(zone)
+	if err != nil {
+		return nil, fmt.Errorf("%w of %s: %v", ErrUnknownTimeZone, bank.SwiftCode, err)
+	}
+
+	calendar, available := s.holidays.ForCountry(bank.CountryISO2)
+	response := &BankLocalTimeResponse{
+		SwiftCode:                bank.SwiftCode,
+		BusinessCalendarStatus:   calendarStatus(calendar, at.In(location)),
+		HolidayCalendarAvailable: available,
+	}
+
+	// Euro payments settle on the TARGET2 days, independently of the national holidays
+	if target2, ok := s.holidays.Get(holidays.TARGET2); ok {
+		status := calendarStatus(target2, at.In(target2.Location(location)))
+		response.TARGET2 = &status
+	}
+
+	return response, nil
+}
+
+func calendarStatus(calendar *holidays.Calendar, local time.Time) BusinessCalendarStatus {
+	holiday, _ := calendar.Holiday(local)
+	return BusinessCalendarStatus{
+		Calendar:              calendar.ID,
+		TimeZone:              local.Location().String(),
+		LocalTime:             local.Format(time.RFC3339),
+		IsBusinessDay:         calendar.IsBusinessDay(local),
+		IsWithinBusinessHours: calendar.IsWithinBusinessHours(local),
+		Holiday:               holiday,
+		BusinessHours:         calendar.BusinessHours,
+		NextBusinessDay:       calendar.NextBusinessDay(local).Format(time.DateOnly),
+	}
+}
+
+// LoadHolidayCalendars replaces or extends the embedded holiday calendars with the files from the directory
+func (s *SwiftCodeService) LoadHolidayCalendars(dir string) error {
+	if err := s.holidays.LoadDir(dir); err != nil {
+		return fmt.Errorf("error loading holiday calendars: %w", err)
+	}
+	s.logger.Info("Loaded holiday calendars: %s", strings.Join(s.holidays.IDs(), ", "))
+	return nil
+}
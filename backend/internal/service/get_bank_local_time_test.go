@@ -0,0 +1,21 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetBankLocalTimeUnknownZone(t *testing.T) {
+	bank := testBank("CHASUS33XXX")
+	bank.CountryISO2, bank.TownName = "US", "NOWHERE"
+	service := newStatusTestService(newFakeRepository(bank))
+
+	_, err := service.GetBankLocalTime("CHASUS33", time.Now())
+	assert.ErrorIs(t, err, ErrUnknownTimeZone)
+	assert.NotErrorIs(t, err, ErrBankNotFound)
+
+	_, err = service.GetBankLocalTime("BREXPLPWXXX", time.Now())
+	assert.ErrorIs(t, err, ErrBankNotFound)
+}
@@ -0,0 +1,153 @@
+package service
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	"github.com/Hbrtjm/SWIFT_API/backend/internal/db/models"
+	"github.com/Hbrtjm/SWIFT_API/backend/internal/db/repository"
+	"github.com/Hbrtjm/SWIFT_API/backend/pkg/validators"
+)
+
+const (
+	DefaultBranchPageLimit = 50
+	MaxBranchPageLimit     = 500
+)
+
+var (
+	ErrInvalidCursor = errors.New("invalid cursor")
+	ErrInvalidLimit  = errors.New("invalid limit")
+	// ErrBankNotFound is returned when the requested code isn't in the database
+	ErrBankNotFound = errors.New("no bank found with the given SWIFT code")
+	// ErrInvalidSwiftCode is returned when the requested code is malformed
+	ErrInvalidSwiftCode = errors.New("invalid SWIFT code")
+)
+
+// BranchPageResponse is a page of the branches of a BIC8
+type BranchPageResponse struct {
+	Headquarter string                   `json:"headquarter"`
+	Branches    []map[string]interface{} `json:"branches"`
+	// Empty on the last page
+	NextCursor string    `json:"nextCursor,omitempty"`
+	Links      PageLinks `json:"links"`
+}
+
+// PageLinks point to the current and the next page
+type PageLinks struct {
+	Self        string `json:"self"`
+	Next        string `json:"next,omitempty"`
+	Headquarter string `json:"headquarter"`
+}
+
+// GetHeadquarter returns the headquarter of the bank, a headquarter is its own headquarter
+func (s *SwiftCodeService) GetHeadquarter(code string) (*SwiftCodeResponse, error) {
+	bank, err := s.findBank(code)
+	if err != nil {
+		return nil, err
+	}
+
+	headquarterCode := headquarterOf(bank.SwiftCode)
+	if _, err := s.lookupBank(headquarterCode); err != nil {
+		return nil, fmt.Errorf("headquarter of %s: %w", bank.SwiftCode, err)
+	}
+	return s.GetBySwiftCode(headquarterCode)
+}
+
+// GetBranches lists the branches of the BIC8 of the code in pages, the cursor is taken from the previous page
+func (s *SwiftCodeService) GetBranches(code string, limit int, cursor string) (*BranchPageResponse, error) {
+	limit, err := pageLimit(limit)
+	if err != nil {
+		return nil, err
+	}
+	after, err := decodeCursor(cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	bank, err := s.findBank(code)
+	if err != nil {
+		return nil, err
+	}
+	headquarterCode := headquarterOf(bank.SwiftCode)
+
+	// One more than requested tells whether there is a next page
+	banks, err := s.repo.FindBranchesPage(headquarterCode[:8], after, limit+1)
+	if err != nil {
+		return nil, fmt.Errorf("error finding branches: %w", err)
+	}
+
+	response := &BranchPageResponse{
+		Headquarter: headquarterCode,
+		Branches:    make([]map[string]interface{}, 0, limit),
+		Links: PageLinks{
+			Self:        s.branchPagePath(headquarterCode, limit, cursor),
+			Headquarter: s.apiPath("/swift-codes/%s", headquarterCode),
+		},
+	}
+
+	if len(banks) > limit {
+		banks = banks[:limit]
+		response.NextCursor = encodeCursor(banks[len(banks)-1].SwiftCode)
+		response.Links.Next = s.branchPagePath(headquarterCode, limit, response.NextCursor)
+	}
+	for _, branch := range banks {
+		response.Branches = append(response.Branches, s.mapBankToMap(&branch))
+	}
+
+	return response, nil
+}
+
+// pageLimit checks the limit of a page of the listings, zero gives the default limit
+func pageLimit(limit int) (int, error) {
+	if limit == 0 {
+		return DefaultBranchPageLimit, nil
+	}
+	if limit < 0 || limit > MaxBranchPageLimit {
+		return 0, fmt.Errorf("%w: must be between 1 and %d", ErrInvalidLimit, MaxBranchPageLimit)
+	}
+	return limit, nil
+}
+
+// decodeCursor gives the SWIFT code the page starts after, the cursor is the last code of the previous page
+func decodeCursor(cursor string) (string, error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidCursor, err)
+	}
+	return string(decoded), nil
+}
+
+func encodeCursor(swiftCode string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(swiftCode))
+}
+
+// findBank looks the bank up by the code, a BIC8 finds its primary office
+func (s *SwiftCodeService) findBank(code string) (models.Bank, error) {
+	return s.lookupBank(validators.NormalizeSwiftCode(code))
+}
+
+// lookupBank looks the bank up by the exact code. Only a missing bank is ErrBankNotFound, the database errors are
+// passed on
+func (s *SwiftCodeService) lookupBank(code string) (models.Bank, error) {
+	bank, err := s.repo.FindBySwiftCode(code)
+	if errors.Is(err, repository.ErrBankNotFound) {
+		return models.Bank{}, fmt.Errorf("%w: %s", ErrBankNotFound, code)
+	}
+	if err != nil {
+		return models.Bank{}, fmt.Errorf("bank lookup failed: %w", err)
+	}
+	return bank, nil
+}
+
+func headquarterOf(swiftCode string) string {
+	return swiftCode[:8] + validators.PrimaryOfficeBranchCode
+}
+
+func (s *SwiftCodeService) branchPagePath(headquarterCode string, limit int, cursor string) string {
+	path := s.apiPath("/swift-codes/%s/branches?limit=%d", headquarterCode, limit)
+	if cursor != "" {
+		path += "&cursor=" + cursor
+	}
+	return path
+}
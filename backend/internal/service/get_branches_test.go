@@ -0,0 +1,28 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindBank(t *testing.T) {
+	repo := newFakeRepository(testBank("TPEOPLPWXXX"))
+	service := newStatusTestService(repo)
+
+	// The 8 character code finds the primary office
+	bank, err := service.findBank("tpeoplpw")
+	require.NoError(t, err)
+	assert.Equal(t, "TPEOPLPWXXX", bank.SwiftCode)
+
+	_, err = service.findBank("BREXPLPWXXX")
+	assert.ErrorIs(t, err, ErrBankNotFound)
+
+	// A database error isn't a missing bank
+	repo.findError = errors.New("server selection timeout")
+	_, err = service.findBank("TPEOPLPWXXX")
+	assert.ErrorIs(t, err, repo.findError)
+	assert.NotErrorIs(t, err, ErrBankNotFound)
+}
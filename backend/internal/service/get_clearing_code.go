@@ -0,0 +1,94 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Hbrtjm/SWIFT_API/backend/internal/db/repository"
+	"github.com/Hbrtjm/SWIFT_API/backend/pkg/clearing"
+)
+
+var ErrClearingCodeNotFound = errors.New("clearing code not found")
+
+// ClearingCodeResponse is a clearing system member id along with the SWIFT entry of the bank
+type ClearingCodeResponse struct {
+	ClearingSystem     string             `json:"clearingSystem"`
+	ClearingSystemName string             `json:"clearingSystemName"`
+	MemberID           string             `json:"memberId"`
+	SwiftCode          string             `json:"swiftCode"`
+	SwiftEntry         *SwiftCodeResponse `json:"swiftEntry"`
+}
+
+// GetByClearingCode finds the bank of a clearing system member id. The system is either the ISO 20022 code or its alias,
+// errors of the clearing package are returned for an unknown system or a malformed id
+func (s *SwiftCodeService) GetByClearingCode(systemName, memberID string) (*ClearingCodeResponse, error) {
+	system, ok := clearing.LookupSystem(systemName)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", clearing.ErrUnknownSystem, systemName)
+	}
+	memberID, err := system.Validate(memberID)
+	if err != nil {
+		return nil, err
+	}
+
+	clearingCode, err := s.repo.FindClearingCode(system.Code, memberID)
+	if errors.Is(err, repository.ErrClearingCodeNotFound) {
+		return nil, fmt.Errorf("%w: %s %s", ErrClearingCodeNotFound, system.Code, memberID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error finding %s %s: %w", system.Code, memberID, err)
+	}
+
+	response := &ClearingCodeResponse{
+		ClearingSystem:     system.Code,
+		ClearingSystemName: system.Name,
+		MemberID:           memberID,
+		SwiftCode:          clearingCode.SwiftCode,
+	}
+
+	// The clearing code is kept even when the bank was removed from the directory
+	bank, err := s.repo.FindBySwiftCode(clearingCode.SwiftCode)
+	if err != nil {
+		s.logger.Warning("Clearing code %s %s refers to a missing SWIFT code %s", system.Code, memberID, clearingCode.SwiftCode)
+		return response, nil
+	}
+
+	countryName, displayName, err := s.countryNames(bank.CountryISO2)
+	if err != nil {
+		s.logger.Error("Error looking up country name: %v", err)
+	}
+	response.SwiftEntry = s.bankToResponse(&bank, countryName, displayName)
+	if response.SwiftEntry.ClearingCodes, err = s.clearingCodesOf(bank.SwiftCode); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// clearingCodesOf lists the clearing codes of the bank. A lookup failure is returned rather than an empty list, so an
+// omitted clearingCodes field always means the bank has no codes
+func (s *SwiftCodeService) clearingCodesOf(swiftCode string) ([]ClearingCodeEntry, error) {
+	clearingCodes, err := s.repo.FindClearingCodesBySwiftCode(swiftCode)
+	if err != nil {
+		return nil, fmt.Errorf("error finding clearing codes of %s: %w", swiftCode, err)
+	}
+
+	entries := make([]ClearingCodeEntry, 0, len(clearingCodes))
+	for _, clearingCode := range clearingCodes {
+		entries = append(entries, ClearingCodeEntry{ClearingSystem: clearingCode.ClearingSystem, MemberID: clearingCode.MemberID})
+	}
+	return entries, nil
+}
+
+// LoadClearingCodes imports the clearing system member ids from a file
+func (s *SwiftCodeService) LoadClearingCodes(filename string) error {
+	clearingCodes, err := s.parser.ParseClearingCodeFile(filename)
+	if err != nil {
+		return fmt.Errorf("error parsing clearing code file: %w", err)
+	}
+
+	s.logger.Info("Inserting %d clearing codes into database", len(clearingCodes))
+	if err := s.repo.UpsertManyClearingCodes(clearingCodes); err != nil {
+		return fmt.Errorf("error inserting clearing codes: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/Hbrtjm/SWIFT_API/backend/internal/db/repository"
+	"github.com/Hbrtjm/SWIFT_API/backend/pkg/countryinfo"
+)
+
+// CountryInfoResponse is the embedded reference data of a country
+type CountryInfoResponse struct {
+	countryinfo.Info
+	// Name in the language of the request, the ISO 3166 name by default
+	DisplayName string       `json:"displayName"`
+	Links       CountryLinks `json:"links"`
+}
+
+// CountryLinks point to the country and its SWIFT codes
+type CountryLinks struct {
+	Self       string `json:"self"`
+	SwiftCodes string `json:"swiftCodes"`
+}
+
+// GetCountries lists the reference data of the countries, optionally limited to a region group
+func (s *SwiftCodeService) GetCountries(region string) ([]CountryInfoResponse, error) {
+	countries := countryinfo.All()
+	if region != "" {
+		group, err := countryinfo.ParseRegion(region)
+		if err != nil {
+			return nil, err
+		}
+		inRegion := countries[:0]
+		for _, country := range countries {
+			if group.Contains(country.CountryISO2) {
+				inRegion = append(inRegion, country)
+			}
+		}
+		countries = inRegion
+	}
+
+	response := make([]CountryInfoResponse, 0, len(countries))
+	for _, country := range countries {
+		response = append(response, s.countryInfoResponse(country))
+	}
+	return response, nil
+}
+
+// GetCountryInfo returns the reference data of a country, which doesn't have to have any SWIFT codes
+func (s *SwiftCodeService) GetCountryInfo(countryISO2 string) (*CountryInfoResponse, error) {
+	country, ok := countryinfo.Lookup(countryISO2)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", repository.ErrCountryNotFound, countryISO2)
+	}
+	response := s.countryInfoResponse(country)
+	return &response, nil
+}
+
+func (s *SwiftCodeService) countryInfoResponse(country countryinfo.Info) CountryInfoResponse {
+	return CountryInfoResponse{
+		Info:        country,
+		DisplayName: country.Name,
+		Links: CountryLinks{
+			Self:       s.apiPath("/countries/%s", country.CountryISO2),
+			SwiftCodes: s.apiPath("/swift-codes/country/%s", country.CountryISO2),
+		},
+	}
+}
@@ -0,0 +1,121 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Hbrtjm/SWIFT_API/backend/internal/db/models"
+	"github.com/Hbrtjm/SWIFT_API/backend/internal/db/repository"
+	"github.com/Hbrtjm/SWIFT_API/backend/pkg/iban"
+	"github.com/Hbrtjm/SWIFT_API/backend/pkg/validators"
+)
+
+// Ways in which the SWIFT code of an IBAN is resolved
+const (
+	ResolvedByBankCodeTable   = "bankCodeTable"
+	ResolvedByInstitutionCode = "institutionCode"
+)
+
+// IBANResponse is the validation result of an IBAN along with the SWIFT entry of its bank
+type IBANResponse struct {
+	IBAN        string `json:"iban"`
+	Valid       bool   `json:"valid"`
+	Error       string `json:"error,omitempty"`
+	CountryISO2 string `json:"countryISO2,omitempty"`
+	CheckDigits string `json:"checkDigits,omitempty"`
+	BBAN        string `json:"bban,omitempty"`
+	BankCode    string `json:"bankCode,omitempty"`
+	BranchCode  string `json:"branchCode,omitempty"`
+	ResolvedBy  string `json:"resolvedBy,omitempty"`
+	// Nil when the bank code couldn't be resolved to a SWIFT code in the database
+	SwiftEntry *SwiftCodeResponse `json:"swiftEntry"`
+}
+
+// GetIBANDetails validates the IBAN and resolves its national bank code to a SWIFT entry.
+// An invalid IBAN isn't an error, the response reports why it's invalid
+func (s *SwiftCodeService) GetIBANDetails(value string) (*IBANResponse, error) {
+	parsed, err := iban.Parse(value)
+	if err != nil {
+		return &IBANResponse{IBAN: iban.Normalize(value), Valid: false, Error: err.Error()}, nil
+	}
+
+	response := &IBANResponse{
+		IBAN:        parsed.Value,
+		Valid:       true,
+		CountryISO2: parsed.CountryCode,
+		CheckDigits: parsed.CheckDigits,
+		BBAN:        parsed.BBAN,
+		BankCode:    parsed.BankCode,
+		BranchCode:  parsed.BranchCode,
+	}
+
+	bank, resolvedBy, err := s.resolveIBANBank(parsed)
+	if err != nil {
+		s.logger.Info("No SWIFT code for the bank code %s of %s: %v", parsed.NationalID(), parsed.CountryCode, err)
+		return response, nil
+	}
+
+	countryName, displayName, err := s.countryNames(bank.CountryISO2)
+	if err != nil {
+		s.logger.Error("Error looking up country name: %v", err)
+	}
+	response.ResolvedBy = resolvedBy
+	response.SwiftEntry = s.bankToResponse(&bank, countryName, displayName)
+	return response, nil
+}
+
+// resolveIBANBank looks up the bank code table with the branch first, then without it. Countries whose
+// bank codes are the institution codes of the BICs (e.g. NL, GB, MT) fall back to the headquarter of the institution
+func (s *SwiftCodeService) resolveIBANBank(parsed *iban.IBAN) (models.Bank, string, error) {
+	candidates := []string{parsed.NationalID()}
+	if parsed.BranchCode != "" {
+		candidates = append(candidates, parsed.BankCode)
+	}
+
+	for _, candidate := range candidates {
+		bankCode, err := s.repo.FindBankCode(parsed.CountryCode, candidate)
+		if errors.Is(err, repository.ErrBankCodeNotFound) {
+			continue
+		}
+		if err != nil {
+			return models.Bank{}, "", err
+		}
+
+		bank, err := s.repo.FindBySwiftCode(validators.NormalizeSwiftCode(bankCode.SwiftCode))
+		if err != nil {
+			return models.Bank{}, "", err
+		}
+		return bank, ResolvedByBankCodeTable, nil
+	}
+
+	if isInstitutionCode(parsed.BankCode) {
+		banks, err := s.repo.FindHeadquartersByInstitution(parsed.BankCode, parsed.CountryCode)
+		if err != nil {
+			return models.Bank{}, "", err
+		}
+		if len(banks) > 0 {
+			return banks[0], ResolvedByInstitutionCode, nil
+		}
+	}
+
+	return models.Bank{}, "", fmt.Errorf("bank code %s is not mapped", parsed.NationalID())
+}
+
+func isInstitutionCode(code string) bool {
+	return len(code) == 4 && strings.Trim(code, "ABCDEFGHIJKLMNOPQRSTUVWXYZ") == ""
+}
+
+// LoadBankCodes imports the bank code to SWIFT code mappings from a file
+func (s *SwiftCodeService) LoadBankCodes(filename string) error {
+	bankCodes, err := s.parser.ParseBankCodeFile(filename)
+	if err != nil {
+		return fmt.Errorf("error parsing bank code file: %w", err)
+	}
+
+	s.logger.Info("Inserting %d bank codes into database", len(bankCodes))
+	if err := s.repo.UpsertManyBankCodes(bankCodes); err != nil {
+		return fmt.Errorf("error inserting bank codes: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Hbrtjm/SWIFT_API/backend/pkg/validators"
+)
+
+var institutionCodePattern = regexp.MustCompile(`^[A-Z0-9]{4}$`)
+
+// ErrInvalidInstitutionCode is returned for codes which aren't four alphanumerics
+var ErrInvalidInstitutionCode = errors.New("invalid institution code")
+
+// InstitutionResponse groups every BIC8 and branch of an institution
+type InstitutionResponse struct {
+	InstitutionCode string               `json:"institutionCode"`
+	Count           int                  `json:"count"`
+	Countries       []InstitutionCountry `json:"countries"`
+	BIC8s           []InstitutionBIC8    `json:"bic8s"`
+}
+
+// InstitutionCountry counts the SWIFT codes of the institution in a country
+type InstitutionCountry struct {
+	CountryISO2        string `json:"countryISO2"`
+	CountryName        string `json:"countryName"`
+	CountryDisplayName string `json:"countryDisplayName"`
+	Count              int    `json:"count"`
+	BIC8Count          int    `json:"bic8Count"`
+}
+
+// InstitutionBIC8 is a BIC8 of the institution with its primary office and branches
+type InstitutionBIC8 struct {
+	BIC8        string `json:"bic8"`
+	CountryISO2 string `json:"countryISO2"`
+	// Nil when the primary office isn't in the database
+	Headquarter map[string]interface{}   `json:"headquarter"`
+	Branches    []map[string]interface{} `json:"branches"`
+}
+
+// GetInstitution lists the SWIFT codes of an institution, identified by the first four characters of a BIC
+func (s *SwiftCodeService) GetInstitution(institutionCode string) (*InstitutionResponse, error) {
+	return s.GetInstitutionFiltered(institutionCode, BankFilter{})
+}
+
+// GetInstitutionFiltered lists the SWIFT codes of an institution matching the filter. An institution without
+// SWIFT codes isn't found, while a filter matching none of its codes gives an empty response
+func (s *SwiftCodeService) GetInstitutionFiltered(institutionCode string, filter BankFilter) (*InstitutionResponse, error) {
+	institutionCode = strings.ToUpper(strings.TrimSpace(institutionCode))
+	if !institutionCodePattern.MatchString(institutionCode) {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidInstitutionCode, institutionCode)
+	}
+
+	banks, err := s.repo.FindByInstitutionCode(institutionCode)
+	if err != nil {
+		return nil, fmt.Errorf("bank lookup failed: %w", err)
+	}
+	if len(banks) == 0 {
+		return nil, fmt.Errorf("no banks found for institution %s", institutionCode)
+	}
+	banks, err = s.filterBanks(banks, filter, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	response := &InstitutionResponse{
+		InstitutionCode: institutionCode,
+		Count:           len(banks),
+		Countries:       []InstitutionCountry{},
+		BIC8s:           []InstitutionBIC8{},
+	}
+
+	countries := make(map[string]*InstitutionCountry)
+	bic8s := make(map[string]*InstitutionBIC8)
+	bic8Order := []string{}
+	for _, bank := range banks {
+		country, exists := countries[bank.CountryISO2]
+		if !exists {
+			countryName, displayName, err := s.countryNames(bank.CountryISO2)
+			if err != nil {
+				s.logger.Error("Error looking up country name: %v", err)
+			}
+			country = &InstitutionCountry{CountryISO2: bank.CountryISO2, CountryName: countryName, CountryDisplayName: displayName}
+			countries[bank.CountryISO2] = country
+		}
+		country.Count++
+
+		bic8 := bank.SwiftCode
+		if len(bic8) > 8 {
+			bic8 = bic8[:8]
+		}
+		group, exists := bic8s[bic8]
+		if !exists {
+			group = &InstitutionBIC8{BIC8: bic8, CountryISO2: bank.CountryISO2, Branches: []map[string]interface{}{}}
+			bic8s[bic8] = group
+			bic8Order = append(bic8Order, bic8)
+			country.BIC8Count++
+		}
+
+		if bank.SwiftCode == bic8+validators.PrimaryOfficeBranchCode || bank.SwiftCode == bic8 {
+			group.Headquarter = s.mapBankToMap(&bank)
+		} else {
+			group.Branches = append(group.Branches, s.mapBankToMap(&bank))
+		}
+	}
+
+	for _, country := range countries {
+		response.Countries = append(response.Countries, *country)
+	}
+	sort.Slice(response.Countries, func(i, j int) bool {
+		return response.Countries[i].CountryISO2 < response.Countries[j].CountryISO2
+	})
+
+	// The banks are sorted by the code, so the BIC8s are sorted as well
+	for _, bic8 := range bic8Order {
+		response.BIC8s = append(response.BIC8s, *bic8s[bic8])
+	}
+
+	return response, nil
+}
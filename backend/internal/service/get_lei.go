@@ -0,0 +1,118 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Hbrtjm/SWIFT_API/backend/internal/db/models"
+	"github.com/Hbrtjm/SWIFT_API/backend/internal/db/repository"
+	"github.com/Hbrtjm/SWIFT_API/backend/pkg/lei"
+	"github.com/Hbrtjm/SWIFT_API/backend/pkg/validators"
+)
+
+// Mappings of the GLEIF file upserted with a single bulk write
+const leiBatchSize = 1000
+
+// LEISwiftCodesResponse lists the SWIFT codes of a legal entity
+type LEISwiftCodesResponse struct {
+	LEI        string                   `json:"lei"`
+	SwiftCodes []map[string]interface{} `json:"swiftCodes"`
+	// Codes mapped to the LEI which are missing from the database
+	UnknownSwiftCodes []string `json:"unknownSwiftCodes,omitempty"`
+}
+
+// MissingLEIReport lists the SWIFT codes without a LEI
+type MissingLEIReport struct {
+	CountryISO2 string                   `json:"countryISO2,omitempty"`
+	Count       int                      `json:"count"`
+	SwiftCodes  []map[string]interface{} `json:"swiftCodes"`
+}
+
+// GetSwiftCodesByLEI finds the SWIFT codes mapped to the LEI, errors of the lei package are returned for a malformed LEI
+func (s *SwiftCodeService) GetSwiftCodesByLEI(value string) (*LEISwiftCodesResponse, error) {
+	validLEI, err := lei.Validate(value)
+	if err != nil {
+		return nil, err
+	}
+
+	swiftCodes, err := s.repo.FindSwiftCodesByLEI(validLEI)
+	if err != nil {
+		return nil, fmt.Errorf("error finding SWIFT codes of LEI %s: %w", validLEI, err)
+	}
+	if len(swiftCodes) == 0 {
+		return nil, fmt.Errorf("no SWIFT codes mapped to LEI %s: %w", validLEI, repository.ErrLEINotFound)
+	}
+
+	response := &LEISwiftCodesResponse{LEI: validLEI, SwiftCodes: []map[string]interface{}{}}
+	for _, swiftCode := range swiftCodes {
+		bank, err := s.repo.FindBySwiftCode(swiftCode)
+		if err != nil {
+			response.UnknownSwiftCodes = append(response.UnknownSwiftCodes, swiftCode)
+			continue
+		}
+		bankMap := s.mapBankToMap(&bank)
+		if countryName, err := s.repo.LookupCountryName(bank.CountryISO2); err == nil {
+			bankMap["countryName"] = countryName
+		}
+		response.SwiftCodes = append(response.SwiftCodes, bankMap)
+	}
+
+	return response, nil
+}
+
+// GetMissingLEIReport lists the banks without a LEI, a branch is covered by the LEI of its headquarter.
+// An empty country reports every country
+func (s *SwiftCodeService) GetMissingLEIReport(countryISO2 string) (*MissingLEIReport, error) {
+	if countryISO2 != "" {
+		if err := validators.NewCountryISO2CodeValidator().Validate(countryISO2, nil); err != nil {
+			return nil, fmt.Errorf("invalid country code %s: %v", countryISO2, err)
+		}
+		countryISO2 = strings.ToUpper(countryISO2)
+	}
+
+	banks, err := s.repo.FindBanksWithoutLEI(countryISO2)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &MissingLEIReport{CountryISO2: countryISO2, Count: len(banks), SwiftCodes: make([]map[string]interface{}, 0, len(banks))}
+	for _, bank := range banks {
+		report.SwiftCodes = append(report.SwiftCodes, s.mapBankToMap(&bank))
+	}
+	return report, nil
+}
+
+// leiOf returns the LEI of the bank, branches without their own mapping share the LEI of the headquarter. A bank
+// without a LEI gives an empty LEI, only the database errors are returned
+func (s *SwiftCodeService) leiOf(swiftCode string) (string, error) {
+	candidates := []string{swiftCode}
+	if len(swiftCode) == 11 && !strings.HasSuffix(swiftCode, validators.PrimaryOfficeBranchCode) {
+		candidates = append(candidates, swiftCode[:8]+validators.PrimaryOfficeBranchCode)
+	}
+
+	mapping, err := s.repo.FindLEIBySwiftCodes(candidates...)
+	if errors.Is(err, repository.ErrLEINotFound) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("error finding LEI of %s: %w", swiftCode, err)
+	}
+	return mapping.LEI, nil
+}
+
+// LoadLEIMappings imports the GLEIF BIC-to-LEI relationship file, upserting the mappings in batches as the file is read
+func (s *SwiftCodeService) LoadLEIMappings(filename string) error {
+	count, err := s.parser.ParseLEIMappingFile(filename, leiBatchSize, func(mappings []models.LEIMapping) error {
+		if err := s.repo.UpsertManyLEIMappings(mappings); err != nil {
+			return fmt.Errorf("error inserting LEI mappings: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("error loading LEI mapping file: %w", err)
+	}
+
+	s.logger.Info("Inserted %d LEI mappings into database", count)
+	return nil
+}
@@ -17,7 +17,7 @@ func (s *SwiftCodeService) GetMultipleSwiftCodes(codes []string) ([]map[string]i
 		bank, err := s.repo.FindBySwiftCode(code)
 		emptyBank := models.Bank{}
 		if err == nil && bank != emptyBank {
-			bankMap := mapBankToMap(&bank)
+			bankMap := s.mapBankToMap(&bank)
 
 			// Try to get country name, but don't fail if it's not found
 			countryName, err := s.repo.LookupCountryName(bank.CountryISO2)
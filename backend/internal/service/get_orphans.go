@@ -0,0 +1,66 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Hbrtjm/SWIFT_API/backend/pkg/validators"
+)
+
+// OrphanReport lists the branches without a headquarter and the branches named differently than their headquarter
+type OrphanReport struct {
+	CountryISO2 string `json:"countryISO2,omitempty"`
+	// Branches whose headquarter doesn't exist
+	BranchesWithoutHeadquarter []map[string]interface{} `json:"branchesWithoutHeadquarter"`
+	// Branches whose bank name differs from the headquarter
+	NameMismatches []NameMismatch `json:"nameMismatches"`
+}
+
+// NameMismatch is a branch with a bank name different than the name of its headquarter
+type NameMismatch struct {
+	SwiftCode           string `json:"swiftCode"`
+	BankName            string `json:"bankName"`
+	HeadquarterCode     string `json:"headquarterSwiftCode"`
+	HeadquarterBankName string `json:"headquarterBankName"`
+}
+
+// GetOrphanReport checks the links between the branches and their headquarters, an empty country reports every country
+func (s *SwiftCodeService) GetOrphanReport(countryISO2 string) (*OrphanReport, error) {
+	if countryISO2 != "" {
+		if err := validators.NewCountryISO2CodeValidator().Validate(countryISO2, nil); err != nil {
+			return nil, fmt.Errorf("invalid country code %s: %v", countryISO2, err)
+		}
+		countryISO2 = strings.ToUpper(countryISO2)
+	}
+
+	pairs, err := s.repo.FindBranchHeadquarters(countryISO2)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &OrphanReport{
+		CountryISO2:                countryISO2,
+		BranchesWithoutHeadquarter: []map[string]interface{}{},
+		NameMismatches:             []NameMismatch{},
+	}
+	for _, pair := range pairs {
+		if pair.Headquarter == nil {
+			report.BranchesWithoutHeadquarter = append(report.BranchesWithoutHeadquarter, s.mapBankToMap(&pair.Branch))
+			continue
+		}
+		if normalizeBankName(pair.Branch.BankName) != normalizeBankName(pair.Headquarter.BankName) {
+			report.NameMismatches = append(report.NameMismatches, NameMismatch{
+				SwiftCode:           pair.Branch.SwiftCode,
+				BankName:            pair.Branch.BankName,
+				HeadquarterCode:     pair.Headquarter.SwiftCode,
+				HeadquarterBankName: pair.Headquarter.BankName,
+			})
+		}
+	}
+	return report, nil
+}
+
+// normalizeBankName ignores the case and the repeated whitespace, which differ between the directory exports
+func normalizeBankName(name string) string {
+	return strings.Join(strings.Fields(strings.ToUpper(name)), " ")
+}
@@ -0,0 +1,90 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Rules for the branches posted without their headquarter
+const (
+	BranchRuleAllow  = "allow"
+	BranchRuleWarn   = "warn"
+	BranchRuleReject = "reject"
+)
+
+// Modes of the headquarter deletes
+const (
+	// The headquarter isn't deleted while it has branches
+	DeleteModeReject = "reject"
+	// The branches are deleted along with the headquarter
+	DeleteModeCascade = "cascade"
+	// Only the headquarter is deleted, the branches are kept without it
+	DeleteModeDetach = "detach"
+)
+
+var (
+	// ErrIntegrityViolation is returned when an operation would break the link between a headquarter and its branches
+	ErrIntegrityViolation = errors.New("integrity violation")
+	// ErrInvalidDeleteMode is returned for a delete mode other than reject, cascade or detach
+	ErrInvalidDeleteMode = errors.New("invalid delete mode")
+)
+
+// IntegrityRules configure the handling of the headquarter-branch links
+type IntegrityRules struct {
+	BranchWithoutHeadquarter string
+	HeadquarterDeleteMode    string
+}
+
+// DefaultIntegrityRules keep the behaviour from before the rules were introduced, branches without
+// a headquarter are only logged and a headquarter delete keeps the branches
+func DefaultIntegrityRules() IntegrityRules {
+	return IntegrityRules{
+		BranchWithoutHeadquarter: BranchRuleWarn,
+		HeadquarterDeleteMode:    DeleteModeDetach,
+	}
+}
+
+// Validate checks that the rules are known
+func (r IntegrityRules) Validate() error {
+	switch r.BranchWithoutHeadquarter {
+	case BranchRuleAllow, BranchRuleWarn, BranchRuleReject:
+	default:
+		return fmt.Errorf("unknown rule for branches without a headquarter: %s", r.BranchWithoutHeadquarter)
+	}
+	if !IsDeleteMode(r.HeadquarterDeleteMode) {
+		return fmt.Errorf("%w: %s", ErrInvalidDeleteMode, r.HeadquarterDeleteMode)
+	}
+	return nil
+}
+
+// IsDeleteMode checks that the mode is one of reject, cascade or detach
+func IsDeleteMode(mode string) bool {
+	return mode == DeleteModeReject || mode == DeleteModeCascade || mode == DeleteModeDetach
+}
+
+// SetIntegrityRules replaces the integrity rules of the service
+func (s *SwiftCodeService) SetIntegrityRules(rules IntegrityRules) error {
+	if err := rules.Validate(); err != nil {
+		return err
+	}
+	s.integrity = rules
+	return nil
+}
+
+// checkBranchHeadquarter applies the rule for branches posted without their headquarter
+func (s *SwiftCodeService) checkBranchHeadquarter(swiftCode string) error {
+	if s.integrity.BranchWithoutHeadquarter == BranchRuleAllow {
+		return nil
+	}
+
+	headquarterCode := headquarterOf(swiftCode)
+	if _, err := s.repo.FindBySwiftCode(headquarterCode); err == nil {
+		return nil
+	}
+
+	if s.integrity.BranchWithoutHeadquarter == BranchRuleReject {
+		return fmt.Errorf("%w: headquarter %s of branch %s doesn't exist", ErrIntegrityViolation, headquarterCode, swiftCode)
+	}
+	s.logger.Warning("Branch %s is added without its headquarter %s", swiftCode, headquarterCode)
+	return nil
+}
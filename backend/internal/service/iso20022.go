@@ -0,0 +1,75 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/Hbrtjm/SWIFT_API/backend/pkg/clearing"
+	"github.com/Hbrtjm/SWIFT_API/backend/pkg/iso20022"
+)
+
+// GetFinancialInstitution renders the bank as the ISO 20022 FinInstnId block. The member id is taken from the
+// requested clearing system, or by default from the domestic system of the bank's country. The block is validated
+// against the embedded schema, an invalid block isn't returned, only the validation error wrapping
+// iso20022.ErrInvalidDocument
+func (s *SwiftCodeService) GetFinancialInstitution(code, clearingSystem string) (*iso20022.FinancialInstitutionIdentification, error) {
+	var requested clearing.System
+	if clearingSystem != "" {
+		system, ok := clearing.LookupSystem(clearingSystem)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", clearing.ErrUnknownSystem, clearingSystem)
+		}
+		requested = system
+	}
+
+	bank, err := s.lookupBank(code)
+	if err != nil {
+		return nil, err
+	}
+
+	lei, err := s.leiOf(bank.SwiftCode)
+	if err != nil {
+		return nil, err
+	}
+	institution := iso20022.Institution{
+		BIC:        bank.SwiftCode,
+		LEI:        lei,
+		Name:       bank.BankName,
+		Address:    structuredAddressOf(&bank),
+		RawAddress: bank.Address,
+	}
+	clearingCodes, err := s.clearingCodesOf(bank.SwiftCode)
+	if err != nil {
+		return nil, err
+	}
+	if entry, ok := memberIDOf(clearingCodes, requested, bank.CountryISO2); ok {
+		institution.ClearingSystem, institution.MemberID = entry.ClearingSystem, entry.MemberID
+	}
+
+	result := iso20022.NewFinancialInstitutionIdentification(institution)
+	if err := result.Validate(); err != nil {
+		return nil, fmt.Errorf("error rendering %s: %w", bank.SwiftCode, err)
+	}
+	return &result, nil
+}
+
+// memberIDOf picks the clearing code rendered in the block, the block holds a single member id
+func memberIDOf(entries []ClearingCodeEntry, requested clearing.System, countryISO2 string) (ClearingCodeEntry, bool) {
+	if requested.Code != "" {
+		for _, entry := range entries {
+			if entry.ClearingSystem == requested.Code {
+				return entry, true
+			}
+		}
+		return ClearingCodeEntry{}, false
+	}
+
+	for _, entry := range entries {
+		if system, ok := clearing.LookupSystem(entry.ClearingSystem); ok && system.CountryISO2 == countryISO2 {
+			return entry, true
+		}
+	}
+	if len(entries) > 0 {
+		return entries[0], true
+	}
+	return ClearingCodeEntry{}, false
+}
@@ -0,0 +1,36 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/Hbrtjm/SWIFT_API/backend/pkg/validators"
+)
+
+// Links point to the related resources of a bank
+type Links struct {
+	Self        string `json:"self"`
+	Headquarter string `json:"headquarter,omitempty"`
+	Branches    string `json:"branches,omitempty"`
+	Country     string `json:"country"`
+	// Code taking over a retired or merged bank
+	Successor string `json:"successor,omitempty"`
+}
+
+// apiPath builds a path of the API under the base path of the version the service was created with
+func (s *SwiftCodeService) apiPath(format string, args ...interface{}) string {
+	return s.basePath + fmt.Sprintf(format, args...)
+}
+
+// bankLinks returns the links of a bank, the headquarter links to its branches and the branches to their headquarter
+func (s *SwiftCodeService) bankLinks(swiftCode, countryISO2 string, isHeadquarter bool) Links {
+	links := Links{
+		Self:    s.apiPath("/swift-codes/%s", swiftCode),
+		Country: s.apiPath("/swift-codes/country/%s", countryISO2),
+	}
+	if isHeadquarter {
+		links.Branches = s.apiPath("/swift-codes/%s/branches", swiftCode)
+	} else if len(swiftCode) == 11 {
+		links.Headquarter = s.apiPath("/swift-codes/%s", swiftCode[:8]+validators.PrimaryOfficeBranchCode)
+	}
+	return links
+}
@@ -6,29 +6,41 @@ import (
 
 	"github.com/Hbrtjm/SWIFT_API/backend/internal/db/models"
 	"github.com/Hbrtjm/SWIFT_API/backend/internal/db/repository"
+	"github.com/Hbrtjm/SWIFT_API/backend/pkg/address"
+	"github.com/Hbrtjm/SWIFT_API/backend/pkg/timezones"
 	"github.com/Hbrtjm/SWIFT_API/backend/pkg/validators"
 )
 
 // PostBankData creates a new bank entry in the database
 func (s *SwiftCodeService) PostBankData(bankData map[string]interface{}) error {
 	bankValidator := validators.NewBankRequestValidator()
-	err := bankValidator.ValidateAndSanitize(bankData)
-	if err != nil {
+	validationResult := bankValidator.Validate(bankData)
+	if err := validationResult.Err(); err != nil {
 		return fmt.Errorf("validation error: %w", err)
 	}
+	s.logValidationWarnings(validationResult)
 
 	countryISO2, _ := bankData["countryISO2"].(string)
 	countryName, _ := bankData["countryName"].(string)
+
+	// A missing zone is inferred from the town, the validator has already checked the provided one
 	timeZone, _ := bankData["timeZone"].(string)
+	if timeZone == "" {
+		timeZone = timezones.InferZone(countryISO2, getValue(bankData, "townName"))
+	}
 
 	country := models.Country{
 		CountryISO2: strings.ToUpper(countryISO2),
-		CountryName: strings.ToUpper(countryName),
-		TimeZone:    timeZone,
+		CountryName: strings.ToUpper(strings.TrimSpace(countryName)),
+		DisplayName: strings.TrimSpace(countryName),
+		TimeZones:   []string{},
+	}
+	if timeZone != "" {
+		country.TimeZones = append(country.TimeZones, timeZone)
 	}
 
 	// Add or update the country in the database
-	err = s.repo.InsertCountry(country)
+	err := s.repo.InsertCountry(country)
 	if err != nil {
 		return fmt.Errorf("failed to process country data: %w", err)
 	}
@@ -42,13 +54,29 @@ func (s *SwiftCodeService) PostBankData(bankData map[string]interface{}) error {
 		BankName:      getValue(bankData, "bankName"),
 		Address:       getValue(bankData, "address"),
 		TownName:      getValue(bankData, "townName"),
+		TimeZone:      timeZone,
 		IsHeadquarter: getBool(bankData, "isHeadquarter"),
 		BranchCode:    "",
 	}
 
+	bank.StructuredAddress = address.Parse(bank.Address, bank.TownName, bank.CountryISO2)
+
 	// Get the first 8 characters of the SWFIT code for the branch code
 	bank.BranchCode = swiftCode[:8]
 
+	// The code has already been validated, so the analysis cannot fail here
+	if analysis, err := validators.NewBICAnalyzer().Analyze(swiftCode); err == nil {
+		bank.InstitutionCode = analysis.InstitutionCode
+		bank.IsTestAndTraining = analysis.IsTestAndTraining
+		bank.IsPassiveParticipant = analysis.IsPassiveParticipant
+	}
+
+	if !bank.IsHeadquarter {
+		if err := s.checkBranchHeadquarter(swiftCode); err != nil {
+			return err
+		}
+	}
+
 	err = s.repo.InsertBank(bank)
 	if err != nil {
 		if err == repository.ErrBankExists {
@@ -57,5 +85,11 @@ func (s *SwiftCodeService) PostBankData(bankData map[string]interface{}) error {
 		return fmt.Errorf("failed to insert bank: %w", err)
 	}
 
+	// The bank is stored either way, a failed screening is left pending for the sanctions watcher
+	if err := s.screenBank(&bank); err != nil {
+		s.logger.Error("Error screening %s, pending the next check: %v", swiftCode, err)
+		s.markScreeningPending(swiftCode)
+	}
+
 	return nil
 }
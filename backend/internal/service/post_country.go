@@ -16,17 +16,20 @@ func (s *SwiftCodeService) PostCountry(countryData map[string]interface{}) error
 	}
 
 	validator := validators.NewCountryValidator()
-	if err := validator.ValidateAndSanitize(countryData); err != nil {
+	validationResult := validator.Validate(countryData)
+	if err := validationResult.Err(); err != nil {
 		return fmt.Errorf("validation error: %w", err)
 	}
+	s.logValidationWarnings(validationResult)
 
 	countryName, _ := countryData["countryName"].(string)
 	timeZone, _ := countryData["timeZone"].(string)
 
 	country := models.Country{
 		CountryISO2: strings.ToUpper(countryISO2),
-		CountryName: strings.ToUpper(countryName),
-		TimeZone:    timeZone,
+		CountryName: strings.ToUpper(strings.TrimSpace(countryName)),
+		DisplayName: strings.TrimSpace(countryName),
+		TimeZones:   []string{timeZone},
 	}
 
 	err := s.repo.InsertCountry(country)
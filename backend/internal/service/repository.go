@@ -0,0 +1,71 @@
+package service
+
+import (
+	"time"
+
+	"github.com/Hbrtjm/SWIFT_API/backend/internal/db/models"
+	"github.com/Hbrtjm/SWIFT_API/backend/internal/db/repository"
+)
+
+// Repository is the storage used by the service, implemented by repository.MongoRepository
+type Repository interface {
+	// Banks
+	FindBySwiftCode(swiftCode string) (models.Bank, error)
+	FindBySwiftCodes(swiftCodes ...string) ([]models.Bank, error)
+	FindByCountry(countryISO2 string) ([]models.Bank, error)
+	FindByInstitutionCode(institutionCode string) ([]models.Bank, error)
+	FindByNamePrefixes(countryISO2 string, prefixes []string, limit int64) ([]models.Bank, error)
+	FindHeadquartersByInstitution(institutionCode, countryISO2 string) ([]models.Bank, error)
+	FindAllBanks() ([]models.Bank, error)
+	FindBranchesPage(bic8, after string, limit int) ([]models.Bank, error)
+	FindByCountriesPage(countries []string, after string, limit int) ([]models.Bank, error)
+	FindBranchHeadquarters(countryISO2 string) ([]repository.BranchHeadquarter, error)
+	CountBranches(bic8 string) (int64, error)
+	InsertBank(bank models.Bank) error
+	InsertManyBanks(banks []models.Bank) error
+	Delete(code string) error
+	DeleteBranches(bic8 string) (int64, error)
+
+	// Countries
+	GetCountry(countryISO2 string) (models.Country, error)
+	LookupCountryName(countryISO2 string) (string, error)
+	InsertCountry(country models.Country) error
+	InsertManyCountries(countries []models.Country) error
+
+	// Status changes
+	UpdateBankStatus(swiftCode, status, successorSwiftCode string, effectiveDate time.Time) error
+	UpsertStatusChange(change models.StatusChange) error
+	FindPendingStatusChanges(swiftCode string) ([]models.StatusChange, error)
+	FindDueStatusChanges(before time.Time) ([]models.StatusChange, error)
+	MarkStatusChangeApplied(change models.StatusChange, appliedAt time.Time) error
+
+	// National codes and identifiers
+	FindBankCode(countryISO2, bankCode string) (models.BankCode, error)
+	UpsertManyBankCodes(bankCodes []models.BankCode) error
+	FindClearingCode(clearingSystem, memberID string) (models.ClearingCode, error)
+	FindClearingCodesBySwiftCode(swiftCode string) ([]models.ClearingCode, error)
+	UpsertManyClearingCodes(clearingCodes []models.ClearingCode) error
+	FindLEIBySwiftCodes(swiftCodes ...string) (models.LEIMapping, error)
+	FindSwiftCodesByLEI(lei string) ([]string, error)
+	FindBanksWithoutLEI(countryISO2 string) ([]models.Bank, error)
+	UpsertManyLEIMappings(mappings []models.LEIMapping) error
+
+	// Correspondents and payment schemes
+	FindCorrespondentsBySwiftCode(swiftCode, currency string) ([]models.Correspondent, error)
+	FindCorrespondentsByCurrency(currency string) ([]models.Correspondent, error)
+	UpsertManyCorrespondents(correspondents []models.Correspondent) error
+	DeleteCorrespondent(correspondent models.Correspondent) error
+	FindSchemeParticipants(scheme string, at time.Time) ([]string, error)
+	FindSchemeParticipations(swiftCodes ...string) ([]models.SchemeParticipation, error)
+	UpsertManySchemeParticipations(participations []models.SchemeParticipation) error
+
+	// Sanctions screening
+	FindSanctionEntries() ([]models.SanctionEntry, error)
+	FindSanctionLists() ([]models.SanctionList, error)
+	ReplaceSanctionList(list models.SanctionList, entries []models.SanctionEntry) error
+	FindScreeningHits(list, countryISO2, matchType string) ([]models.ScreeningHit, error)
+	FindScreeningHitsBySwiftCode(swiftCode string) ([]models.ScreeningHit, error)
+	ReplaceScreeningHits(hits []models.ScreeningHit, screenedAt time.Time) error
+	ReplaceScreeningHitsOf(swiftCode string, hits []models.ScreeningHit) error
+	DeleteScreeningHitsByPrefix(prefix string) error
+}
@@ -0,0 +1,260 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Hbrtjm/SWIFT_API/backend/internal/db/models"
+	"github.com/Hbrtjm/SWIFT_API/backend/pkg/iso3166"
+	"github.com/Hbrtjm/SWIFT_API/backend/pkg/names"
+)
+
+const (
+	DefaultResolveLimit = 10
+	MaxResolveLimit     = 50
+	// Candidates scored lower are left out unless the request sets minScore
+	DefaultResolveMinScore = 0.4
+	// Banks scored at most for a request, the ones sharing the most word prefixes with the request
+	maxResolveCandidates = 2000
+)
+
+var ErrInvalidResolveRequest = errors.New("invalid resolve request")
+
+// Weights of the fields in the score, the fields missing from the request are left out and the rest weighs the same
+// in proportion
+var resolveWeights = map[string]float64{
+	"name":    0.65,
+	"town":    0.25,
+	"address": 0.1,
+}
+
+// ResolveRequest describes an institution as written in a payment instruction
+type ResolveRequest struct {
+	Name string `json:"name"`
+	Town string `json:"town"`
+	// ISO 3166 alpha-2 code or name of the country, the candidates are limited to the country
+	Country string `json:"country"`
+	// Fragments of the address, such as the street
+	Address []string `json:"address"`
+	Limit   int      `json:"limit"`
+	// Score from 0 to 1, DefaultResolveMinScore when zero
+	MinScore float64 `json:"minScore"`
+}
+
+// ResolveResponse lists the candidates from the best
+type ResolveResponse struct {
+	Query      ResolveQuery       `json:"query"`
+	Candidates []ResolveCandidate `json:"candidates"`
+}
+
+// ResolveQuery is the request as it was compared
+type ResolveQuery struct {
+	Name        string `json:"name"`
+	Town        string `json:"town,omitempty"`
+	CountryISO2 string `json:"countryISO2,omitempty"`
+	Address     string `json:"address,omitempty"`
+}
+
+// ResolveCandidate is a directory entry with its score and the explanation of the score
+type ResolveCandidate struct {
+	SwiftCode     string  `json:"swiftCode"`
+	BankName      string  `json:"bankName"`
+	TownName      string  `json:"townName"`
+	CountryISO2   string  `json:"countryISO2"`
+	Address       string  `json:"address"`
+	IsHeadquarter bool    `json:"isHeadquarter"`
+	Status        string  `json:"status"`
+	Score         float64 `json:"score"`
+	// One component per compared field, the score is the sum of their weighted scores
+	Explanation []ScoreComponent `json:"explanation"`
+}
+
+// ScoreComponent explains the score of a field
+type ScoreComponent struct {
+	Field  string  `json:"field"`
+	Weight float64 `json:"weight"`
+	Score  float64 `json:"score"`
+	// Normalized values
+	Query     string             `json:"query"`
+	Candidate string             `json:"candidate"`
+	Matches   []names.TokenMatch `json:"matches"`
+}
+
+// resolveField is a field of the request compared with the candidates
+type resolveField struct {
+	name   string
+	weight float64
+	query  names.Name
+	// Gives the compared value of the bank
+	value func(bank *models.Bank) string
+	// The address fragments only have to be found in the address, the other fields are compared both ways
+	coverage bool
+}
+
+// Resolve scores the directory entries against the name, the town and the address of an institution. The entries
+// are looked up by the prefixes of the words of the name and of the town, then compared with fuzzy matching
+func (s *SwiftCodeService) Resolve(request ResolveRequest) (*ResolveResponse, error) {
+	limit := request.Limit
+	if limit == 0 {
+		limit = DefaultResolveLimit
+	}
+	if limit < 0 || limit > MaxResolveLimit {
+		return nil, fmt.Errorf("%w: %d, expected 1 to %d", ErrInvalidLimit, request.Limit, MaxResolveLimit)
+	}
+	minScore := request.MinScore
+	if minScore == 0 {
+		minScore = DefaultResolveMinScore
+	}
+	if minScore < 0 || minScore > 1 {
+		return nil, fmt.Errorf("%w: minScore %v is not between 0 and 1", ErrInvalidResolveRequest, request.MinScore)
+	}
+
+	name := names.Parse(request.Name)
+	if name.IsEmpty() {
+		return nil, fmt.Errorf("%w: the name is required", ErrInvalidResolveRequest)
+	}
+	countryISO2 := ""
+	if strings.TrimSpace(request.Country) != "" {
+		country, ok := iso3166.Lookup(request.Country)
+		if !ok {
+			country, ok = iso3166.LookupName(request.Country)
+		}
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown country %q", ErrInvalidResolveRequest, request.Country)
+		}
+		countryISO2 = country.Alpha2
+	}
+
+	fields := []resolveField{{name: "name", query: name, value: func(bank *models.Bank) string { return bank.BankName }}}
+	if town := names.Parse(request.Town); !town.IsEmpty() {
+		fields = append(fields, resolveField{name: "town", query: town, value: func(bank *models.Bank) string { return bank.TownName }})
+	}
+	if address := names.Parse(strings.Join(request.Address, " ")); !address.IsEmpty() {
+		fields = append(fields, resolveField{name: "address", query: address, value: func(bank *models.Bank) string { return bank.Address }, coverage: true})
+	}
+	totalWeight := 0.0
+	for _, field := range fields {
+		totalWeight += resolveWeights[field.name]
+	}
+	for i := range fields {
+		fields[i].weight = resolveWeights[fields[i].name] / totalWeight
+	}
+
+	response := &ResolveResponse{
+		Query:      ResolveQuery{Name: name.Normalized, CountryISO2: countryISO2},
+		Candidates: []ResolveCandidate{},
+	}
+	for _, field := range fields[1:] {
+		switch field.name {
+		case "town":
+			response.Query.Town = field.query.Normalized
+		case "address":
+			response.Query.Address = field.query.Normalized
+		}
+	}
+
+	banks, err := s.repo.FindByNamePrefixes(countryISO2, resolvePrefixes(fields), maxResolveCandidates)
+	if err != nil {
+		return nil, fmt.Errorf("error looking up the candidates: %w", err)
+	}
+	for i := range banks {
+		candidate := scoreCandidate(&banks[i], fields)
+		if candidate.Score >= minScore {
+			response.Candidates = append(response.Candidates, candidate)
+		}
+	}
+
+	// The headquarters go first among the equally scored branches of an institution
+	sort.SliceStable(response.Candidates, func(i, j int) bool {
+		a, b := response.Candidates[i], response.Candidates[j]
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		if a.IsHeadquarter != b.IsHeadquarter {
+			return a.IsHeadquarter
+		}
+		return a.SwiftCode < b.SwiftCode
+	})
+	if len(response.Candidates) > limit {
+		response.Candidates = response.Candidates[:limit]
+	}
+	return response, nil
+}
+
+func scoreCandidate(bank *models.Bank, fields []resolveField) ResolveCandidate {
+	candidate := ResolveCandidate{
+		SwiftCode:     bank.SwiftCode,
+		BankName:      bank.BankName,
+		TownName:      bank.TownName,
+		CountryISO2:   bank.CountryISO2,
+		Address:       bank.Address,
+		IsHeadquarter: bank.IsHeadquarter,
+		Status:        statusOf(bank),
+		Explanation:   make([]ScoreComponent, 0, len(fields)),
+	}
+
+	score := 0.0
+	for _, field := range fields {
+		value := names.Parse(field.value(bank))
+		comparison := names.Compare(field.query, value)
+		component := ScoreComponent{
+			Field:     field.name,
+			Weight:    names.Round(field.weight),
+			Score:     comparison.Score,
+			Query:     field.query.Normalized,
+			Candidate: value.Normalized,
+			Matches:   comparison.Matches,
+		}
+		if field.coverage {
+			component.Score = comparison.Coverage
+			// The rest of the address isn't expected in the fragments
+			component.Matches = onlyQueryMatches(comparison.Matches)
+		}
+		score += field.weight * component.Score
+		candidate.Explanation = append(candidate.Explanation, component)
+	}
+	candidate.Score = names.Round(score)
+	return candidate
+}
+
+// resolvePrefixes are the beginnings of the distinctive words of the name and the town, the generic words are only
+// used when the name has nothing else
+func resolvePrefixes(fields []resolveField) []string {
+	seen := map[string]bool{}
+	prefixes := []string{}
+	add := func(text string) {
+		if prefix := names.Prefix(text); !seen[prefix] {
+			seen[prefix] = true
+			prefixes = append(prefixes, prefix)
+		}
+	}
+
+	for _, field := range fields {
+		if field.name == "address" {
+			continue
+		}
+		for _, token := range field.query.Tokens {
+			if token.Kind == names.KindWord {
+				add(token.Text)
+			}
+		}
+	}
+	if len(prefixes) == 0 {
+		for _, token := range fields[0].query.Tokens {
+			add(token.Text)
+		}
+	}
+	return prefixes
+}
+
+func onlyQueryMatches(matches []names.TokenMatch) []names.TokenMatch {
+	result := []names.TokenMatch{}
+	for _, match := range matches {
+		if match.Reason != names.ReasonExtra {
+			result = append(result, match)
+		}
+	}
+	return result
+}
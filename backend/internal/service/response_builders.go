@@ -2,43 +2,72 @@ package service
 
 import (
 	"github.com/Hbrtjm/SWIFT_API/backend/internal/db/models"
+	"github.com/Hbrtjm/SWIFT_API/backend/pkg/address"
 )
 
 // Helper function to map a Bank model to SwiftCodeResponse
-func bankToResponse(bank *models.Bank, countryName string) *SwiftCodeResponse {
-	return &SwiftCodeResponse{
-		Address:       bank.Address,
-		BankName:      bank.BankName,
-		CountryISO2:   bank.CountryISO2,
-		CountryName:   countryName,
-		IsHeadquarter: bank.IsHeadquarter,
-		SwiftCode:     bank.SwiftCode,
+func (s *SwiftCodeService) bankToResponse(bank *models.Bank, countryName, countryDisplayName string) *SwiftCodeResponse {
+	response := &SwiftCodeResponse{
+		Address:            bank.Address,
+		BankName:           bank.BankName,
+		CountryISO2:        bank.CountryISO2,
+		CountryName:        countryName,
+		CountryDisplayName: countryDisplayName,
+		IsHeadquarter:      bank.IsHeadquarter,
+		SwiftCode:          bank.SwiftCode,
+		TimeZone:           bank.TimeZone,
+		Links:              s.bankLinks(bank.SwiftCode, bank.CountryISO2, bank.IsHeadquarter),
 	}
+
+	if structuredAddress := structuredAddressOf(bank); !structuredAddress.IsZero() {
+		response.StructuredAddress = &structuredAddress
+	}
+
+	if bank.Status != "" {
+		response.Status = bank.Status
+		response.SuccessorSwiftCode = successorOf(bank)
+		if bank.StatusEffectiveDate != nil {
+			effectiveDate := bank.StatusEffectiveDate.UTC()
+			response.StatusEffectiveDate = &effectiveDate
+		}
+		if response.SuccessorSwiftCode != "" {
+			response.Links.Successor = s.apiPath("/swift-codes/%s", response.SuccessorSwiftCode)
+		}
+	}
+	return response
 }
 
 // Helper function to map a Bank model to a map[string]interface{}
-func mapBankToMap(bank *models.Bank) map[string]interface{} {
-	return map[string]interface{}{
+func (s *SwiftCodeService) mapBankToMap(bank *models.Bank) map[string]interface{} {
+	bankMap := map[string]interface{}{
 		"address":       bank.Address,
 		"bankName":      bank.BankName,
 		"countryISO2":   bank.CountryISO2,
 		"isHeadquarter": bank.IsHeadquarter,
 		"swiftCode":     bank.SwiftCode,
+		"timeZone":      bank.TimeZone,
+		"links":         s.bankLinks(bank.SwiftCode, bank.CountryISO2, bank.IsHeadquarter),
 	}
+	addStatus(bankMap, bank.Status, successorOf(bank))
+	return bankMap
 }
 
-func mapBranchValues(value map[string]interface{}) map[string]interface{} {
-	address, _ := value["address"].(string)
-	bankName, _ := value["bankName"].(string)
-	countryISO2, _ := value["countryISO2"].(string)
-	isHeadquarter, _ := value["isHeadquarter"].(bool)
-	swiftCode, _ := value["swiftCode"].(string)
+// addStatus adds the status to the map of a bank, the banks which never had their status changed are left as they were
+func addStatus(bankMap map[string]interface{}, status, successor string) {
+	if status == "" {
+		return
+	}
+	bankMap["status"] = status
+	if successor != "" {
+		bankMap["successorSwiftCode"] = successor
+	}
+}
 
-	return map[string]interface{}{
-		"address":       address,
-		"bankName":      bankName,
-		"countryISO2":   countryISO2,
-		"isHeadquarter": isHeadquarter,
-		"swiftCode":     swiftCode,
+// structuredAddressOf returns the parsed address of the bank, the banks stored before the addresses were parsed at
+// ingestion are parsed on the fly
+func structuredAddressOf(bank *models.Bank) address.PostalAddress {
+	if !bank.StructuredAddress.IsZero() {
+		return bank.StructuredAddress
 	}
+	return address.Parse(bank.Address, bank.TownName, bank.CountryISO2)
 }
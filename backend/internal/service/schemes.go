@@ -0,0 +1,136 @@
+package service
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Hbrtjm/SWIFT_API/backend/internal/db/models"
+	"github.com/Hbrtjm/SWIFT_API/backend/pkg/countryinfo"
+	"github.com/Hbrtjm/SWIFT_API/backend/pkg/schemes"
+	"github.com/Hbrtjm/SWIFT_API/backend/pkg/validators"
+)
+
+// SchemeEntry is a payment scheme through which the bank is reachable
+type SchemeEntry struct {
+	Scheme string `json:"scheme"`
+	Name   string `json:"name"`
+	// Omitted when the bank has always been reachable
+	AdherenceDate *time.Time `json:"adherenceDate,omitempty"`
+	// The participation of the headquarter covers its branches
+	ViaHeadquarter bool `json:"viaHeadquarter,omitempty"`
+}
+
+// BankFilter narrows down the listings of the banks, the empty fields don't filter
+type BankFilter struct {
+	// Code or alias of a payment scheme the bank has to be reachable through
+	Scheme string
+	// Group of countries the bank has to be located in, e.g. SEPA or Western Europe
+	Region string
+}
+
+// schemesOf lists the schemes the bank is reachable through at the time, directly or through its headquarter
+func (s *SwiftCodeService) schemesOf(swiftCode string, at time.Time) ([]SchemeEntry, error) {
+	candidates := []string{swiftCode}
+	if len(swiftCode) == 11 && !strings.HasSuffix(swiftCode, validators.PrimaryOfficeBranchCode) {
+		candidates = append(candidates, headquarterOf(swiftCode))
+	}
+
+	participations, err := s.repo.FindSchemeParticipations(candidates...)
+	if err != nil {
+		return nil, fmt.Errorf("error finding scheme participations of %s: %w", swiftCode, err)
+	}
+
+	entries := []SchemeEntry{}
+	for _, participation := range participations {
+		if participation.AdherenceDate.After(at) {
+			continue
+		}
+		viaHeadquarter := participation.SwiftCode != swiftCode
+		// The own participation of the branch takes precedence
+		if len(entries) > 0 && entries[len(entries)-1].Scheme == participation.Scheme {
+			if viaHeadquarter {
+				continue
+			}
+			entries = entries[:len(entries)-1]
+		}
+		entries = append(entries, schemeEntry(participation, viaHeadquarter))
+	}
+	return entries, nil
+}
+
+func schemeEntry(participation models.SchemeParticipation, viaHeadquarter bool) SchemeEntry {
+	entry := SchemeEntry{Scheme: participation.Scheme, ViaHeadquarter: viaHeadquarter}
+	if scheme, ok := schemes.Lookup(participation.Scheme); ok {
+		entry.Name = scheme.Name
+	}
+	if !participation.AdherenceDate.IsZero() {
+		adherenceDate := participation.AdherenceDate.UTC()
+		entry.AdherenceDate = &adherenceDate
+	}
+	return entry
+}
+
+// filterBanks keeps the banks matching the filter at the time
+func (s *SwiftCodeService) filterBanks(banks []models.Bank, filter BankFilter, at time.Time) ([]models.Bank, error) {
+	if filter.Region != "" {
+		region, err := countryinfo.ParseRegion(filter.Region)
+		if err != nil {
+			return nil, err
+		}
+		inRegion := make([]models.Bank, 0, len(banks))
+		for _, bank := range banks {
+			if region.Contains(bank.CountryISO2) {
+				inRegion = append(inRegion, bank)
+			}
+		}
+		banks = inRegion
+	}
+
+	if filter.Scheme == "" {
+		return banks, nil
+	}
+
+	scheme, ok := schemes.Lookup(filter.Scheme)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", schemes.ErrUnknownScheme, filter.Scheme)
+	}
+	participants, err := s.repo.FindSchemeParticipants(scheme.Code, at)
+	if err != nil {
+		return nil, err
+	}
+	reachable := make(map[string]bool, len(participants))
+	for _, participant := range participants {
+		reachable[participant] = true
+	}
+
+	filtered := make([]models.Bank, 0, len(banks))
+	for _, bank := range banks {
+		if reachable[bank.SwiftCode] || (len(bank.SwiftCode) == 11 && reachable[headquarterOf(bank.SwiftCode)]) {
+			filtered = append(filtered, bank)
+		}
+	}
+	return filtered, nil
+}
+
+// LoadSchemeParticipants imports every CSV file of the directory with the participants of the payment schemes
+func (s *SwiftCodeService) LoadSchemeParticipants(dir string) error {
+	files, err := filepath.Glob(filepath.Join(dir, "*.csv"))
+	if err != nil {
+		return fmt.Errorf("error listing scheme participant files: %w", err)
+	}
+
+	for _, file := range files {
+		participations, err := s.parser.ParseSchemeParticipantFile(file)
+		if err != nil {
+			return fmt.Errorf("error parsing scheme participant file %s: %w", file, err)
+		}
+
+		s.logger.Info("Inserting %d scheme participations from %s into database", len(participations), filepath.Base(file))
+		if err := s.repo.UpsertManySchemeParticipations(participations); err != nil {
+			return fmt.Errorf("error inserting scheme participations: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,319 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Hbrtjm/SWIFT_API/backend/internal/db/models"
+	"github.com/Hbrtjm/SWIFT_API/backend/pkg/sanctions"
+	"github.com/Hbrtjm/SWIFT_API/backend/pkg/validators"
+)
+
+var (
+	ErrUnknownSanctionsList   = errors.New("unknown sanctions list")
+	ErrInvalidScreeningFilter = errors.New("invalid screening filter")
+)
+
+// ScreeningEntry is a sanctions list entry matching the bank
+type ScreeningEntry struct {
+	List      string `json:"list"`
+	EntryID   string `json:"entryId"`
+	EntryName string `json:"entryName"`
+	// bic or name
+	MatchType    string    `json:"matchType"`
+	Score        float64   `json:"score"`
+	CountryMatch bool      `json:"countryMatch"`
+	Programs     []string  `json:"programs"`
+	ScreenedAt   time.Time `json:"screenedAt"`
+}
+
+// ScreeningReport lists the banks matching the sanctions lists, along with the imported lists
+type ScreeningReport struct {
+	Lists       []models.SanctionList `json:"lists"`
+	List        string                `json:"list,omitempty"`
+	CountryISO2 string                `json:"countryISO2,omitempty"`
+	MatchType   string                `json:"matchType,omitempty"`
+	// Number of the banks with at least one hit
+	Banks int                   `json:"banks"`
+	Count int                   `json:"count"`
+	Hits  []models.ScreeningHit `json:"hits"`
+}
+
+// importSanctionsList imports a sanctions list file, replacing the previous import of the list
+func (s *SwiftCodeService) importSanctionsList(list, filename string) error {
+	if !sanctions.IsList(list) {
+		return fmt.Errorf("%w: %s", ErrUnknownSanctionsList, list)
+	}
+
+	entries, err := s.parser.ParseSanctionsFile(list, filename)
+	if err != nil {
+		return fmt.Errorf("error parsing sanctions list: %w", err)
+	}
+
+	s.logger.Info("Inserting %d entries of the %s sanctions list into database", len(entries), list)
+	// Mongo keeps the milliseconds, the entries of the older imports are found by the stored time
+	loadedAt := time.Now().UTC().Truncate(time.Millisecond)
+	for i := range entries {
+		entries[i].LoadedAt = loadedAt
+	}
+	record := models.SanctionList{List: list, Source: filepath.Base(filename), Entries: len(entries), LoadedAt: loadedAt}
+	if err := s.repo.ReplaceSanctionList(record, entries); err != nil {
+		return fmt.Errorf("error inserting sanctions list: %w", err)
+	}
+	return nil
+}
+
+// ScreenDirectory screens every bank against the imported lists and replaces the hits, the number of the hits is
+// returned
+func (s *SwiftCodeService) ScreenDirectory() (int, error) {
+	s.screeningMutex.Lock()
+	defer s.screeningMutex.Unlock()
+
+	entries, err := s.repo.FindSanctionEntries()
+	if err != nil {
+		return 0, err
+	}
+	s.screener = sanctions.NewScreener(toSanctionsEntries(entries))
+
+	// Mongo keeps the milliseconds, the older hits are found by the stored time
+	screenedAt := time.Now().UTC().Truncate(time.Millisecond)
+	hits := []models.ScreeningHit{}
+	// Without the lists the hits are only cleared
+	if s.screener.Len() > 0 {
+		banks, err := s.repo.FindAllBanks()
+		if err != nil {
+			return 0, err
+		}
+		for i := range banks {
+			hits = append(hits, screeningHits(s.screener, &banks[i], screenedAt)...)
+		}
+	}
+
+	if err := s.repo.ReplaceScreeningHits(hits, screenedAt); err != nil {
+		return 0, fmt.Errorf("error storing screening hits: %w", err)
+	}
+	// Every bank has been screened, including the pending ones
+	s.pendingScreenings = map[string]bool{}
+	s.logger.Info("Screened the directory against %d sanctioned entities, %d hits", s.screener.Len(), len(hits))
+	return len(hits), nil
+}
+
+// screenBank screens a bank added to the directory, against the lists indexed by the last screening
+func (s *SwiftCodeService) screenBank(bank *models.Bank) error {
+	s.screeningMutex.Lock()
+	defer s.screeningMutex.Unlock()
+
+	if s.screener == nil {
+		entries, err := s.repo.FindSanctionEntries()
+		if err != nil {
+			return err
+		}
+		s.screener = sanctions.NewScreener(toSanctionsEntries(entries))
+	}
+
+	hits := screeningHits(s.screener, bank, time.Now().UTC().Truncate(time.Millisecond))
+	return s.repo.ReplaceScreeningHitsOf(bank.SwiftCode, hits)
+}
+
+func (s *SwiftCodeService) markScreeningPending(swiftCode string) {
+	s.screeningMutex.Lock()
+	defer s.screeningMutex.Unlock()
+	s.pendingScreenings[swiftCode] = true
+}
+
+func (s *SwiftCodeService) clearScreeningPending(swiftCode string) {
+	s.screeningMutex.Lock()
+	defer s.screeningMutex.Unlock()
+	delete(s.pendingScreenings, swiftCode)
+}
+
+// screenPending screens again the added banks whose screening failed, the banks deleted in the meantime are dropped
+// and the ones failing again stay pending
+func (s *SwiftCodeService) screenPending() {
+	s.screeningMutex.Lock()
+	codes := make([]string, 0, len(s.pendingScreenings))
+	for code := range s.pendingScreenings {
+		codes = append(codes, code)
+	}
+	s.screeningMutex.Unlock()
+	sort.Strings(codes)
+
+	for _, code := range codes {
+		bank, err := s.lookupBank(code)
+		if errors.Is(err, ErrBankNotFound) {
+			s.clearScreeningPending(code)
+			continue
+		}
+		if err != nil {
+			s.logger.Error("Error screening %s: %v", code, err)
+			continue
+		}
+		if err := s.screenBank(&bank); err != nil {
+			s.logger.Error("Error screening %s: %v", code, err)
+			continue
+		}
+		s.clearScreeningPending(code)
+	}
+}
+
+// unscreen removes the hits of a deleted bank, or of a headquarter and its branches for an 8 character code
+func (s *SwiftCodeService) unscreen(prefix string) {
+	if err := s.repo.DeleteScreeningHitsByPrefix(prefix); err != nil {
+		s.logger.Error("Error deleting screening hits of %s: %v", prefix, err)
+	}
+}
+
+func screeningHits(screener *sanctions.Screener, bank *models.Bank, screenedAt time.Time) []models.ScreeningHit {
+	subject := sanctions.Subject{SwiftCode: bank.SwiftCode, Name: bank.BankName, CountryISO2: bank.CountryISO2}
+	hits := []models.ScreeningHit{}
+	for _, hit := range screener.Screen(subject) {
+		hits = append(hits, models.ScreeningHit{
+			SwiftCode:    bank.SwiftCode,
+			BankName:     bank.BankName,
+			CountryISO2:  bank.CountryISO2,
+			List:         hit.List,
+			EntryID:      hit.EntryID,
+			EntryName:    hit.EntryName,
+			MatchType:    hit.MatchType,
+			Score:        hit.Score,
+			CountryMatch: hit.CountryMatch,
+			Programs:     hit.Programs,
+			ScreenedAt:   screenedAt,
+		})
+	}
+	return hits
+}
+
+func toSanctionsEntries(entries []models.SanctionEntry) []sanctions.Entry {
+	result := make([]sanctions.Entry, len(entries))
+	for i, entry := range entries {
+		result[i] = sanctions.Entry{
+			List:      entry.List,
+			ID:        entry.EntryID,
+			Names:     entry.Names,
+			Type:      entry.Type,
+			Programs:  entry.Programs,
+			BICs:      entry.BICs,
+			Countries: entry.Countries,
+		}
+	}
+	return result
+}
+
+// screeningOf lists the hits of the bank for the SWIFT code response
+func (s *SwiftCodeService) screeningOf(swiftCode string) ([]ScreeningEntry, error) {
+	hits, err := s.repo.FindScreeningHitsBySwiftCode(swiftCode)
+	if err != nil {
+		return nil, fmt.Errorf("error finding screening hits of %s: %w", swiftCode, err)
+	}
+
+	entries := make([]ScreeningEntry, 0, len(hits))
+	for _, hit := range hits {
+		entries = append(entries, ScreeningEntry{
+			List:         hit.List,
+			EntryID:      hit.EntryID,
+			EntryName:    hit.EntryName,
+			MatchType:    hit.MatchType,
+			Score:        hit.Score,
+			CountryMatch: hit.CountryMatch,
+			Programs:     hit.Programs,
+			ScreenedAt:   hit.ScreenedAt,
+		})
+	}
+	return entries, nil
+}
+
+// GetScreeningReport lists the screening hits, optionally filtered by the list, the country and the match type
+func (s *SwiftCodeService) GetScreeningReport(list, countryISO2, matchType string) (*ScreeningReport, error) {
+	list, matchType = strings.ToLower(list), strings.ToLower(matchType)
+	if list != "" && !sanctions.IsList(list) {
+		return nil, fmt.Errorf("%w: unknown list %s", ErrInvalidScreeningFilter, list)
+	}
+	if matchType != "" && matchType != sanctions.MatchBIC && matchType != sanctions.MatchName {
+		return nil, fmt.Errorf("%w: unknown match type %s", ErrInvalidScreeningFilter, matchType)
+	}
+	if countryISO2 != "" {
+		if err := validators.NewCountryISO2CodeValidator().Validate(countryISO2, nil); err != nil {
+			return nil, fmt.Errorf("%w: invalid country code %s: %v", ErrInvalidScreeningFilter, countryISO2, err)
+		}
+		countryISO2 = strings.ToUpper(countryISO2)
+	}
+
+	lists, err := s.repo.FindSanctionLists()
+	if err != nil {
+		return nil, err
+	}
+	hits, err := s.repo.FindScreeningHits(list, countryISO2, matchType)
+	if err != nil {
+		return nil, err
+	}
+
+	banks := map[string]bool{}
+	for _, hit := range hits {
+		banks[hit.SwiftCode] = true
+	}
+	return &ScreeningReport{
+		Lists:       lists,
+		List:        list,
+		CountryISO2: countryISO2,
+		MatchType:   matchType,
+		Banks:       len(banks),
+		Count:       len(hits),
+		Hits:        hits,
+	}, nil
+}
+
+// RunSanctionsWatcher imports the sanctions list files, keyed by the list, and imports them again whenever their
+// modification time changes, checking at every interval until the context is done. The directory is screened once
+// after the changed lists are imported, and the added banks whose screening failed are screened again at every
+// check. The OFAC companion files are published along with sdn.csv, so only the SDN file is watched
+func (s *SwiftCodeService) RunSanctionsWatcher(ctx context.Context, interval time.Duration, files map[string]string) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	lists := make([]string, 0, len(files))
+	for list := range files {
+		lists = append(lists, list)
+	}
+	sort.Strings(lists)
+
+	modified := map[string]time.Time{}
+	for {
+		changed := false
+		for _, list := range lists {
+			info, err := os.Stat(files[list])
+			if err != nil {
+				s.logger.Error("Error checking the %s sanctions list: %v", list, err)
+				continue
+			}
+			if modTime, ok := modified[list]; ok && modTime.Equal(info.ModTime()) {
+				continue
+			}
+			// A failed import is retried at the next check
+			if err := s.importSanctionsList(list, files[list]); err != nil {
+				s.logger.Error("Error loading the %s sanctions list: %v", list, err)
+				continue
+			}
+			modified[list] = info.ModTime()
+			changed = true
+		}
+		if changed {
+			if _, err := s.ScreenDirectory(); err != nil {
+				s.logger.Error("Error screening the directory: %v", err)
+			}
+		}
+		s.screenPending()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
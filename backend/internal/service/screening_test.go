@@ -0,0 +1,57 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Hbrtjm/SWIFT_API/backend/internal/api/middleware"
+	"github.com/Hbrtjm/SWIFT_API/backend/internal/db/models"
+	"github.com/Hbrtjm/SWIFT_API/backend/internal/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+// screeningRepository adds the sanctions entries and the hits of the banks to the fake repository
+type screeningRepository struct {
+	*fakeRepository
+	entries []models.SanctionEntry
+	// Error returned by FindSanctionEntries, as when the database is down
+	entriesError error
+	hits         map[string][]models.ScreeningHit
+}
+
+func (r *screeningRepository) FindSanctionEntries() ([]models.SanctionEntry, error) {
+	return r.entries, r.entriesError
+}
+
+func (r *screeningRepository) ReplaceScreeningHitsOf(swiftCode string, hits []models.ScreeningHit) error {
+	r.hits[swiftCode] = hits
+	return nil
+}
+
+func TestScreenPendingScreensTheFailedBanksAgain(t *testing.T) {
+	bank := testBank("TPEOPLPWXXX")
+	repo := &screeningRepository{
+		fakeRepository: newFakeRepository(bank),
+		entries: []models.SanctionEntry{
+			{List: "ofac", EntryID: "1", Names: []string{"SANCTIONED BANK"}, Type: "entity", BICs: []string{"TPEOPLPW"}},
+		},
+		entriesError: errors.New("connection refused"),
+		hits:         map[string][]models.ScreeningHit{},
+	}
+	s := NewSwiftCodeService(repo, parser.NewSwiftFileParser(), middleware.NewNoLogger(), "v1")
+
+	assert.Error(t, s.screenBank(&bank))
+	s.markScreeningPending(bank.SwiftCode)
+	s.markScreeningPending("TPEOPLPWP65")
+
+	// The first check fails again and keeps the bank pending
+	s.screenPending()
+	assert.Empty(t, repo.hits)
+	assert.True(t, s.pendingScreenings[bank.SwiftCode])
+
+	repo.entriesError = nil
+	s.screenPending()
+	assert.Len(t, repo.hits[bank.SwiftCode], 1)
+	// The deleted bank is dropped along with the screened one
+	assert.Empty(t, s.pendingScreenings)
+}
@@ -0,0 +1,66 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/Hbrtjm/SWIFT_API/backend/internal/db/models"
+	"github.com/Hbrtjm/SWIFT_API/backend/pkg/suggest"
+	"github.com/Hbrtjm/SWIFT_API/backend/pkg/validators"
+)
+
+// MaxSuggestions is the number of the codes suggested at most for a missing code
+const MaxSuggestions = 5
+
+// SwiftCodeSuggestion is a directory entry close to a code which isn't in the directory
+type SwiftCodeSuggestion struct {
+	SwiftCode   string `json:"swiftCode"`
+	BankName    string `json:"bankName"`
+	CountryISO2 string `json:"countryISO2"`
+	// Edits turning the requested code into the suggestion, e.g. transposition or confusion (O and 0, I and 1)
+	Edits []string `json:"edits"`
+	// Edit distance of the requested code and the suggestion, the BIC8 codes are compared as their primary office
+	Distance int `json:"distance"`
+}
+
+// SuggestSwiftCodes lists the codes of the directory the missing code was likely meant to be, from the closest. All
+// of the candidates are looked up with a single query
+func (s *SwiftCodeService) SuggestSwiftCodes(code string) ([]SwiftCodeSuggestion, error) {
+	candidates := suggest.Candidates(code)
+	if len(candidates) == 0 {
+		return []SwiftCodeSuggestion{}, nil
+	}
+
+	codes := make([]string, len(candidates))
+	for i, candidate := range candidates {
+		codes[i] = candidate.Code
+	}
+	banks, err := s.repo.FindBySwiftCodes(codes...)
+	if err != nil {
+		return nil, fmt.Errorf("error looking up the suggestions: %w", err)
+	}
+	found := make(map[string]*models.Bank, len(banks))
+	for i := range banks {
+		found[banks[i].SwiftCode] = &banks[i]
+	}
+
+	requested := validators.NormalizeSwiftCode(code)
+	suggestions := []SwiftCodeSuggestion{}
+	// The candidates come from the cheapest
+	for _, candidate := range candidates {
+		bank, ok := found[candidate.Code]
+		if !ok {
+			continue
+		}
+		suggestions = append(suggestions, SwiftCodeSuggestion{
+			SwiftCode:   candidate.Code,
+			BankName:    bank.BankName,
+			CountryISO2: bank.CountryISO2,
+			Edits:       candidate.Edits,
+			Distance:    suggest.Distance(requested, candidate.Code),
+		})
+		if len(suggestions) == MaxSuggestions {
+			break
+		}
+	}
+	return suggestions, nil
+}
@@ -1,11 +1,38 @@
 package service
 
+import (
+	"time"
+
+	"github.com/Hbrtjm/SWIFT_API/backend/pkg/address"
+)
+
 type SwiftCodeResponse struct {
-	Address       string                   `json:"address"`
-	BankName      string                   `json:"bankName"`
-	CountryISO2   string                   `json:"countryISO2"`
-	CountryName   string                   `json:"countryName"`
-	IsHeadquarter bool                     `json:"isHeadquarter"`
-	SwiftCode     string                   `json:"swiftCode"`
-	Branches      []map[string]interface{} `json:"branches,omitempty"`
+	Address string `json:"address"`
+	// ISO 20022 fields parsed from the address, omitted when nothing could be parsed
+	StructuredAddress *address.PostalAddress `json:"structuredAddress,omitempty"`
+	BankName          string                 `json:"bankName"`
+	CountryISO2       string                 `json:"countryISO2"`
+	CountryName       string                 `json:"countryName"`
+	// Name of the country in the language of the request
+	CountryDisplayName string `json:"countryDisplayName,omitempty"`
+	IsHeadquarter      bool   `json:"isHeadquarter"`
+	SwiftCode          string `json:"swiftCode"`
+	TimeZone           string `json:"timeZone"`
+	LEI                string `json:"lei,omitempty"`
+	// Lifecycle status, omitted for the banks which never had their status changed
+	Status              string                   `json:"status,omitempty"`
+	StatusEffectiveDate *time.Time               `json:"statusEffectiveDate,omitempty"`
+	SuccessorSwiftCode  string                   `json:"successorSwiftCode,omitempty"`
+	ClearingCodes       []ClearingCodeEntry      `json:"clearingCodes,omitempty"`
+	Schemes             []SchemeEntry            `json:"schemes,omitempty"`
+	Screening           []ScreeningEntry         `json:"screening,omitempty"`
+	Branches            []map[string]interface{} `json:"branches,omitempty"`
+	BranchesTruncated   bool                     `json:"branchesTruncated,omitempty"`
+	Links               Links                    `json:"links"`
+}
+
+// ClearingCodeEntry is a member id of the bank in a national clearing system
+type ClearingCodeEntry struct {
+	ClearingSystem string `json:"clearingSystem"`
+	MemberID       string `json:"memberId"`
 }
@@ -2,25 +2,52 @@ package service
 
 import (
 	"errors"
+	"sync"
 
 	"github.com/Hbrtjm/SWIFT_API/backend/internal/api/middleware"
-	"github.com/Hbrtjm/SWIFT_API/backend/internal/db/repository"
+	"github.com/Hbrtjm/SWIFT_API/backend/internal/db/models"
 	"github.com/Hbrtjm/SWIFT_API/backend/internal/parser"
+	"github.com/Hbrtjm/SWIFT_API/backend/pkg/holidays"
+	"github.com/Hbrtjm/SWIFT_API/backend/pkg/iso3166"
+	"github.com/Hbrtjm/SWIFT_API/backend/pkg/sanctions"
+	"github.com/Hbrtjm/SWIFT_API/backend/pkg/validators"
 )
 
 // SwiftCodeService handles business logic for SWIFT codes
 type SwiftCodeService struct {
-	repo   *repository.MongoRepository
-	parser *parser.SwiftFileParser
-	logger *middleware.Logger
+	repo     Repository
+	parser   *parser.SwiftFileParser
+	logger   *middleware.Logger
+	holidays *holidays.Registry
+	// Prefix of the API paths in the links, e.g. /v1
+	basePath string
+	// Handling of the headquarter-branch links
+	integrity IntegrityRules
+	// Serializes the screenings, the screener indexes the sanctions lists of the last screening
+	screeningMutex sync.Mutex
+	screener       *sanctions.Screener
+	// Codes of the added banks whose screening failed, screened again at every check of the sanctions watcher
+	pendingScreenings map[string]bool
 }
 
-// NewSwiftCodeService creates a new SwiftCodeService
-func NewSwiftCodeService(repo *repository.MongoRepository, parser *parser.SwiftFileParser, logger *middleware.Logger) *SwiftCodeService {
+// NewSwiftCodeService creates a new SwiftCodeService, the links of the responses point to the given API version
+func NewSwiftCodeService(repo Repository, parser *parser.SwiftFileParser, logger *middleware.Logger, apiVersion string) *SwiftCodeService {
+	calendars, err := holidays.NewRegistry()
+	if err != nil {
+		// The calendars are embedded, the local time endpoint falls back to the default weekend only
+		logger.Error("Error loading holiday calendars: %v", err)
+		calendars = &holidays.Registry{}
+	}
+
 	return &SwiftCodeService{
-		repo:   repo,
-		parser: parser,
-		logger: logger,
+		repo:      repo,
+		parser:    parser,
+		logger:    logger,
+		holidays:  calendars,
+		basePath:  "/" + apiVersion,
+		integrity: DefaultIntegrityRules(),
+
+		pendingScreenings: map[string]bool{},
 	}
 }
 
@@ -34,6 +61,8 @@ func (s *SwiftCodeService) LoadInitialData(filename string) error {
 	}
 
 	s.logger.Info("Parsed %d banks and %d countries from file", len(banks), len(countries))
+
+	banks, countries = s.filterUnknownCountries(banks, countries)
 	if len(banks) == 0 {
 		return errors.New("no banks found in file")
 	}
@@ -52,5 +81,50 @@ func (s *SwiftCodeService) LoadInitialData(filename string) error {
 		return err
 	}
 
+	// The new banks are screened against the imported sanctions lists
+	if _, err := s.ScreenDirectory(); err != nil {
+		s.logger.Error("Error screening the directory: %v", err)
+	}
+
 	return nil
 }
+
+// filterUnknownCountries drops the countries missing from ISO 3166 along with their banks, and flags the country names
+// which don't match the registry
+func (s *SwiftCodeService) filterUnknownCountries(banks []models.Bank, countries []models.Country) ([]models.Bank, []models.Country) {
+	unknown := make(map[string]bool)
+	knownCountries := make([]models.Country, 0, len(countries))
+	for _, country := range countries {
+		registered, ok := iso3166.Lookup(country.CountryISO2)
+		if !ok {
+			s.logger.Warning("Skipping unknown country code %s", country.CountryISO2)
+			unknown[country.CountryISO2] = true
+			continue
+		}
+		if !registered.MatchesName(country.CountryName) {
+			s.logger.Warning("Country name %s doesn't match the ISO 3166 name of %s: %s", country.CountryName, registered.Alpha2, registered.Name)
+		}
+		knownCountries = append(knownCountries, country)
+	}
+
+	if len(unknown) == 0 {
+		return banks, knownCountries
+	}
+
+	knownBanks := make([]models.Bank, 0, len(banks))
+	for _, bank := range banks {
+		if !unknown[bank.CountryISO2] {
+			knownBanks = append(knownBanks, bank)
+		}
+	}
+	s.logger.Warning("Skipped %d banks with an unknown country code", len(banks)-len(knownBanks))
+
+	return knownBanks, knownCountries
+}
+
+// logValidationWarnings reports the values which passed the validation but look suspicious
+func (s *SwiftCodeService) logValidationWarnings(result *validators.ValidationResult) {
+	for _, warning := range result.Warnings {
+		s.logger.Warning("Validation warning on %s: %s", warning.Field, warning.Message)
+	}
+}
@@ -0,0 +1,122 @@
+package service
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/Hbrtjm/SWIFT_API/backend/pkg/iban"
+	"github.com/Hbrtjm/SWIFT_API/backend/pkg/iso20022"
+)
+
+// ISO20022ValidationReport lists the checked parties of every transaction of a pain.001 or pacs.008 message
+type ISO20022ValidationReport struct {
+	// Message type with its version, e.g. pain.001.001.09
+	MessageType             string                      `json:"messageType"`
+	Valid                   bool                        `json:"valid"`
+	TransactionCount        int                         `json:"transactionCount"`
+	InvalidTransactionCount int                         `json:"invalidTransactionCount"`
+	Transactions            []ISO20022TransactionReport `json:"transactions"`
+}
+
+// ISO20022TransactionReport is a transaction with the checks of its BICs and IBANs, including the parties inherited
+// from the group header and the payment information
+type ISO20022TransactionReport struct {
+	Index                int          `json:"index"`
+	PaymentInformationID string       `json:"paymentInformationId,omitempty"`
+	InstructionID        string       `json:"instructionId,omitempty"`
+	EndToEndID           string       `json:"endToEndId,omitempty"`
+	TransactionID        string       `json:"transactionId,omitempty"`
+	UETR                 string       `json:"uetr,omitempty"`
+	Valid                bool         `json:"valid"`
+	Parties              []PartyCheck `json:"parties"`
+}
+
+// PartyCheck is a BIC or an IBAN of the message with the outcome of its check
+type PartyCheck struct {
+	iso20022.Party
+	CodeCheck
+}
+
+// ValidateISO20022 reads the message as a stream and checks every BIC against the directory and every IBAN against
+// the registry and the country of its agent. The errors of the iso20022 package are returned for a malformed or
+// unsupported message, and a failed directory lookup stops the validation
+func (s *SwiftCodeService) ValidateISO20022(r io.Reader) (*ISO20022ValidationReport, error) {
+	checker := s.newBICChecker()
+	report := &ISO20022ValidationReport{Valid: true, Transactions: []ISO20022TransactionReport{}}
+
+	messageType, err := iso20022.Scan(r, func(transaction iso20022.Transaction) error {
+		result, err := checkTransaction(transaction, checker)
+		if err != nil {
+			return err
+		}
+		report.TransactionCount++
+		if !result.Valid {
+			report.Valid = false
+			report.InvalidTransactionCount++
+		}
+		report.Transactions = append(report.Transactions, result)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error scanning the message: %w", err)
+	}
+	report.MessageType = messageType
+	return report, nil
+}
+
+func checkTransaction(transaction iso20022.Transaction, checker *bicChecker) (ISO20022TransactionReport, error) {
+	result := ISO20022TransactionReport{
+		Index:                transaction.Index,
+		PaymentInformationID: transaction.PaymentInformationID,
+		InstructionID:        transaction.InstructionID,
+		EndToEndID:           transaction.EndToEndID,
+		TransactionID:        transaction.TransactionID,
+		UETR:                 transaction.UETR,
+		Valid:                true,
+		Parties:              make([]PartyCheck, 0, len(transaction.Parties)),
+	}
+
+	// The IBANs are compared with the BICs of their agents
+	agentBICs := map[string]string{}
+	for _, party := range transaction.Parties {
+		if !party.IsAccount() {
+			agentBICs[party.Role] = party.BIC
+		}
+	}
+
+	for _, party := range transaction.Parties {
+		var check CodeCheck
+		if party.IsAccount() {
+			check = checkIBAN(party.IBAN, agentBICs[party.AgentRole()])
+		} else {
+			var err error
+			if check, err = checker.check(party.BIC); err != nil {
+				return ISO20022TransactionReport{}, err
+			}
+		}
+		if !check.Passed() {
+			result.Valid = false
+		}
+		result.Parties = append(result.Parties, PartyCheck{Party: party, CodeCheck: check})
+	}
+	return result, nil
+}
+
+// checkIBAN validates the IBAN, and compares its country with the country of the agent's BIC when the agent is known
+func checkIBAN(value, agentBIC string) CodeCheck {
+	parsed, err := iban.Parse(value)
+	if err != nil {
+		return CodeCheck{Outcome: OutcomeInvalid, Issues: []string{err.Error()}}
+	}
+
+	result := CodeCheck{Outcome: OutcomeValid, CountryISO2: parsed.CountryCode}
+	if agentBIC = strings.ToUpper(strings.TrimSpace(agentBIC)); len(agentBIC) >= 6 {
+		if agentCountry := agentBIC[4:6]; agentCountry != parsed.CountryCode {
+			result.Outcome = OutcomeCountryMismatch
+			result.Issues = []string{fmt.Sprintf("IBAN country %s doesn't match the country %s of the agent %s",
+				parsed.CountryCode, agentCountry, agentBIC)}
+		}
+	}
+	return result
+}
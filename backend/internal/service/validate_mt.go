@@ -0,0 +1,76 @@
+package service
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/Hbrtjm/SWIFT_API/backend/pkg/mt"
+)
+
+// MTValidationReport lists the checked BICs of every FIN message of a file
+type MTValidationReport struct {
+	Valid               bool              `json:"valid"`
+	MessageCount        int               `json:"messageCount"`
+	InvalidMessageCount int               `json:"invalidMessageCount"`
+	Messages            []MTMessageReport `json:"messages"`
+}
+
+// MTMessageReport is a FIN message with its structural issues and the checks of its BICs
+type MTMessageReport struct {
+	Index int `json:"index"`
+	// MT type without the prefix, e.g. 103
+	MessageType string       `json:"messageType"`
+	Direction   string       `json:"direction"`
+	Reference   string       `json:"reference,omitempty"`
+	Valid       bool         `json:"valid"`
+	Issues      []string     `json:"issues"`
+	BICs        []MTBICCheck `json:"bics"`
+}
+
+// MTBICCheck is a BIC of the message with the outcome of its check
+type MTBICCheck struct {
+	mt.BICReference
+	CodeCheck
+}
+
+// ValidateMT parses the FIN messages of the file and checks their BICs against the structural rules and the
+// directory. A file which isn't made of FIN blocks gives mt.ErrMalformedMessage, and a failed directory lookup stops
+// the validation
+func (s *SwiftCodeService) ValidateMT(r io.Reader) (*MTValidationReport, error) {
+	checker := s.newBICChecker()
+	report := &MTValidationReport{Valid: true, Messages: []MTMessageReport{}}
+
+	err := mt.Scan(r, func(message mt.Message) error {
+		result := MTMessageReport{
+			Index:       message.Index,
+			MessageType: message.Type,
+			Direction:   message.Direction,
+			Reference:   message.Reference,
+			Valid:       len(message.Issues) == 0,
+			Issues:      message.Issues,
+			BICs:        make([]MTBICCheck, 0, len(message.BICs)),
+		}
+		for _, reference := range message.BICs {
+			check, err := checker.check(reference.BIC)
+			if err != nil {
+				return err
+			}
+			if !check.Passed() {
+				result.Valid = false
+			}
+			result.BICs = append(result.BICs, MTBICCheck{BICReference: reference, CodeCheck: check})
+		}
+
+		report.MessageCount++
+		if !result.Valid {
+			report.Valid = false
+			report.InvalidMessageCount++
+		}
+		report.Messages = append(report.Messages, result)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error parsing the FIN messages: %w", err)
+	}
+	return report, nil
+}
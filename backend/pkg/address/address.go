@@ -0,0 +1,299 @@
+// Package address splits the free-text addresses of the SWIFT directory into the fields of the ISO 20022 postal
+// address: street, building number, post code and town. The directory writes the addresses as
+// "<street> <town>, <subdivision>, <post code>", but the parts are often missing or reordered, so the result comes
+// with a confidence
+package address
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Confidence of the parsed fields
+const (
+	// Street, building number, a post code of the country format and the town were all found
+	ConfidenceHigh = "high"
+	// The town was found along with the street or the post code
+	ConfidenceMedium = "medium"
+	// Only some of the fields were found
+	ConfidenceLow = "low"
+)
+
+// PostalAddress holds the structured fields of the ISO 20022 postal address (PostalAddress24), the empty fields
+// weren't found in the address. It's stored on the banks as it is
+type PostalAddress struct {
+	StreetName         string `bson:"streetName,omitempty" json:"streetName,omitempty"`
+	BuildingNumber     string `bson:"buildingNumber,omitempty" json:"buildingNumber,omitempty"`
+	BuildingName       string `bson:"buildingName,omitempty" json:"buildingName,omitempty"`
+	Floor              string `bson:"floor,omitempty" json:"floor,omitempty"`
+	Room               string `bson:"room,omitempty" json:"room,omitempty"`
+	PostCode           string `bson:"postCode,omitempty" json:"postCode,omitempty"`
+	TownName           string `bson:"townName,omitempty" json:"townName,omitempty"`
+	CountrySubDivision string `bson:"countrySubDivision,omitempty" json:"countrySubDivision,omitempty"`
+	// ISO 3166 alpha-2 code
+	Country string `bson:"country,omitempty" json:"country,omitempty"`
+	// How reliable the split is, high, medium or low
+	Confidence string `bson:"confidence,omitempty" json:"confidence,omitempty"`
+}
+
+// IsZero checks whether nothing was parsed
+func (a PostalAddress) IsZero() bool {
+	return a == PostalAddress{}
+}
+
+var (
+	spaces = regexp.MustCompile(`\s+`)
+	// "FLOOR 2", "FL. 2" or "2ND FLOOR"
+	floorPattern = regexp.MustCompile(`(?i)\b(?:FLOOR|FL\.)\s*(\d+[A-Z]?)\b|\b(\d+)(?:ST|ND|RD|TH)\s+FLOOR\b`)
+	// "OFFICE 4", "OF. 111", "AP. 201" or "LOK. 313"
+	roomPattern = regexp.MustCompile(`(?i)(?:^|\s)(?:OFFICE|OFICINA|OF\.|AP\.|APT\.?|SUITE|ROOM|LOK\.|LOKAL)\s*(\d+[A-Z]?)\b`)
+	// Albanian entrance of the building, "HYRJA 3"
+	entrancePattern = regexp.MustCompile(`(?i)(?:^|\s)HYRJA\s*\d+[A-Z]?\b`)
+	// Building number given after the street with a marker, "RR. DRITAN HOXHA ND. 11" or "STR. VICTORIEI NR. 5"
+	markedNumber = regexp.MustCompile(`(?i)(?:^|\s)(?:ND|NR)\.\s*(\d+[A-Z]?(?:[-/]\d+[A-Z]?)?)\b`)
+	// "WRONIA 31", "ZEMGALA GATVE 78-1", "LOPUSZANSKA 38 D", "BARONA STREET 20/22 - 5"
+	trailingNumber = regexp.MustCompile(`^(.*[^\d\s-].*?)\s+(\d+[A-Z]?(?:\s?[-/]\s?\d+[A-Z]?)*(?:\s[A-Z])?)$`)
+	// "12 BOULEVARD DES MOULINS"
+	leadingNumber = regexp.MustCompile(`^(\d+[A-Z]?(?:[-/]\d+[A-Z]?)?)\s+(\D.*)$`)
+	// "TINTYAVA STR 13B IZTOK RESIDENTIAL COMPLEX" or "VILLA BIJOU 19 AVENUE DE LA COSTA", the building name is on the
+	// other side of the number than the street
+	middleNumber = regexp.MustCompile(`^(.*?[^\d\s])\s+(\d+[A-Z]?)\s+(\D.*)$`)
+)
+
+// Parse splits the address of a bank. The town name of the directory is used to find the end of the street, without
+// it the town is guessed from the subdivision or a double space
+func Parse(raw, townName, countryISO2 string) PostalAddress {
+	countryISO2 = strings.ToUpper(strings.TrimSpace(countryISO2))
+	townName = collapse(townName)
+
+	if strings.TrimSpace(raw) == "" {
+		if townName == "" {
+			return PostalAddress{}
+		}
+		return PostalAddress{TownName: townName, Country: countryISO2, Confidence: ConfidenceLow}
+	}
+
+	result := PostalAddress{Country: countryISO2}
+	components := splitComponents(raw)
+	format, hasFormat := postCodeFormats[countryISO2]
+
+	// The post code is the last component, or for the distinctive formats anywhere in the address
+	postCodeValid := false
+	if n := len(components); n > 0 && isPostCode(components[n-1], countryISO2) {
+		result.PostCode = collapse(components[n-1])
+		postCodeValid = hasFormat && format.pattern.MatchString(result.PostCode)
+		components = components[:n-1]
+	} else if hasFormat && format.distinctive {
+		for i, component := range components {
+			if match := format.search.FindStringSubmatchIndex(component); match != nil {
+				result.PostCode = component[match[2]:match[3]]
+				components[i] = component[:match[2]] + component[match[3]:]
+				postCodeValid = true
+				break
+			}
+		}
+	}
+
+	// The town is either the last component, or the end of the street component followed by the subdivision
+	townFound := false
+	if n := len(components); n > 0 && townName != "" && strings.EqualFold(collapse(components[n-1]), townName) &&
+		(n == 1 || !hasSuffixFold(collapse(components[n-2]), townName)) {
+		result.TownName = townName
+		components = components[:n-1]
+		townFound = true
+	} else if n >= 2 && !strings.ContainsAny(components[n-1], "0123456789") {
+		result.CountrySubDivision = collapse(components[n-1])
+		components = components[:n-1]
+	}
+
+	street := strings.Join(components, ",")
+	if !townFound {
+		var town, rest string
+		street, town, rest = splitTown(street, townName, result.CountrySubDivision)
+		result.TownName = town
+		if rest != "" && result.CountrySubDivision == "" {
+			result.CountrySubDivision = rest
+		}
+	}
+
+	middle := parseStreet(street, countryISO2, &result)
+	result.Confidence = confidence(result, postCodeValid, middle)
+	return result
+}
+
+// splitComponents splits the address on the commas, keeping the double spaces which mark the end of the street
+func splitComponents(raw string) []string {
+	components := []string{}
+	for _, component := range strings.Split(raw, ",") {
+		if component = strings.TrimSpace(component); component != "" {
+			components = append(components, component)
+		}
+	}
+	return components
+}
+
+// splitTown finds the town in the street component and returns the street before it, the town and the text after it
+func splitTown(text, townName, subDivision string) (string, string, string) {
+	collapsed := collapse(text)
+	if townName != "" {
+		if index := lastWordIndexFold(collapsed, townName); index >= 0 {
+			return trimStreet(collapsed[:index]), townName, trimStreet(collapsed[index+len(townName):])
+		}
+	}
+
+	// The directory often repeats the town as the subdivision ("VARNA, VARNA")
+	if subDivision != "" {
+		if index := lastWordIndexFold(collapsed, subDivision); index > 0 && index+len(subDivision) == len(collapsed) {
+			return trimStreet(collapsed[:index]), subDivision, ""
+		}
+	}
+
+	// Otherwise the street and the town are separated by a double space ("TSAR ASEN 20  VARNA")
+	if index := strings.LastIndex(strings.TrimSpace(text), "  "); index > 0 {
+		trimmed := strings.TrimSpace(text)
+		return trimStreet(collapse(trimmed[:index])), collapse(trimmed[index:]), ""
+	}
+	return trimStreet(collapsed), "", ""
+}
+
+// parseStreet fills in the street, building number, building name, floor and room, it reports whether the number was
+// found in the middle of the street, which is the least reliable
+func parseStreet(street, countryISO2 string, result *PostalAddress) bool {
+	if match := floorPattern.FindStringSubmatchIndex(street); match != nil {
+		if match[2] >= 0 {
+			result.Floor = street[match[2]:match[3]]
+		} else {
+			result.Floor = street[match[4]:match[5]]
+		}
+		street = street[:match[0]] + street[match[1]:]
+	}
+	if match := roomPattern.FindStringSubmatchIndex(street); match != nil {
+		result.Room = street[match[2]:match[3]]
+		street = street[:match[0]] + " " + street[match[1]:]
+	}
+	entrance := ""
+	if match := entrancePattern.FindStringIndex(street); match != nil {
+		entrance = collapse(street[match[0]:match[1]])
+		street = street[:match[0]] + " " + street[match[1]:]
+	}
+
+	segments := []string{}
+	for _, segment := range strings.Split(street, ",") {
+		if segment = trimStreet(collapse(segment)); segment != "" {
+			segments = append(segments, segment)
+		}
+	}
+	if len(segments) == 0 {
+		return false
+	}
+
+	patterns := []*regexp.Regexp{trailingNumber, leadingNumber, middleNumber}
+	if numberFirst[countryISO2] {
+		patterns = []*regexp.Regexp{leadingNumber, trailingNumber, middleNumber}
+	}
+
+	// The segment with a building number is the street, the other ones name the building. A marked number wins over
+	// the position of the number
+	streetIndex := -1
+	middle := false
+	for i, segment := range segments {
+		if match := markedNumber.FindStringSubmatchIndex(segment); match != nil {
+			result.BuildingNumber = segment[match[2]:match[3]]
+			result.StreetName = trimStreet(collapse(segment[:match[0]] + " " + segment[match[1]:]))
+			streetIndex = i
+			break
+		}
+	}
+	for _, pattern := range patterns {
+		if streetIndex >= 0 {
+			break
+		}
+		for i, segment := range segments {
+			match := pattern.FindStringSubmatch(segment)
+			if match == nil {
+				continue
+			}
+			switch pattern {
+			case leadingNumber:
+				result.BuildingNumber, result.StreetName = match[1], match[2]
+			case trailingNumber:
+				result.StreetName, result.BuildingNumber = match[1], match[2]
+			case middleNumber:
+				result.StreetName, result.BuildingNumber, segments[i] = match[1], match[2], match[3]
+				if numberFirst[countryISO2] {
+					result.StreetName, segments[i] = match[3], match[1]
+				}
+				middle = true
+			}
+			streetIndex = i
+			break
+		}
+	}
+
+	if streetIndex < 0 {
+		result.StreetName = segments[0]
+		streetIndex = 0
+	}
+	buildingName := []string{}
+	for i, segment := range segments {
+		if i != streetIndex || middle {
+			buildingName = append(buildingName, segment)
+		}
+	}
+	if entrance != "" {
+		buildingName = append(buildingName, entrance)
+	}
+	result.BuildingName = strings.Join(buildingName, ", ")
+	return middle
+}
+
+func confidence(result PostalAddress, postCodeValid, middle bool) string {
+	switch {
+	case result.StreetName != "" && result.BuildingNumber != "" && result.TownName != "" && postCodeValid && !middle:
+		return ConfidenceHigh
+	case result.TownName != "" && (result.StreetName != "" || result.PostCode != ""):
+		return ConfidenceMedium
+	default:
+		return ConfidenceLow
+	}
+}
+
+func collapse(value string) string {
+	return strings.TrimSpace(spaces.ReplaceAllString(value, " "))
+}
+
+// trimStreet drops the separators left around the street after cutting out the town ("PLAC TEATRALNY 4  -")
+func trimStreet(value string) string {
+	return strings.Trim(value, " -,")
+}
+
+func hasSuffixFold(value, suffix string) bool {
+	return len(value) >= len(suffix) && strings.EqualFold(value[len(value)-len(suffix):], suffix)
+}
+
+// lastWordIndexFold finds the last occurrence of the words, ignoring case, which isn't a part of a longer word
+func lastWordIndexFold(text, words string) int {
+	upperText, upperWords := strings.ToUpper(text), strings.ToUpper(words)
+	if len(upperText) != len(text) || len(upperWords) != len(words) {
+		// The case mapping changed the lengths, so the offsets wouldn't match the text
+		upperText, upperWords = text, words
+	}
+	for end := len(upperText); end > 0; {
+		index := strings.LastIndex(upperText[:end], upperWords)
+		if index < 0 {
+			return -1
+		}
+		after := index + len(upperWords)
+		if (index == 0 || !isWordByte(upperText[index-1])) && (after == len(upperText) || !isWordByte(upperText[after])) {
+			return index
+		}
+		end = index
+	}
+	return -1
+}
+
+// isWordByte checks whether the byte belongs to a word, the hyphens and apostrophes join the parts of the names
+// ("ORANJESTAD-WEST", "ST. JULIAN'S")
+func isWordByte(b byte) bool {
+	return b >= 'A' && b <= 'Z' || b >= '0' && b <= '9' || b == '-' || b == '\'' || b >= 0x80
+}
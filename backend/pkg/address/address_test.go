@@ -0,0 +1,131 @@
+package address
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name     string
+		raw      string
+		town     string
+		country  string
+		expected PostalAddress
+	}{
+		{
+			name:    "Street, town, subdivision and post code",
+			raw:     "UL. WRONIA 31  WARSZAWA, MAZOWIECKIE, 00-846",
+			town:    "WARSZAWA",
+			country: "PL",
+			expected: PostalAddress{StreetName: "UL. WRONIA", BuildingNumber: "31", PostCode: "00-846", TownName: "WARSZAWA",
+				CountrySubDivision: "MAZOWIECKIE", Country: "PL", Confidence: ConfidenceHigh},
+		},
+		{
+			name:    "Number before the street",
+			raw:     "12 BOULEVARD DES MOULINS  MONACO, MONACO, 98000",
+			town:    "MONACO",
+			country: "MC",
+			expected: PostalAddress{StreetName: "BOULEVARD DES MOULINS", BuildingNumber: "12", PostCode: "98000", TownName: "MONACO",
+				CountrySubDivision: "MONACO", Country: "MC", Confidence: ConfidenceHigh},
+		},
+		{
+			name:    "Building name and floor",
+			raw:     "FOREST ZUBRA 1, FLOOR 1 WARSZAWA, MAZOWIECKIE, 01-066",
+			town:    "WARSZAWA",
+			country: "PL",
+			expected: PostalAddress{StreetName: "FOREST ZUBRA", BuildingNumber: "1", Floor: "1", PostCode: "01-066", TownName: "WARSZAWA",
+				CountrySubDivision: "MAZOWIECKIE", Country: "PL", Confidence: ConfidenceHigh},
+		},
+		{
+			name:    "Number in the middle of the street",
+			raw:     "TINTYAVA STR 13B IZTOK RESIDENTIAL COMPLEX, ENT A, FLOOR 2 SOFIA, SOFIA, 1113",
+			town:    "SOFIA",
+			country: "BG",
+			expected: PostalAddress{StreetName: "TINTYAVA STR", BuildingNumber: "13B", BuildingName: "IZTOK RESIDENTIAL COMPLEX, ENT A",
+				Floor: "2", PostCode: "1113", TownName: "SOFIA", CountrySubDivision: "SOFIA", Country: "BG", Confidence: ConfidenceMedium},
+		},
+		{
+			name:    "Room",
+			raw:     "CONVENCION 1490 AP. 201 MONTEVIDEO, MONTEVIDEO, 11100",
+			town:    "MONTEVIDEO",
+			country: "UY",
+			expected: PostalAddress{StreetName: "CONVENCION", BuildingNumber: "1490", Room: "201", PostCode: "11100", TownName: "MONTEVIDEO",
+				CountrySubDivision: "MONTEVIDEO", Country: "UY", Confidence: ConfidenceHigh},
+		},
+		{
+			name:    "Subdivision after the town",
+			raw:     "PLAC TEATRALNY 4  - BYDGOSZCZ KUJAWSKO-POMORSKIE, 85-950 ",
+			town:    "BYDGOSZCZ",
+			country: "PL",
+			expected: PostalAddress{StreetName: "PLAC TEATRALNY", BuildingNumber: "4", PostCode: "85-950", TownName: "BYDGOSZCZ",
+				CountrySubDivision: "KUJAWSKO-POMORSKIE", Country: "PL", Confidence: ConfidenceHigh},
+		},
+		{
+			name:    "Post code inside the address",
+			raw:     "Ul. Chłodna 52, 00-872 Warszawa",
+			town:    "Warszawa",
+			country: "pl",
+			expected: PostalAddress{StreetName: "Ul. Chłodna", BuildingNumber: "52", PostCode: "00-872", TownName: "Warszawa",
+				Country: "PL", Confidence: ConfidenceHigh},
+		},
+		{
+			name:    "Town from a double space",
+			raw:     "TSAR ASEN 20  VARNA, 9002",
+			country: "BG",
+			expected: PostalAddress{StreetName: "TSAR ASEN", BuildingNumber: "20", PostCode: "9002", TownName: "VARNA",
+				Country: "BG", Confidence: ConfidenceHigh},
+		},
+		{
+			name:    "Entrance and marked building number",
+			raw:     "HYRJA 3 RR. DRITAN HOXHA ND. 11 TIRANA, TIRANA, 1023",
+			town:    "TIRANA",
+			country: "AL",
+			expected: PostalAddress{StreetName: "RR. DRITAN HOXHA", BuildingNumber: "11", BuildingName: "HYRJA 3", PostCode: "1023",
+				TownName: "TIRANA", CountrySubDivision: "TIRANA", Country: "AL", Confidence: ConfidenceHigh},
+		},
+		{
+			name:    "Street without a number",
+			raw:     "CASTILLE PLACE  VALLETTA, VALLETTA, VLT 1063",
+			town:    "VALLETTA",
+			country: "MT",
+			expected: PostalAddress{StreetName: "CASTILLE PLACE", PostCode: "VLT 1063", TownName: "VALLETTA",
+				CountrySubDivision: "VALLETTA", Country: "MT", Confidence: ConfidenceMedium},
+		},
+		{
+			name:     "Town only",
+			raw:      "  OPOLE, OPOLSKIE",
+			town:     "OPOLE",
+			country:  "PL",
+			expected: PostalAddress{TownName: "OPOLE", CountrySubDivision: "OPOLSKIE", Country: "PL", Confidence: ConfidenceLow},
+		},
+		{
+			name:     "Empty address",
+			raw:      "   ",
+			town:     "SANTIAGO",
+			country:  "CL",
+			expected: PostalAddress{TownName: "SANTIAGO", Country: "CL", Confidence: ConfidenceLow},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, Parse(tc.raw, tc.town, tc.country))
+		})
+	}
+}
+
+func TestParseEmpty(t *testing.T) {
+	assert.True(t, Parse("", "", "PL").IsZero())
+}
+
+func TestIsPostCode(t *testing.T) {
+	assert.True(t, isPostCode("00-846", "PL"))
+	assert.True(t, isPostCode("LV-1045", "LV"))
+	assert.True(t, isPostCode("SW1A 1AA", "GB"))
+	// Countries without a known format accept anything resembling a post code
+	assert.True(t, isPostCode("12345", "ZZ"))
+	assert.False(t, isPostCode("MAZOWIECKIE", "PL"))
+	assert.False(t, isPostCode("ALEXANDER BATTENBERG SQUARE 1", "BG"))
+}
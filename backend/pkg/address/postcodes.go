@@ -0,0 +1,88 @@
+package address
+
+import (
+	"regexp"
+	"strings"
+)
+
+// postCodeFormat is the post code of a country
+type postCodeFormat struct {
+	pattern *regexp.Regexp
+	// Finds the post code inside the text, set for the formats which can't be mistaken for a building number
+	search      *regexp.Regexp
+	distinctive bool
+}
+
+// Post code formats of the countries, per the Universal Postal Union addressing guides
+var postCodeFormats = map[string]postCodeFormat{}
+
+// Countries which write the building number before the street
+var numberFirst = map[string]bool{
+	"AU": true, "CA": true, "FR": true, "GB": true, "IE": true, "LU": true, "MC": true, "NZ": true, "US": true,
+}
+
+// genericPostCode accepts the post codes of the countries without a known format: up to ten letters and digits,
+// at least one of them a digit
+var genericPostCode = regexp.MustCompile(`^[A-Z0-9]{2,6}(?:[ -][A-Z0-9]{2,4})?$`)
+
+func init() {
+	formats := map[string]string{
+		"AL": `\d{4}`,
+		"AT": `\d{4}`,
+		"BE": `\d{4}`,
+		"BG": `\d{4}`,
+		"CH": `\d{4}`,
+		"CL": `\d{7}`,
+		"CZ": `\d{3} ?\d{2}`,
+		"DE": `\d{5}`,
+		"DK": `\d{4}`,
+		"EE": `\d{5}`,
+		"ES": `\d{5}`,
+		"FI": `\d{5}`,
+		"FR": `\d{5}`,
+		"GR": `\d{3} ?\d{2}`,
+		"HR": `\d{5}`,
+		"HU": `\d{4}`,
+		"IT": `\d{5}`,
+		"LT": `(?:LT-)?\d{5}`,
+		"LU": `(?:L-)?\d{4}`,
+		"MC": `980\d{2}`,
+		"NO": `\d{4}`,
+		"RO": `\d{6}`,
+		"SE": `\d{3} ?\d{2}`,
+		"SI": `\d{4}`,
+		"SK": `\d{3} ?\d{2}`,
+		"US": `\d{5}(?:-\d{4})?`,
+		"UY": `\d{5}`,
+	}
+	distinctive := map[string]string{
+		"CA": `[A-Z]\d[A-Z] ?\d[A-Z]\d`,
+		"GB": `[A-Z]{1,2}\d[A-Z\d]? ?\d[A-Z]{2}`,
+		"IE": `[A-Z]\d[\dW] ?[A-Z\d]{4}`,
+		"LV": `LV-\d{4}`,
+		"MT": `[A-Z]{3} ?\d{4}`,
+		"NL": `\d{4} ?[A-Z]{2}`,
+		"PL": `\d{2}-\d{3}`,
+		"PT": `\d{4}-\d{3}`,
+	}
+
+	for country, pattern := range formats {
+		postCodeFormats[country] = postCodeFormat{pattern: regexp.MustCompile(`^(?:` + pattern + `)$`)}
+	}
+	for country, pattern := range distinctive {
+		postCodeFormats[country] = postCodeFormat{
+			pattern:     regexp.MustCompile(`^(?:` + pattern + `)$`),
+			search:      regexp.MustCompile(`(?:^|\s)(` + pattern + `)(?:\s|$)`),
+			distinctive: true,
+		}
+	}
+}
+
+// isPostCode checks whether the text is a post code of the country, or of any format for the countries without one
+func isPostCode(text, countryISO2 string) bool {
+	text = strings.ToUpper(collapse(text))
+	if format, ok := postCodeFormats[countryISO2]; ok && format.pattern.MatchString(text) {
+		return true
+	}
+	return len(text) <= 10 && strings.ContainsAny(text, "0123456789") && genericPostCode.MatchString(text)
+}
@@ -0,0 +1,61 @@
+package clearing
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLookupSystem(t *testing.T) {
+	system, ok := LookupSystem("gbdsc")
+	require.True(t, ok)
+	assert.Equal(t, "GB", system.CountryISO2)
+
+	system, ok = LookupSystem("aba")
+	require.True(t, ok)
+	assert.Equal(t, "USABA", system.Code)
+
+	system, ok = LookupSystem("BLZ")
+	require.True(t, ok)
+	assert.Equal(t, "DEBLZ", system.Code)
+
+	_, ok = LookupSystem("XXCLR")
+	assert.False(t, ok)
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		system   string
+		id       string
+		expected string
+		err      error
+	}{
+		{"GBDSC", "60-16-13", "601613", nil},
+		{"GBDSC", "6016133", "", ErrInvalidFormat},
+		{"USABA", "021000021", "021000021", nil},
+		{"USABA", "021000022", "", ErrInvalidCheck},
+		{"DEBLZ", "370 400 44", "37040044", nil},
+		{"DEBLZ", "07040044", "", ErrInvalidFormat},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.system+" "+tt.id, func(t *testing.T) {
+			system, _ := LookupSystem(tt.system)
+			normalized, err := system.Validate(tt.id)
+			if tt.err != nil {
+				assert.True(t, errors.Is(err, tt.err), "got %v", err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, normalized)
+		})
+	}
+}
+
+func TestSystems(t *testing.T) {
+	all := Systems()
+	require.Len(t, all, 3)
+	assert.Equal(t, "DEBLZ", all[0].Code)
+}
@@ -0,0 +1,151 @@
+// Package countryinfo is embedded reference data of the countries: currencies, regions, EU/EEA/SEPA membership,
+// IBAN usage and calling codes
+package countryinfo
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Hbrtjm/SWIFT_API/backend/pkg/iban"
+	"github.com/Hbrtjm/SWIFT_API/backend/pkg/iso3166"
+)
+
+//go:embed data/countries.csv
+var countryData []byte
+
+var ErrUnknownRegion = errors.New("unknown region")
+
+// Info is the reference data of a country
+type Info struct {
+	CountryISO2    string `json:"countryISO2"`
+	CountryISO3    string `json:"countryISO3"`
+	CountryNumeric string `json:"countryNumeric"`
+	// ISO 3166 short name (in English)
+	Name string `json:"name"`
+	// ISO 4217 codes of the legal tender
+	Currencies []string `json:"currencies"`
+	// UN M49 region and sub-region, as grouped by CLDR
+	Region    string `json:"region,omitempty"`
+	SubRegion string `json:"subRegion,omitempty"`
+	// ITU-T E.164 country code, e.g. +48
+	CallingCode string `json:"callingCode,omitempty"`
+	EU          bool   `json:"eu"`
+	EEA         bool   `json:"eea"`
+	SEPA        bool   `json:"sepa"`
+	UsesIBAN    bool   `json:"usesIBAN"`
+	IBANLength  int    `json:"ibanLength,omitempty"`
+}
+
+var byAlpha2 = make(map[string]Info)
+
+func init() {
+	reader := csv.NewReader(bytes.NewReader(countryData))
+	reader.Comma = ';'
+	reader.Comment = '#'
+	records, err := reader.ReadAll()
+	if err != nil {
+		// The file is embedded, so this can only happen with a broken build
+		panic("countryinfo: malformed country data: " + err.Error())
+	}
+
+	// Skip the header
+	for _, record := range records[1:] {
+		country, ok := iso3166.Lookup(record[0])
+		if !ok {
+			panic("countryinfo: country missing from ISO 3166: " + record[0])
+		}
+
+		info := Info{
+			CountryISO2:    country.Alpha2,
+			CountryISO3:    country.Alpha3,
+			CountryNumeric: country.Numeric,
+			Name:           country.Name,
+			Currencies:     []string{},
+			Region:         record[2],
+			SubRegion:      record[3],
+			CallingCode:    record[4],
+			EU:             record[5] == "Y",
+			EEA:            record[6] == "Y",
+			SEPA:           record[7] == "Y",
+		}
+		if record[1] != "" {
+			info.Currencies = strings.Split(record[1], ",")
+		}
+		if format, ok := iban.LookupFormat(country.Alpha2); ok {
+			info.UsesIBAN = true
+			info.IBANLength = format.Length
+		}
+		byAlpha2[info.CountryISO2] = info
+	}
+}
+
+// Lookup finds the data of a country by its alpha-2 code
+func Lookup(alpha2 string) (Info, bool) {
+	info, ok := byAlpha2[strings.ToUpper(strings.TrimSpace(alpha2))]
+	if ok {
+		// The slice is shared, so the callers get their own copy
+		info.Currencies = append([]string{}, info.Currencies...)
+	}
+	return info, ok
+}
+
+// All returns the data of every country sorted by the alpha-2 code
+func All() []Info {
+	result := make([]Info, 0, len(byAlpha2))
+	for alpha2 := range byAlpha2 {
+		info, _ := Lookup(alpha2)
+		result = append(result, info)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].CountryISO2 < result[j].CountryISO2
+	})
+	return result
+}
+
+// Region is a group of countries: EU, EEA, SEPA, a region (e.g. Europe) or a sub-region (e.g. Western Europe)
+type Region struct {
+	Name     string
+	contains func(Info) bool
+}
+
+// ParseRegion finds a group by its name, ignoring case. The words of the names can be separated with spaces,
+// underscores or hyphens
+func ParseRegion(name string) (Region, error) {
+	normalized := normalizeRegion(name)
+	switch normalized {
+	case "EU":
+		return Region{Name: "EU", contains: func(info Info) bool { return info.EU }}, nil
+	case "EEA":
+		return Region{Name: "EEA", contains: func(info Info) bool { return info.EEA }}, nil
+	case "SEPA":
+		return Region{Name: "SEPA", contains: func(info Info) bool { return info.SEPA }}, nil
+	}
+
+	for _, info := range byAlpha2 {
+		if info.Region != "" && normalizeRegion(info.Region) == normalized {
+			region := info.Region
+			return Region{Name: region, contains: func(info Info) bool { return info.Region == region }}, nil
+		}
+		if info.SubRegion != "" && normalizeRegion(info.SubRegion) == normalized {
+			subRegion := info.SubRegion
+			return Region{Name: subRegion, contains: func(info Info) bool { return info.SubRegion == subRegion }}, nil
+		}
+	}
+	return Region{}, fmt.Errorf("%w: %s", ErrUnknownRegion, name)
+}
+
+// Contains checks whether the country belongs to the group
+func (r Region) Contains(alpha2 string) bool {
+	info, ok := byAlpha2[strings.ToUpper(strings.TrimSpace(alpha2))]
+	return ok && r.contains(info)
+}
+
+func normalizeRegion(name string) string {
+	name = strings.NewReplacer("_", " ", "-", " ").Replace(strings.ToUpper(name))
+	return strings.Join(strings.Fields(name), " ")
+}
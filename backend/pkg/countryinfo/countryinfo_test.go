@@ -0,0 +1,73 @@
+package countryinfo
+
+import (
+	"testing"
+
+	"github.com/Hbrtjm/SWIFT_API/backend/pkg/iso3166"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLookup(t *testing.T) {
+	info, ok := Lookup("pl")
+	require.True(t, ok)
+	assert.Equal(t, Info{
+		CountryISO2:    "PL",
+		CountryISO3:    "POL",
+		CountryNumeric: "616",
+		Name:           "Poland",
+		Currencies:     []string{"PLN"},
+		Region:         "Europe",
+		SubRegion:      "Eastern Europe",
+		CallingCode:    "+48",
+		EU:             true,
+		EEA:            true,
+		SEPA:           true,
+		UsesIBAN:       true,
+		IBANLength:     28,
+	}, info)
+
+	info, ok = Lookup("CH")
+	require.True(t, ok)
+	assert.False(t, info.EEA)
+	assert.True(t, info.SEPA)
+
+	info, ok = Lookup("US")
+	require.True(t, ok)
+	assert.False(t, info.UsesIBAN)
+	assert.Equal(t, []string{"USD"}, info.Currencies)
+
+	_, ok = Lookup("XX")
+	assert.False(t, ok)
+}
+
+func TestAllCoversISO3166(t *testing.T) {
+	assert.Len(t, All(), len(iso3166.All()))
+}
+
+func TestParseRegion(t *testing.T) {
+	sepa, err := ParseRegion("sepa")
+	require.NoError(t, err)
+	assert.True(t, sepa.Contains("GB"))
+	assert.False(t, sepa.Contains("US"))
+
+	eu, err := ParseRegion("EU")
+	require.NoError(t, err)
+	assert.False(t, eu.Contains("NO"))
+	// Outermost regions are part of the EU
+	assert.True(t, eu.Contains("RE"))
+
+	westernEurope, err := ParseRegion("western_europe")
+	require.NoError(t, err)
+	assert.Equal(t, "Western Europe", westernEurope.Name)
+	assert.True(t, westernEurope.Contains("DE"))
+	assert.False(t, westernEurope.Contains("PL"))
+
+	americas, err := ParseRegion("Americas")
+	require.NoError(t, err)
+	assert.True(t, americas.Contains("BR"))
+	assert.False(t, americas.Contains("XX"))
+
+	_, err = ParseRegion("Atlantis")
+	assert.ErrorIs(t, err, ErrUnknownRegion)
+}
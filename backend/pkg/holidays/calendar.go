@@ -0,0 +1,300 @@
+// Package holidays evaluates business days and business hours based on holiday calendars.
+// The calendars are embedded JSON files, which can be replaced or extended with files from a directory
+package holidays
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Calendar describes the business days of a country or a payment system
+type Calendar struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	// Zone in which the calendar is evaluated, empty means the zone of the caller (e.g. the zone of the bank)
+	TimeZone      string        `json:"timeZone,omitempty"`
+	Weekend       []string      `json:"weekend"`
+	BusinessHours BusinessHours `json:"businessHours"`
+	// Observance of the fixed holidays falling on a weekend: "none", "next_weekday" or "nearest_weekday"
+	Observance string       `json:"observance,omitempty"`
+	Fixed      []FixedDay   `json:"fixed,omitempty"`
+	Easter     []EasterDay  `json:"easter,omitempty"`
+	Weekdays   []WeekdayDay `json:"weekdays,omitempty"`
+	Dates      []DatedDay   `json:"dates,omitempty"`
+
+	weekend map[time.Weekday]bool
+}
+
+// BusinessHours are given as HH:MM in the zone of the calendar
+type BusinessHours struct {
+	Open  string `json:"open"`
+	Close string `json:"close"`
+}
+
+// FixedDay repeats on the same date every year
+type FixedDay struct {
+	Month int    `json:"month"`
+	Day   int    `json:"day"`
+	Name  string `json:"name"`
+}
+
+// EasterDay is relative to Easter Sunday, the orthodox flag switches to the Julian computus
+type EasterDay struct {
+	Offset   int    `json:"offset"`
+	Name     string `json:"name"`
+	Orthodox bool   `json:"orthodox,omitempty"`
+}
+
+// WeekdayDay is the nth weekday of a month, a negative nth counts from the end of the month
+type WeekdayDay struct {
+	Month   int    `json:"month"`
+	Weekday string `json:"weekday"`
+	Nth     int    `json:"nth"`
+	Name    string `json:"name"`
+}
+
+// DatedDay is a single occurrence, used for the moving holidays without a simple rule (e.g. lunar holidays)
+type DatedDay struct {
+	Date string `json:"date"`
+	Name string `json:"name"`
+}
+
+const (
+	ObservanceNone           = "none"
+	ObservanceNextWeekday    = "next_weekday"
+	ObservanceNearestWeekday = "nearest_weekday"
+)
+
+var weekdays = map[string]time.Weekday{
+	"SUNDAY":    time.Sunday,
+	"MONDAY":    time.Monday,
+	"TUESDAY":   time.Tuesday,
+	"WEDNESDAY": time.Wednesday,
+	"THURSDAY":  time.Thursday,
+	"FRIDAY":    time.Friday,
+	"SATURDAY":  time.Saturday,
+}
+
+// prepare validates the calendar and builds the lookup structures
+func (c *Calendar) prepare() error {
+	if c.ID == "" {
+		return fmt.Errorf("calendar without an id")
+	}
+	c.ID = strings.ToUpper(c.ID)
+
+	if len(c.Weekend) == 0 {
+		c.Weekend = []string{"Saturday", "Sunday"}
+	}
+	c.weekend = make(map[time.Weekday]bool)
+	for _, day := range c.Weekend {
+		weekday, ok := weekdays[strings.ToUpper(day)]
+		if !ok {
+			return fmt.Errorf("calendar %s: unknown weekend day %s", c.ID, day)
+		}
+		c.weekend[weekday] = true
+	}
+
+	if c.BusinessHours.Open == "" {
+		c.BusinessHours = BusinessHours{Open: "09:00", Close: "17:00"}
+	}
+	if _, err := parseClock(c.BusinessHours.Open); err != nil {
+		return fmt.Errorf("calendar %s: %w", c.ID, err)
+	}
+	if _, err := parseClock(c.BusinessHours.Close); err != nil {
+		return fmt.Errorf("calendar %s: %w", c.ID, err)
+	}
+
+	switch c.Observance {
+	case "":
+		c.Observance = ObservanceNone
+	case ObservanceNone, ObservanceNextWeekday, ObservanceNearestWeekday:
+	default:
+		return fmt.Errorf("calendar %s: unknown observance %s", c.ID, c.Observance)
+	}
+
+	if c.TimeZone != "" {
+		if _, err := time.LoadLocation(c.TimeZone); err != nil {
+			return fmt.Errorf("calendar %s: %w", c.ID, err)
+		}
+	}
+
+	for _, rule := range c.Weekdays {
+		if _, ok := weekdays[strings.ToUpper(rule.Weekday)]; !ok || rule.Nth == 0 {
+			return fmt.Errorf("calendar %s: invalid weekday rule %s", c.ID, rule.Name)
+		}
+	}
+	for _, dated := range c.Dates {
+		if _, err := time.Parse(time.DateOnly, dated.Date); err != nil {
+			return fmt.Errorf("calendar %s: %w", c.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// Location returns the zone of the calendar, or the fallback when the calendar doesn't define one
+func (c *Calendar) Location(fallback *time.Location) *time.Location {
+	if c.TimeZone == "" {
+		return fallback
+	}
+	location, err := time.LoadLocation(c.TimeZone)
+	if err != nil {
+		return fallback
+	}
+	return location
+}
+
+// IsWeekend checks the weekend days of the calendar
+func (c *Calendar) IsWeekend(date time.Time) bool {
+	return c.weekend[date.Weekday()]
+}
+
+// Holiday returns the name of the holiday on the date, if there is one
+func (c *Calendar) Holiday(date time.Time) (string, bool) {
+	year, month, day := date.Date()
+	holidays := c.holidaysOf(year)
+	// Observed days may cross the turn of the year (1 January on a Saturday is observed on 31 December)
+	for _, neighbour := range []int{year - 1, year + 1} {
+		for name, observed := range c.observedFixed(neighbour) {
+			if _, taken := holidays[observed]; !taken {
+				holidays[observed] = name
+			}
+		}
+	}
+	name, ok := holidays[civilDate{year, month, day}]
+	return name, ok
+}
+
+// IsBusinessDay checks that the date is neither a weekend day nor a holiday
+func (c *Calendar) IsBusinessDay(date time.Time) bool {
+	if c.IsWeekend(date) {
+		return false
+	}
+	_, holiday := c.Holiday(date)
+	return !holiday
+}
+
+// NextBusinessDay returns the first business day after the date
+func (c *Calendar) NextBusinessDay(date time.Time) time.Time {
+	next := date.AddDate(0, 0, 1)
+	// A year cannot consist of holidays only, the bound just protects against broken calendar files
+	for i := 0; i < 366 && !c.IsBusinessDay(next); i++ {
+		next = next.AddDate(0, 0, 1)
+	}
+	return next
+}
+
+// IsWithinBusinessHours checks that the time falls on a business day between the opening and the closing time
+func (c *Calendar) IsWithinBusinessHours(t time.Time) bool {
+	if !c.IsBusinessDay(t) {
+		return false
+	}
+	open, _ := parseClock(c.BusinessHours.Open)
+	closing, _ := parseClock(c.BusinessHours.Close)
+	minutes := t.Hour()*60 + t.Minute()
+	return minutes >= open && minutes < closing
+}
+
+type civilDate struct {
+	year  int
+	month time.Month
+	day   int
+}
+
+func toCivil(t time.Time) civilDate {
+	year, month, day := t.Date()
+	return civilDate{year, month, day}
+}
+
+// holidaysOf lists all of the holidays of a year
+func (c *Calendar) holidaysOf(year int) map[civilDate]string {
+	holidays := make(map[civilDate]string)
+
+	for _, fixed := range c.Fixed {
+		holidays[civilDate{year, time.Month(fixed.Month), fixed.Day}] = fixed.Name
+	}
+	for name, observed := range c.observedFixed(year) {
+		if _, taken := holidays[observed]; !taken {
+			holidays[observed] = name
+		}
+	}
+
+	for _, easter := range c.Easter {
+		sunday := WesternEaster(year)
+		if easter.Orthodox {
+			sunday = OrthodoxEaster(year)
+		}
+		holidays[toCivil(sunday.AddDate(0, 0, easter.Offset))] = easter.Name
+	}
+
+	for _, rule := range c.Weekdays {
+		holidays[toCivil(nthWeekday(year, time.Month(rule.Month), weekdays[strings.ToUpper(rule.Weekday)], rule.Nth))] = rule.Name
+	}
+
+	for _, dated := range c.Dates {
+		date, err := time.Parse(time.DateOnly, dated.Date)
+		if err == nil && date.Year() == year {
+			holidays[toCivil(date)] = dated.Name
+		}
+	}
+
+	return holidays
+}
+
+// observedFixed moves the fixed holidays of the year falling on a weekend according to the observance rule.
+// A holiday is never moved onto another holiday, e.g. 25 and 26 December on a weekend are observed on Monday and Tuesday
+func (c *Calendar) observedFixed(year int) map[string]civilDate {
+	observed := make(map[string]civilDate)
+	if c.Observance == ObservanceNone {
+		return observed
+	}
+
+	occupied := make(map[civilDate]bool)
+	fixedDates := make([]time.Time, 0, len(c.Fixed))
+	for _, fixed := range c.Fixed {
+		date := time.Date(year, time.Month(fixed.Month), fixed.Day, 0, 0, 0, 0, time.UTC)
+		fixedDates = append(fixedDates, date)
+		occupied[toCivil(date)] = true
+	}
+
+	for i, fixed := range c.Fixed {
+		date := fixedDates[i]
+		if !c.IsWeekend(date) {
+			continue
+		}
+
+		moved := date.AddDate(0, 0, 1)
+		if c.Observance == ObservanceNearestWeekday && date.Weekday() == time.Saturday {
+			moved = date.AddDate(0, 0, -1)
+		}
+		for c.IsWeekend(moved) || occupied[toCivil(moved)] {
+			moved = moved.AddDate(0, 0, 1)
+		}
+
+		occupied[toCivil(moved)] = true
+		observed[fixed.Name+" (observed)"] = toCivil(moved)
+	}
+
+	return observed
+}
+
+func nthWeekday(year int, month time.Month, weekday time.Weekday, nth int) time.Time {
+	if nth > 0 {
+		first := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+		shift := (int(weekday) - int(first.Weekday()) + 7) % 7
+		return first.AddDate(0, 0, shift+(nth-1)*7)
+	}
+
+	last := time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC)
+	shift := (int(last.Weekday()) - int(weekday) + 7) % 7
+	return last.AddDate(0, 0, -shift+(nth+1)*7)
+}
+
+func parseClock(clock string) (int, error) {
+	parsed, err := time.Parse("15:04", clock)
+	if err != nil {
+		return 0, fmt.Errorf("invalid business hour %s", clock)
+	}
+	return parsed.Hour()*60 + parsed.Minute(), nil
+}
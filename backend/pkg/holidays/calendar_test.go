@@ -0,0 +1,100 @@
+package holidays
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestEaster(t *testing.T) {
+	assert.Equal(t, date(2024, time.March, 31), WesternEaster(2024))
+	assert.Equal(t, date(2025, time.April, 20), WesternEaster(2025))
+	assert.Equal(t, date(2026, time.April, 5), WesternEaster(2026))
+	assert.Equal(t, date(2024, time.May, 5), OrthodoxEaster(2024))
+	assert.Equal(t, date(2026, time.April, 12), OrthodoxEaster(2026))
+}
+
+func TestCountryCalendar(t *testing.T) {
+	registry, err := NewRegistry()
+	require.NoError(t, err)
+
+	poland, ok := registry.ForCountry("pl")
+	require.True(t, ok)
+
+	name, ok := poland.Holiday(date(2026, time.April, 6))
+	assert.True(t, ok)
+	assert.Equal(t, "Easter Monday", name)
+	assert.False(t, poland.IsBusinessDay(date(2026, time.June, 4)), "Corpus Christi")
+	assert.False(t, poland.IsBusinessDay(date(2026, time.October, 17)), "Saturday")
+	assert.True(t, poland.IsBusinessDay(date(2026, time.October, 19)))
+	assert.Equal(t, date(2026, time.December, 28), poland.NextBusinessDay(date(2026, time.December, 23)))
+
+	bulgaria, _ := registry.ForCountry("BG")
+	name, _ = bulgaria.Holiday(date(2026, time.April, 13))
+	assert.Equal(t, "Easter Monday", name)
+}
+
+func TestObservance(t *testing.T) {
+	registry, err := NewRegistry()
+	require.NoError(t, err)
+
+	// 25 and 26 December 2027 fall on a weekend
+	britain, _ := registry.Get("GB")
+	name, ok := britain.Holiday(date(2027, time.December, 27))
+	assert.True(t, ok)
+	assert.Equal(t, "Christmas Day (observed)", name)
+	name, _ = britain.Holiday(date(2027, time.December, 28))
+	assert.Equal(t, "Boxing Day (observed)", name)
+	name, _ = britain.Holiday(date(2026, time.May, 25))
+	assert.Equal(t, "Spring Bank Holiday", name)
+
+	// 1 January 2022 is a Saturday, observed on the previous Friday
+	nearest := &Calendar{ID: "test", Observance: ObservanceNearestWeekday, Fixed: []FixedDay{{Month: 1, Day: 1, Name: "New Year's Day"}}}
+	require.NoError(t, nearest.prepare())
+	assert.False(t, nearest.IsBusinessDay(date(2021, time.December, 31)))
+	assert.True(t, nearest.IsBusinessDay(date(2022, time.January, 3)))
+}
+
+func TestBusinessHours(t *testing.T) {
+	registry, err := NewRegistry()
+	require.NoError(t, err)
+
+	target2, ok := registry.Get(TARGET2)
+	require.True(t, ok)
+	berlin := target2.Location(time.UTC)
+	assert.Equal(t, "Europe/Berlin", berlin.String())
+
+	assert.True(t, target2.IsWithinBusinessHours(time.Date(2026, time.October, 19, 7, 0, 0, 0, berlin)))
+	assert.False(t, target2.IsWithinBusinessHours(time.Date(2026, time.October, 19, 18, 0, 0, 0, berlin)))
+	assert.False(t, target2.IsWithinBusinessHours(time.Date(2026, time.May, 1, 12, 0, 0, 0, berlin)))
+
+	unknown, ok := registry.ForCountry("ZZ")
+	assert.False(t, ok)
+	assert.True(t, unknown.IsWithinBusinessHours(time.Date(2026, time.January, 1, 10, 0, 0, 0, time.UTC)))
+}
+
+func TestLoadDir(t *testing.T) {
+	registry, err := NewRegistry()
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	calendar := `{"id": "pl", "name": "Poland", "dates": [{"date": "2026-10-19", "name": "Extra Holiday"}]}`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "pl.json"), []byte(calendar), 0o644))
+	require.NoError(t, registry.LoadDir(dir))
+
+	poland, _ := registry.Get("PL")
+	name, ok := poland.Holiday(date(2026, time.October, 19))
+	assert.True(t, ok)
+	assert.Equal(t, "Extra Holiday", name)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "broken.json"), []byte(`{"id": "XX", "weekend": ["Funday"]}`), 0o644))
+	assert.Error(t, registry.LoadDir(dir))
+}
@@ -0,0 +1,108 @@
+package holidays
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+//go:embed data/*.json
+var embeddedCalendars embed.FS
+
+// TARGET2 is the id of the calendar of the Eurosystem settlement days
+const TARGET2 = "TARGET2"
+
+// Registry holds the calendars by their ids, countries use their ISO 3166 alpha-2 code as the id.
+// The zero value is an empty registry
+type Registry struct {
+	mu        sync.RWMutex
+	calendars map[string]*Calendar
+}
+
+// NewRegistry loads the embedded calendars
+func NewRegistry() (*Registry, error) {
+	r := &Registry{calendars: make(map[string]*Calendar)}
+	if err := r.load(embeddedCalendars, "data"); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// LoadDir adds the calendars from the JSON files of a directory, replacing the calendars with the same id.
+// This keeps the calendars up to date without a rebuild
+func (r *Registry) LoadDir(dir string) error {
+	return r.load(os.DirFS(dir), ".")
+}
+
+func (r *Registry) load(fsys fs.FS, dir string) error {
+	files, err := fs.Glob(fsys, filepath.Join(dir, "*.json"))
+	if err != nil {
+		return fmt.Errorf("error listing calendars: %w", err)
+	}
+
+	loaded := make([]*Calendar, 0, len(files))
+	for _, file := range files {
+		content, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return fmt.Errorf("error reading calendar %s: %w", file, err)
+		}
+
+		calendar := &Calendar{}
+		if err := json.Unmarshal(content, calendar); err != nil {
+			return fmt.Errorf("error decoding calendar %s: %w", file, err)
+		}
+		if err := calendar.prepare(); err != nil {
+			return fmt.Errorf("error in calendar %s: %w", file, err)
+		}
+		loaded = append(loaded, calendar)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.calendars == nil {
+		r.calendars = make(map[string]*Calendar)
+	}
+	for _, calendar := range loaded {
+		r.calendars[calendar.ID] = calendar
+	}
+	return nil
+}
+
+// Get returns the calendar with the id
+func (r *Registry) Get(id string) (*Calendar, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	calendar, ok := r.calendars[strings.ToUpper(id)]
+	return calendar, ok
+}
+
+// ForCountry returns the calendar of the country. Countries without a calendar get a calendar with
+// the default weekend and business hours only, the flag reports whether the calendar knows the holidays
+func (r *Registry) ForCountry(iso2 string) (*Calendar, bool) {
+	if calendar, ok := r.Get(iso2); ok {
+		return calendar, true
+	}
+
+	calendar := &Calendar{ID: strings.ToUpper(iso2), Name: "Default"}
+	// The default calendar is always valid
+	_ = calendar.prepare()
+	return calendar, false
+}
+
+// IDs lists the ids of the loaded calendars
+func (r *Registry) IDs() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	ids := make([]string, 0, len(r.calendars))
+	for id := range r.calendars {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
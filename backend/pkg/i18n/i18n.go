@@ -0,0 +1,117 @@
+// Package i18n holds the embedded translation catalogs of the country names and the API messages. English is the
+// language of the API, so it has no catalog and is the fallback of every lookup
+package i18n
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"encoding/csv"
+	"path"
+	"strings"
+
+	"github.com/Hbrtjm/SWIFT_API/backend/pkg/iso3166"
+	"golang.org/x/text/language"
+)
+
+//go:embed data/countries/*.csv data/messages/*.csv
+var catalogData embed.FS
+
+// supported lists the languages with a catalog, the first one is the fallback
+var supported = []language.Tag{language.English, language.Polish, language.German}
+
+var matcher = language.NewMatcher(supported)
+
+// catalog is the translations of a single language
+type catalog struct {
+	countries map[string]string
+	messages  map[string]string
+}
+
+var catalogs = make(map[language.Tag]catalog)
+
+func init() {
+	for _, tag := range supported[1:] {
+		catalogs[tag] = catalog{
+			countries: readCatalog(path.Join("data/countries", tag.String()+".csv")),
+			messages:  readCatalog(path.Join("data/messages", tag.String()+".csv")),
+		}
+	}
+}
+
+// readCatalog reads the two column file of the keys and their translations
+func readCatalog(name string) map[string]string {
+	data, err := catalogData.ReadFile(name)
+	if err != nil {
+		// The files are embedded, so this can only happen with a broken build
+		panic("i18n: missing catalog " + name)
+	}
+
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.Comma = ';'
+	reader.Comment = '#'
+	// The messages may contain quotes
+	reader.LazyQuotes = true
+	records, err := reader.ReadAll()
+	if err != nil {
+		panic("i18n: malformed catalog " + name + ": " + err.Error())
+	}
+
+	// Skip the header
+	translations := make(map[string]string, len(records))
+	for _, record := range records[1:] {
+		translations[record[0]] = record[1]
+	}
+	return translations
+}
+
+// Supported returns the languages of the catalogs, English first
+func Supported() []language.Tag {
+	return append([]language.Tag{}, supported...)
+}
+
+// Match picks the supported language best matching an Accept-Language header, English when none of them does
+func Match(acceptLanguage string) language.Tag {
+	tags, _, err := language.ParseAcceptLanguage(acceptLanguage)
+	if err != nil || len(tags) == 0 {
+		return supported[0]
+	}
+	_, index, confidence := matcher.Match(tags...)
+	if confidence == language.No {
+		return supported[0]
+	}
+	return supported[index]
+}
+
+// CountryName returns the name of a country in the language, the English one is the ISO 3166 short name
+func CountryName(tag language.Tag, alpha2 string) (string, bool) {
+	alpha2 = strings.ToUpper(strings.TrimSpace(alpha2))
+	if name, ok := catalogs[tag].countries[alpha2]; ok {
+		return name, true
+	}
+	country, ok := iso3166.Lookup(alpha2)
+	return country.Name, ok
+}
+
+// Message translates an English message of the API, the messages missing from the catalog are returned unchanged
+func Message(tag language.Tag, message string) string {
+	if translation, ok := catalogs[tag].messages[message]; ok {
+		return translation
+	}
+	return message
+}
+
+type contextKey struct{}
+
+// NewContext returns a copy of the context carrying the language of the request
+func NewContext(ctx context.Context, tag language.Tag) context.Context {
+	return context.WithValue(ctx, contextKey{}, tag)
+}
+
+// FromContext returns the language of the request, English when it isn't set
+func FromContext(ctx context.Context) language.Tag {
+	if tag, ok := ctx.Value(contextKey{}).(language.Tag); ok {
+		return tag
+	}
+	return supported[0]
+}
@@ -0,0 +1,80 @@
+package i18n
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Hbrtjm/SWIFT_API/backend/pkg/iso3166"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		header   string
+		expected language.Tag
+	}{
+		{"pl-PL,pl;q=0.9,en;q=0.8", language.Polish},
+		{"de-CH", language.German},
+		{"fr-FR,de;q=0.5", language.German},
+		{"en-GB,de;q=0.5", language.English},
+		{"fr", language.English},
+		{"", language.English},
+		{"not a language;;", language.English},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.header, func(t *testing.T) {
+			assert.Equal(t, tc.expected, Match(tc.header))
+		})
+	}
+}
+
+func TestCountryName(t *testing.T) {
+	name, ok := CountryName(language.Polish, "de")
+	assert.True(t, ok)
+	assert.Equal(t, "Niemcy", name)
+
+	name, ok = CountryName(language.German, "PL")
+	assert.True(t, ok)
+	assert.Equal(t, "Polen", name)
+
+	// Falls back to the ISO 3166 name
+	name, ok = CountryName(language.English, "PL")
+	assert.True(t, ok)
+	assert.Equal(t, "Poland", name)
+
+	name, ok = CountryName(language.French, "PL")
+	assert.True(t, ok)
+	assert.Equal(t, "Poland", name)
+
+	_, ok = CountryName(language.Polish, "XX")
+	assert.False(t, ok)
+}
+
+func TestCatalogsCoverEveryCountry(t *testing.T) {
+	for _, tag := range Supported()[1:] {
+		assert.Len(t, catalogs[tag].countries, len(iso3166.All()), tag.String())
+	}
+}
+
+func TestMessage(t *testing.T) {
+	assert.Equal(t, "Nie znaleziono kodu SWIFT", Message(language.Polish, "SWIFT code not found"))
+	assert.Equal(t, "SWIFT-Code nicht gefunden", Message(language.German, "SWIFT code not found"))
+	assert.Equal(t, "SWIFT code not found", Message(language.English, "SWIFT code not found"))
+	assert.Equal(t, "database error", Message(language.Polish, "database error"))
+}
+
+func TestMessageCatalogsMatch(t *testing.T) {
+	// Every language translates the same messages
+	polish := catalogs[language.Polish].messages
+	for message := range catalogs[language.German].messages {
+		assert.Contains(t, polish, message)
+	}
+	assert.Len(t, polish, len(catalogs[language.German].messages))
+}
+
+func TestContext(t *testing.T) {
+	assert.Equal(t, language.English, FromContext(context.Background()))
+	assert.Equal(t, language.German, FromContext(NewContext(context.Background(), language.German)))
+}
@@ -0,0 +1,122 @@
+// Package iban validates International Bank Account Numbers (ISO 13616) and extracts the national bank codes
+package iban
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	ErrTooShort         = errors.New("IBAN is too short")
+	ErrIllegalCharacter = errors.New("IBAN contains illegal characters")
+	ErrUnknownCountry   = errors.New("country doesn't use the IBAN")
+	ErrInvalidLength    = errors.New("invalid IBAN length")
+	ErrInvalidStructure = errors.New("BBAN doesn't match the structure of the country")
+	ErrInvalidChecksum  = errors.New("invalid IBAN check digits")
+)
+
+// IBAN is a validated account number split into its parts
+type IBAN struct {
+	// Electronic format, without spaces
+	Value       string `json:"iban"`
+	CountryCode string `json:"countryCode"`
+	CheckDigits string `json:"checkDigits"`
+	BBAN        string `json:"bban"`
+	BankCode    string `json:"bankCode"`
+	BranchCode  string `json:"branchCode,omitempty"`
+}
+
+// Normalize converts the paper format ("IBAN PL61 1090 ...") into the electronic one
+func Normalize(value string) string {
+	value = strings.ToUpper(strings.TrimSpace(value))
+	value = strings.TrimPrefix(value, "IBAN")
+	return strings.Map(func(r rune) rune {
+		if r == ' ' || r == '-' || r == '\t' {
+			return -1
+		}
+		return r
+	}, value)
+}
+
+// Parse validates the IBAN against the registry of the country and the mod-97 check digits
+func Parse(value string) (*IBAN, error) {
+	value = Normalize(value)
+	if len(value) < 5 {
+		return nil, ErrTooShort
+	}
+	for _, r := range value {
+		if (r < '0' || r > '9') && (r < 'A' || r > 'Z') {
+			return nil, ErrIllegalCharacter
+		}
+	}
+
+	countryCode := value[:2]
+	format, ok := LookupFormat(countryCode)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownCountry, countryCode)
+	}
+	if len(value) != format.Length {
+		return nil, fmt.Errorf("%w: %s IBANs have %d characters, got %d", ErrInvalidLength, countryCode, format.Length, len(value))
+	}
+
+	bban := value[4:]
+	if !format.bbanPattern.MatchString(bban) {
+		return nil, fmt.Errorf("%w %s: %s", ErrInvalidStructure, countryCode, format.BBANStructure)
+	}
+	if Checksum(value) != 1 {
+		return nil, ErrInvalidChecksum
+	}
+
+	return &IBAN{
+		Value:       value,
+		CountryCode: countryCode,
+		CheckDigits: value[2:4],
+		BBAN:        bban,
+		BankCode:    format.bankCode.extract(bban),
+		BranchCode:  format.branchCode.extract(bban),
+	}, nil
+}
+
+// Checksum computes the ISO 7064 mod 97-10 remainder of the IBAN, a valid IBAN gives 1
+func Checksum(value string) int {
+	return Mod97(value[4:] + value[:4])
+}
+
+// Mod97 computes the ISO 7064 mod 97-10 remainder of the number formed by the digits and the letters of the value,
+// shared with the other identifiers using the scheme (e.g. the LEI)
+func Mod97(value string) int {
+	remainder := 0
+	for _, r := range value {
+		// Letters count as two digits, A = 10 ... Z = 35
+		if r >= 'A' && r <= 'Z' {
+			remainder = (remainder*100 + int(r-'A'+10)) % 97
+		} else {
+			remainder = (remainder*10 + int(r-'0')) % 97
+		}
+	}
+	return remainder
+}
+
+// CheckDigits computes the check digits for the country and the BBAN
+func CheckDigits(countryCode, bban string) string {
+	remainder := Checksum(strings.ToUpper(countryCode) + "00" + strings.ToUpper(bban))
+	return fmt.Sprintf("%02d", 98-remainder)
+}
+
+// PrintFormat groups the IBAN in blocks of four characters
+func (i *IBAN) PrintFormat() string {
+	var builder strings.Builder
+	for position, r := range i.Value {
+		if position > 0 && position%4 == 0 {
+			builder.WriteRune(' ')
+		}
+		builder.WriteRune(r)
+	}
+	return builder.String()
+}
+
+// NationalID is the bank identifier followed by the branch identifier, which is how the national directories key the banks
+func (i *IBAN) NationalID() string {
+	return i.BankCode + i.BranchCode
+}
@@ -0,0 +1,78 @@
+package iban
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseValid(t *testing.T) {
+	tests := []struct {
+		value      string
+		bankCode   string
+		branchCode string
+	}{
+		{"DE89370400440532013000", "37040044", ""},
+		{"GB29 NWBK 6016 1331 9268 19", "NWBK", "601613"},
+		{"IBAN PL61 1090 1014 0000 0712 1981 2874", "109", "01014"},
+		{"FR1420041010050500013M02606", "20041", "01005"},
+		{"MT84MALT011000012345MTLCAST001S", "MALT", "01100"},
+		{"BG80BNBG96611020345678", "BNBG", "9661"},
+		{"AL47212110090000000235698741", "212", "11009"},
+		{"LV80BANK0000435195001", "BANK", ""},
+		{"MC5811222000010123456789030", "11222", "00001"},
+		{"it60x0542811101000000123456", "05428", "11101"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			parsed, err := Parse(tt.value)
+			require.NoError(t, err)
+			assert.Equal(t, tt.bankCode, parsed.BankCode)
+			assert.Equal(t, tt.branchCode, parsed.BranchCode)
+		})
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected error
+	}{
+		{"DE", ErrTooShort},
+		{"DE89_370400440532013000", ErrIllegalCharacter},
+		{"US12345678901234", ErrUnknownCountry},
+		{"DE8937040044053201300", ErrInvalidLength},
+		{"GB29NWBK6016133192681A", ErrInvalidStructure},
+		{"DE88370400440532013000", ErrInvalidChecksum},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			_, err := Parse(tt.value)
+			assert.True(t, errors.Is(err, tt.expected), "got %v", err)
+		})
+	}
+}
+
+func TestCheckDigits(t *testing.T) {
+	assert.Equal(t, "89", CheckDigits("DE", "370400440532013000"))
+	assert.Equal(t, "61", CheckDigits("pl", "109010140000071219812874"))
+}
+
+func TestPrintFormat(t *testing.T) {
+	parsed, err := Parse("PL61109010140000071219812874")
+	require.NoError(t, err)
+	assert.Equal(t, "PL61 1090 1014 0000 0712 1981 2874", parsed.PrintFormat())
+	assert.Equal(t, "10901014", parsed.NationalID())
+}
+
+func TestRegistry(t *testing.T) {
+	format, ok := LookupFormat("pl")
+	require.True(t, ok)
+	assert.Equal(t, 28, format.Length)
+	assert.Contains(t, Countries(), "XK")
+	assert.NotContains(t, Countries(), "US")
+}
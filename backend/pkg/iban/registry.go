@@ -0,0 +1,125 @@
+package iban
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/csv"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+//go:embed data/registry.csv
+var registryData []byte
+
+// Format is the IBAN structure of a country, as published in the SWIFT IBAN registry
+type Format struct {
+	CountryCode string `json:"countryCode"`
+	Length      int    `json:"length"`
+	// BBAN structure in the registry notation, e.g. "8!n16!n" (n - digits, a - uppercase letters, c - alphanumerics)
+	BBANStructure string `json:"bbanStructure"`
+
+	bbanPattern *regexp.Regexp
+	bankCode    span
+	branchCode  span
+}
+
+// span holds 0-based positions within the BBAN, an empty span means the identifier is not part of the BBAN
+type span struct {
+	start, end int
+}
+
+func (s span) extract(bban string) string {
+	if s.start < 0 || s.end <= s.start || s.end > len(bban) {
+		return ""
+	}
+	return bban[s.start:s.end]
+}
+
+var formats = make(map[string]Format)
+
+var structurePart = regexp.MustCompile(`(\d+)!([nac])`)
+
+func init() {
+	reader := csv.NewReader(bytes.NewReader(registryData))
+	reader.Comma = ';'
+	reader.Comment = '#'
+	records, err := reader.ReadAll()
+	if err != nil {
+		// The file is embedded, so this can only happen with a broken build
+		panic("iban: malformed registry data: " + err.Error())
+	}
+
+	// Skip the header
+	for _, record := range records[1:] {
+		format, err := parseFormat(record)
+		if err != nil {
+			panic("iban: malformed registry data: " + err.Error())
+		}
+		formats[format.CountryCode] = format
+	}
+}
+
+func parseFormat(record []string) (Format, error) {
+	if len(record) != 7 {
+		return Format{}, fmt.Errorf("expected 7 fields, got %d", len(record))
+	}
+
+	numbers := make([]int, 0, 5)
+	for _, field := range []string{record[1], record[3], record[4], record[5], record[6]} {
+		number, err := strconv.Atoi(field)
+		if err != nil {
+			return Format{}, fmt.Errorf("country %s: %w", record[0], err)
+		}
+		numbers = append(numbers, number)
+	}
+
+	pattern, err := compileStructure(record[2])
+	if err != nil {
+		return Format{}, fmt.Errorf("country %s: %w", record[0], err)
+	}
+
+	return Format{
+		CountryCode:   record[0],
+		Length:        numbers[0],
+		BBANStructure: record[2],
+		bbanPattern:   pattern,
+		// The registry counts from 1 and includes the end position
+		bankCode:   span{numbers[1] - 1, numbers[2]},
+		branchCode: span{numbers[3] - 1, numbers[4]},
+	}, nil
+}
+
+// compileStructure converts the registry notation into an anchored regular expression
+func compileStructure(structure string) (*regexp.Regexp, error) {
+	if structurePart.ReplaceAllString(structure, "") != "" {
+		return nil, fmt.Errorf("invalid BBAN structure %s", structure)
+	}
+
+	classes := map[string]string{"n": "[0-9]", "a": "[A-Z]", "c": "[0-9A-Z]"}
+	var pattern strings.Builder
+	pattern.WriteString("^")
+	for _, part := range structurePart.FindAllStringSubmatch(structure, -1) {
+		pattern.WriteString(classes[part[2]] + "{" + part[1] + "}")
+	}
+	pattern.WriteString("$")
+	return regexp.Compile(pattern.String())
+}
+
+// LookupFormat returns the IBAN format of the country
+func LookupFormat(countryCode string) (Format, bool) {
+	format, ok := formats[strings.ToUpper(countryCode)]
+	return format, ok
+}
+
+// Countries lists the countries using the IBAN, sorted by the country code
+func Countries() []string {
+	countries := make([]string, 0, len(formats))
+	for country := range formats {
+		countries = append(countries, country)
+	}
+	sort.Strings(countries)
+	return countries
+}
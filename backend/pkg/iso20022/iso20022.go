@@ -0,0 +1,176 @@
+// Package iso20022 renders the directory entries as the blocks of the ISO 20022 payment messages (pacs.008,
+// pain.001), and scans the messages for the BICs and IBANs of their parties. The elements keep their ISO 20022 tags in
+// both XML and JSON, so that the blocks can be copied into the messages without mapping
+package iso20022
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+
+	"github.com/Hbrtjm/SWIFT_API/backend/pkg/address"
+	"github.com/Hbrtjm/SWIFT_API/backend/pkg/clearing"
+)
+
+// FinancialInstitutionIdentification is the FinInstnId block (FinancialInstitutionIdentification18) identifying an
+// agent of the payment
+type FinancialInstitutionIdentification struct {
+	XMLName                xml.Name                            `xml:"FinInstnId" json:"-"`
+	BICFI                  string                              `xml:"BICFI,omitempty" json:"BICFI,omitempty"`
+	ClearingSystemMemberID *ClearingSystemMemberIdentification `xml:"ClrSysMmbId,omitempty" json:"ClrSysMmbId,omitempty"`
+	LEI                    string                              `xml:"LEI,omitempty" json:"LEI,omitempty"`
+	Name                   string                              `xml:"Nm,omitempty" json:"Nm,omitempty"`
+	PostalAddress          *PostalAddress                      `xml:"PstlAdr,omitempty" json:"PstlAdr,omitempty"`
+}
+
+// ClearingSystemMemberIdentification is the member id of the agent in a national clearing system
+type ClearingSystemMemberIdentification struct {
+	ClearingSystemID *ClearingSystemIdentification `xml:"ClrSysId,omitempty" json:"ClrSysId,omitempty"`
+	MemberID         string                        `xml:"MmbId" json:"MmbId"`
+}
+
+// ClearingSystemIdentification is either a code of the ExternalClearingSystemIdentification1Code list or a
+// proprietary name
+type ClearingSystemIdentification struct {
+	Code        string `xml:"Cd,omitempty" json:"Cd,omitempty"`
+	Proprietary string `xml:"Prtry,omitempty" json:"Prtry,omitempty"`
+}
+
+// PostalAddress is the PstlAdr block (PostalAddress24)
+type PostalAddress struct {
+	StreetName         string   `xml:"StrtNm,omitempty" json:"StrtNm,omitempty"`
+	BuildingNumber     string   `xml:"BldgNb,omitempty" json:"BldgNb,omitempty"`
+	BuildingName       string   `xml:"BldgNm,omitempty" json:"BldgNm,omitempty"`
+	Floor              string   `xml:"Flr,omitempty" json:"Flr,omitempty"`
+	Room               string   `xml:"Room,omitempty" json:"Room,omitempty"`
+	PostCode           string   `xml:"PstCd,omitempty" json:"PstCd,omitempty"`
+	TownName           string   `xml:"TwnNm,omitempty" json:"TwnNm,omitempty"`
+	CountrySubDivision string   `xml:"CtrySubDvsn,omitempty" json:"CtrySubDvsn,omitempty"`
+	Country            string   `xml:"Ctry,omitempty" json:"Ctry,omitempty"`
+	AddressLines       []string `xml:"AdrLine,omitempty" json:"AdrLine,omitempty"`
+}
+
+// Institution holds the directory data of a bank rendered in the block
+type Institution struct {
+	BIC  string
+	LEI  string
+	Name string
+	// Member id of the bank in the clearing system, the system is either a code or a proprietary name
+	ClearingSystem string
+	MemberID       string
+	Address        address.PostalAddress
+	// Free-text address of the directory, put in the address lines when the parsed fields aren't reliable
+	RawAddress string
+}
+
+// Maximum lengths of the rendered values, the longer values are cut
+const (
+	maxNameLength         = 140
+	maxLongTextLength     = 70
+	maxTextLength         = 35
+	maxShortTextLength    = 16
+	maxAddressLines       = 7
+	maxHybridAddressLines = 2
+)
+
+// NewFinancialInstitutionIdentification renders the bank
+func NewFinancialInstitutionIdentification(institution Institution) FinancialInstitutionIdentification {
+	result := FinancialInstitutionIdentification{
+		BICFI:         strings.ToUpper(strings.TrimSpace(institution.BIC)),
+		LEI:           strings.ToUpper(strings.TrimSpace(institution.LEI)),
+		Name:          cut(institution.Name, maxNameLength),
+		PostalAddress: newPostalAddress(institution.Address, institution.RawAddress),
+	}
+
+	if memberID := cut(institution.MemberID, maxTextLength); memberID != "" {
+		member := &ClearingSystemMemberIdentification{MemberID: memberID}
+		if system, ok := clearing.LookupSystem(institution.ClearingSystem); ok {
+			member.ClearingSystemID = &ClearingSystemIdentification{Code: system.Code}
+		} else if name := cut(institution.ClearingSystem, maxTextLength); name != "" {
+			member.ClearingSystemID = &ClearingSystemIdentification{Proprietary: name}
+		}
+		result.ClearingSystemMemberID = member
+	}
+	return result
+}
+
+// newPostalAddress uses the parsed fields when they are reliable. Otherwise the address is sent in the address lines,
+// along with the town and the country when they are known (the hybrid address)
+func newPostalAddress(parsed address.PostalAddress, raw string) *PostalAddress {
+	result := PostalAddress{
+		TownName: cut(parsed.TownName, maxTextLength),
+		Country:  parsed.Country,
+	}
+
+	if parsed.Confidence == address.ConfidenceHigh || parsed.Confidence == address.ConfidenceMedium {
+		result.StreetName = cut(parsed.StreetName, maxLongTextLength)
+		result.BuildingNumber = cut(parsed.BuildingNumber, maxShortTextLength)
+		result.BuildingName = cut(parsed.BuildingName, maxTextLength)
+		result.Floor = cut(parsed.Floor, maxLongTextLength)
+		result.Room = cut(parsed.Room, maxLongTextLength)
+		result.PostCode = cut(parsed.PostCode, maxShortTextLength)
+		result.CountrySubDivision = cut(parsed.CountrySubDivision, maxTextLength)
+	} else {
+		lines := maxAddressLines
+		if result.TownName != "" && result.Country != "" {
+			lines = maxHybridAddressLines
+		}
+		result.AddressLines = wrap(raw, maxLongTextLength, lines)
+	}
+
+	if result.StreetName == "" && result.TownName == "" && result.Country == "" && len(result.AddressLines) == 0 {
+		return nil
+	}
+	return &result
+}
+
+// Validate checks the block against the embedded schema
+func (f FinancialInstitutionIdentification) Validate() error {
+	document, err := xml.Marshal(f)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidDocument, err)
+	}
+	return Validate(document)
+}
+
+// cut collapses the spaces and shortens the value to the maximum number of characters
+func cut(value string, maxLength int) string {
+	value = strings.Join(strings.Fields(value), " ")
+	runes := []rune(value)
+	if len(runes) > maxLength {
+		return strings.TrimSpace(string(runes[:maxLength]))
+	}
+	return value
+}
+
+// wrap splits the text into lines at the spaces, the words longer than a line are split and the text beyond the
+// last line is dropped
+func wrap(text string, lineLength, maxLines int) []string {
+	lines := []string{}
+	line := []rune{}
+	for _, word := range strings.Fields(text) {
+		runes := []rune(word)
+		if len(line) > 0 && len(line)+1+len(runes) > lineLength {
+			lines = append(lines, string(line))
+			line = line[:0]
+		}
+		for len(runes) > lineLength {
+			lines = append(lines, string(runes[:lineLength]))
+			runes = runes[lineLength:]
+		}
+		if len(line) > 0 {
+			line = append(line, ' ')
+		}
+		line = append(line, runes...)
+	}
+	if len(line) > 0 {
+		lines = append(lines, string(line))
+	}
+	if len(lines) > maxLines {
+		lines = lines[:maxLines]
+	}
+	if len(lines) == 0 {
+		return nil
+	}
+	return lines
+}
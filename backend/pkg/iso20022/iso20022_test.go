@@ -0,0 +1,157 @@
+package iso20022
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/Hbrtjm/SWIFT_API/backend/pkg/address"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewFinancialInstitutionIdentification(t *testing.T) {
+	institution := Institution{
+		BIC:            "bpkopplpxxx",
+		LEI:            "P4GTT6GF1W40CVIMFR43",
+		Name:           "PKO BANK POLSKI S.A.",
+		ClearingSystem: "GBDSC",
+		MemberID:       "601613",
+		Address:        address.Parse("UL. PULAWSKA 15  WARSZAWA, MAZOWIECKIE, 02-515", "WARSZAWA", "PL"),
+		RawAddress:     "UL. PULAWSKA 15  WARSZAWA, MAZOWIECKIE, 02-515",
+	}
+
+	result := NewFinancialInstitutionIdentification(institution)
+	require.NoError(t, result.Validate())
+
+	document, err := xml.Marshal(result)
+	require.NoError(t, err)
+	assert.Equal(t, "<FinInstnId><BICFI>BPKOPPLPXXX</BICFI>"+
+		"<ClrSysMmbId><ClrSysId><Cd>GBDSC</Cd></ClrSysId><MmbId>601613</MmbId></ClrSysMmbId>"+
+		"<LEI>P4GTT6GF1W40CVIMFR43</LEI><Nm>PKO BANK POLSKI S.A.</Nm>"+
+		"<PstlAdr><StrtNm>UL. PULAWSKA</StrtNm><BldgNb>15</BldgNb><PstCd>02-515</PstCd><TwnNm>WARSZAWA</TwnNm>"+
+		"<CtrySubDvsn>MAZOWIECKIE</CtrySubDvsn><Ctry>PL</Ctry></PstlAdr></FinInstnId>", string(document))
+
+	encoded, err := json.Marshal(result)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"BICFI":"BPKOPPLPXXX","ClrSysMmbId":{"ClrSysId":{"Cd":"GBDSC"},"MmbId":"601613"},
+		"LEI":"P4GTT6GF1W40CVIMFR43","Nm":"PKO BANK POLSKI S.A.","PstlAdr":{"StrtNm":"UL. PULAWSKA","BldgNb":"15",
+		"PstCd":"02-515","TwnNm":"WARSZAWA","CtrySubDvsn":"MAZOWIECKIE","Ctry":"PL"}}`, string(encoded))
+}
+
+func TestNewFinancialInstitutionIdentificationHybridAddress(t *testing.T) {
+	raw := strings.Repeat("BUILDING ", 20) + "MAIN STREET"
+	result := NewFinancialInstitutionIdentification(Institution{
+		BIC:            "TESTFR22XXX",
+		Name:           strings.Repeat("N", 200),
+		ClearingSystem: "BANK OF FRANCE PROPRIETARY CLEARING SYSTEM",
+		MemberID:       "12345",
+		Address:        address.PostalAddress{TownName: "PARIS", Country: "FR", Confidence: address.ConfidenceLow},
+		RawAddress:     raw,
+	})
+	require.NoError(t, result.Validate())
+
+	assert.Len(t, result.Name, maxNameLength)
+	assert.Equal(t, "BANK OF FRANCE PROPRIETARY CLEARING", result.ClearingSystemMemberID.ClearingSystemID.Proprietary)
+	require.NotNil(t, result.PostalAddress)
+	assert.Equal(t, "PARIS", result.PostalAddress.TownName)
+	assert.Empty(t, result.PostalAddress.StreetName)
+	assert.Len(t, result.PostalAddress.AddressLines, maxHybridAddressLines)
+	for _, line := range result.PostalAddress.AddressLines {
+		assert.LessOrEqual(t, len(line), maxLongTextLength)
+	}
+}
+
+func TestNewFinancialInstitutionIdentificationWithoutAddress(t *testing.T) {
+	result := NewFinancialInstitutionIdentification(Institution{BIC: "TESTFR22"})
+	require.NoError(t, result.Validate())
+	assert.Nil(t, result.PostalAddress)
+	assert.Nil(t, result.ClearingSystemMemberID)
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		document string
+		errorMsg string
+	}{
+		{
+			name:     "Valid block",
+			document: "<FinInstnId><BICFI>TESTFR22</BICFI><Nm>TEST BANK</Nm><PstlAdr><TwnNm>PARIS</TwnNm><Ctry>FR</Ctry></PstlAdr></FinInstnId>",
+		},
+		{
+			name:     "Namespace of the message",
+			document: `<FinInstnId xmlns="urn:iso:std:iso:20022:tech:xsd:pacs.008.001.08"><BICFI>TESTFR22XXX</BICFI></FinInstnId>`,
+		},
+		{
+			name:     "Invalid BIC",
+			document: "<FinInstnId><BICFI>TEST1122</BICFI></FinInstnId>",
+			errorMsg: "FinInstnId/BICFI: value \"TEST1122\" doesn't match the pattern",
+		},
+		{
+			name:     "Wrong order",
+			document: "<FinInstnId><Nm>TEST BANK</Nm><BICFI>TESTFR22</BICFI></FinInstnId>",
+			errorMsg: "FinInstnId: unexpected element BICFI",
+		},
+		{
+			name:     "Repeated element",
+			document: "<FinInstnId><BICFI>TESTFR22</BICFI><BICFI>TESTFR22</BICFI></FinInstnId>",
+			errorMsg: "element BICFI occurs 2 times, at most 1 allowed",
+		},
+		{
+			name:     "Missing member id",
+			document: "<FinInstnId><ClrSysMmbId><ClrSysId><Cd>GBDSC</Cd></ClrSysId></ClrSysMmbId></FinInstnId>",
+			errorMsg: "FinInstnId/ClrSysMmbId: missing element MmbId",
+		},
+		{
+			name:     "Both options of a choice",
+			document: "<FinInstnId><ClrSysMmbId><ClrSysId><Cd>GBDSC</Cd><Prtry>SORT</Prtry></ClrSysId><MmbId>1</MmbId></ClrSysMmbId></FinInstnId>",
+			errorMsg: "FinInstnId/ClrSysMmbId/ClrSysId: expected exactly one of Cd, Prtry",
+		},
+		{
+			name:     "Too long value",
+			document: "<FinInstnId><PstlAdr><TwnNm>" + strings.Repeat("A", 36) + "</TwnNm></PstlAdr></FinInstnId>",
+			errorMsg: "FinInstnId/PstlAdr/TwnNm: length 36 exceeds the maximum of 35",
+		},
+		{
+			name:     "Empty value",
+			document: "<FinInstnId><Nm></Nm></FinInstnId>",
+			errorMsg: "FinInstnId/Nm: length 0 is below the minimum of 1",
+		},
+		{
+			name:     "Too many address lines",
+			document: "<FinInstnId><PstlAdr>" + strings.Repeat("<AdrLine>A</AdrLine>", 8) + "</PstlAdr></FinInstnId>",
+			errorMsg: "element AdrLine occurs 8 times, at most 7 allowed",
+		},
+		{
+			name:     "Unknown root",
+			document: "<Dbtr><Nm>TEST</Nm></Dbtr>",
+			errorMsg: "unexpected root element Dbtr",
+		},
+		{
+			name:     "Malformed XML",
+			document: "<FinInstnId><BICFI>TESTFR22</FinInstnId>",
+			errorMsg: ErrInvalidDocument.Error(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate([]byte(tt.document))
+			if tt.errorMsg == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, ErrInvalidDocument)
+			assert.ErrorContains(t, err, tt.errorMsg)
+		})
+	}
+}
+
+func TestWrap(t *testing.T) {
+	assert.Equal(t, []string{"AB CD", "EF"}, wrap("AB  CD EF", 5, 3))
+	assert.Equal(t, []string{"ABCDE", "FG HI"}, wrap("ABCDEFG HI", 5, 3))
+	assert.Equal(t, []string{"AB CD"}, wrap("AB CD EF", 5, 1))
+	assert.Nil(t, wrap("   ", 5, 3))
+}
@@ -0,0 +1,210 @@
+package iso20022
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+var (
+	ErrMalformedMessage   = errors.New("malformed message")
+	ErrUnsupportedMessage = errors.New("unsupported message type")
+)
+
+// Message types whose transactions are scanned
+const (
+	// Customer credit transfer initiation
+	MessageTypePain001 = "pain.001"
+	// FI to FI customer credit transfer
+	MessageTypePacs008 = "pacs.008"
+)
+
+const namespacePrefix = "urn:iso:std:iso:20022:tech:xsd:"
+
+// Elements opening the levels of the message, the parties of the group header and of the payment information apply
+// to all of their transactions
+const (
+	groupHeaderElement        = "GrpHdr"
+	paymentInformationElement = "PmtInf"
+	transactionElement        = "CdtTrfTxInf"
+)
+
+// Party is a BIC or an IBAN found in the message
+type Party struct {
+	// Element holding the party at its level, e.g. DbtrAgt or CdtrAcct
+	Role string `json:"role"`
+	// Path of the value from the message element, e.g. PmtInf/DbtrAgt/FinInstnId/BICFI
+	Path string `json:"path"`
+	BIC  string `json:"bic,omitempty"`
+	IBAN string `json:"iban,omitempty"`
+}
+
+// IsAccount checks whether the party is an account, the other parties are agents
+func (p Party) IsAccount() bool {
+	return p.IBAN != ""
+}
+
+// AgentRole returns the role of the agent servicing the account, e.g. DbtrAgt for DbtrAcct and IntrmyAgt1 for
+// IntrmyAgt1Acct
+func (p Party) AgentRole() string {
+	role := strings.TrimSuffix(p.Role, "Acct")
+	if strings.HasSuffix(role, "Dbtr") || strings.HasSuffix(role, "Cdtr") {
+		role += "Agt"
+	}
+	return role
+}
+
+// Transaction is a credit transfer with the parties of its own and of the levels above it
+type Transaction struct {
+	// 1-based position in the message
+	Index                int     `json:"index"`
+	PaymentInformationID string  `json:"paymentInformationId,omitempty"`
+	InstructionID        string  `json:"instructionId,omitempty"`
+	EndToEndID           string  `json:"endToEndId,omitempty"`
+	TransactionID        string  `json:"transactionId,omitempty"`
+	UETR                 string  `json:"uetr,omitempty"`
+	Parties              []Party `json:"parties"`
+}
+
+// Scan reads the pain.001 or pacs.008 message as a stream and calls the function with every transaction once it's
+// complete, so the message is never held in memory. It returns the message type with its version, e.g.
+// pain.001.001.09, the errors of the function stop the scan and are returned as they are
+func Scan(r io.Reader, fn func(Transaction) error) (string, error) {
+	decoder := xml.NewDecoder(r)
+
+	messageType := ""
+	stack := []string{}
+	// The parties of the group header and of the current payment information
+	groupParties, paymentParties := []Party{}, []Party{}
+	paymentInformationID := ""
+	var transaction *Transaction
+	index := 0
+	text := strings.Builder{}
+
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			// The read errors are kept, e.g. for the size limit of the uploads
+			return messageType, fmt.Errorf("%w: %w", ErrMalformedMessage, err)
+		}
+
+		switch element := token.(type) {
+		case xml.StartElement:
+			if len(stack) == 0 {
+				messageType, err = messageTypeOf(element.Name)
+				if err != nil {
+					return "", err
+				}
+			}
+			stack = append(stack, element.Name.Local)
+			text.Reset()
+
+			switch element.Name.Local {
+			case paymentInformationElement:
+				paymentParties, paymentInformationID = []Party{}, ""
+			case transactionElement:
+				index++
+				transaction = &Transaction{Index: index, PaymentInformationID: paymentInformationID}
+				transaction.Parties = append(append(transaction.Parties, groupParties...), paymentParties...)
+			}
+
+		case xml.CharData:
+			text.Write(element)
+
+		case xml.EndElement:
+			value := strings.TrimSpace(text.String())
+			text.Reset()
+			name := element.Name.Local
+			parent := ""
+			if len(stack) >= 2 {
+				parent = stack[len(stack)-2]
+			}
+
+			switch {
+			case name == transactionElement && transaction != nil:
+				if err := fn(*transaction); err != nil {
+					return messageType, err
+				}
+				transaction = nil
+			case name == "PmtInfId" && parent == paymentInformationElement:
+				paymentInformationID = value
+			case parent == "PmtId" && transaction != nil:
+				switch name {
+				case "InstrId":
+					transaction.InstructionID = value
+				case "EndToEndId":
+					transaction.EndToEndID = value
+				case "TxId":
+					transaction.TransactionID = value
+				case "UETR":
+					transaction.UETR = value
+				}
+			case (name == "BICFI" || name == "BIC") && parent == "FinInstnId", name == "IBAN" && parent == "Id":
+				if party, ok := partyOf(stack, value); ok {
+					switch {
+					case transaction != nil:
+						transaction.Parties = append(transaction.Parties, party)
+					case containsElement(stack, paymentInformationElement):
+						paymentParties = append(paymentParties, party)
+					case containsElement(stack, groupHeaderElement):
+						groupParties = append(groupParties, party)
+					}
+				}
+			}
+			stack = stack[:len(stack)-1]
+		}
+	}
+
+	if messageType == "" {
+		return "", fmt.Errorf("%w: empty document", ErrMalformedMessage)
+	}
+	return messageType, nil
+}
+
+// messageTypeOf reads the message type from the namespace of the document, e.g.
+// urn:iso:std:iso:20022:tech:xsd:pacs.008.001.08
+func messageTypeOf(name xml.Name) (string, error) {
+	messageType, ok := strings.CutPrefix(name.Space, namespacePrefix)
+	if !ok {
+		return "", fmt.Errorf("%w: namespace %q of %s is not an ISO 20022 message", ErrUnsupportedMessage, name.Space, name.Local)
+	}
+	if !strings.HasPrefix(messageType, MessageTypePain001+".") && !strings.HasPrefix(messageType, MessageTypePacs008+".") {
+		return "", fmt.Errorf("%w: %s", ErrUnsupportedMessage, messageType)
+	}
+	return messageType, nil
+}
+
+// partyOf finds the role of the value, which is the element under the group header, the payment information or the
+// transaction. The path starts from the level element
+func partyOf(stack []string, value string) (Party, bool) {
+	for i := len(stack) - 1; i >= 0; i-- {
+		switch stack[i] {
+		case groupHeaderElement, paymentInformationElement, transactionElement:
+			if i+1 >= len(stack) {
+				return Party{}, false
+			}
+			party := Party{Role: stack[i+1], Path: strings.Join(stack[i:], "/")}
+			if stack[len(stack)-1] == "IBAN" {
+				party.IBAN = value
+			} else {
+				party.BIC = value
+			}
+			return party, true
+		}
+	}
+	return Party{}, false
+}
+
+func containsElement(stack []string, name string) bool {
+	for _, element := range stack {
+		if element == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,148 @@
+package iso20022
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const pain001 = `<?xml version="1.0" encoding="UTF-8"?>
+<Document xmlns="urn:iso:std:iso:20022:tech:xsd:pain.001.001.09">
+  <CstmrCdtTrfInitn>
+    <GrpHdr>
+      <MsgId>MSG-1</MsgId>
+      <NbOfTxs>2</NbOfTxs>
+      <InitgPty><Nm>TEST COMPANY</Nm></InitgPty>
+    </GrpHdr>
+    <PmtInf>
+      <PmtInfId>PMT-1</PmtInfId>
+      <DbtrAcct><Id><IBAN>PL61109010140000071219812874</IBAN></Id></DbtrAcct>
+      <DbtrAgt><FinInstnId><BICFI>WBKPPLPPXXX</BICFI></FinInstnId></DbtrAgt>
+      <CdtTrfTxInf>
+        <PmtId><InstrId>INSTR-1</InstrId><EndToEndId>E2E-1</EndToEndId></PmtId>
+        <CdtrAgt><FinInstnId><BICFI>DEUTDEFF</BICFI></FinInstnId></CdtrAgt>
+        <Cdtr><Nm>CREDITOR</Nm></Cdtr>
+        <CdtrAcct><Id><IBAN>DE89370400440532013000</IBAN></Id></CdtrAcct>
+      </CdtTrfTxInf>
+      <CdtTrfTxInf>
+        <PmtId><EndToEndId>E2E-2</EndToEndId></PmtId>
+        <IntrmyAgt1><FinInstnId><BICFI>COBADEFFXXX</BICFI></FinInstnId></IntrmyAgt1>
+        <CdtrAgt><FinInstnId><ClrSysMmbId><MmbId>37040044</MmbId></ClrSysMmbId></FinInstnId></CdtrAgt>
+        <CdtrAcct><Id><Othr><Id>12345</Id></Othr></Id></CdtrAcct>
+      </CdtTrfTxInf>
+    </PmtInf>
+    <PmtInf>
+      <PmtInfId>PMT-2</PmtInfId>
+      <DbtrAgt><FinInstnId><BICFI>BPKOPLPW</BICFI></FinInstnId></DbtrAgt>
+      <CdtTrfTxInf>
+        <PmtId><EndToEndId>E2E-3</EndToEndId></PmtId>
+      </CdtTrfTxInf>
+    </PmtInf>
+  </CstmrCdtTrfInitn>
+</Document>`
+
+const pacs008 = `<Document xmlns="urn:iso:std:iso:20022:tech:xsd:pacs.008.001.08">
+  <FIToFICstmrCdtTrf>
+    <GrpHdr>
+      <MsgId>MSG-2</MsgId>
+      <InstgAgt><FinInstnId><BICFI>BNPAFRPPXXX</BICFI></FinInstnId></InstgAgt>
+    </GrpHdr>
+    <CdtTrfTxInf>
+      <PmtId><EndToEndId>E2E-1</EndToEndId><TxId>TX-1</TxId><UETR>eb6305c9-1f7f-49de-aed0-16487c27b42d</UETR></PmtId>
+      <DbtrAgt><FinInstnId><BICFI>BNPAFRPP</BICFI></FinInstnId></DbtrAgt>
+      <DbtrAgtAcct><Id><IBAN>FR1420041010050500013M02606</IBAN></Id></DbtrAgtAcct>
+    </CdtTrfTxInf>
+  </FIToFICstmrCdtTrf>
+</Document>`
+
+func TestScanPain001(t *testing.T) {
+	transactions := []Transaction{}
+	messageType, err := Scan(strings.NewReader(pain001), func(transaction Transaction) error {
+		transactions = append(transactions, transaction)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "pain.001.001.09", messageType)
+	require.Len(t, transactions, 3)
+
+	assert.Equal(t, Transaction{
+		Index:                1,
+		PaymentInformationID: "PMT-1",
+		InstructionID:        "INSTR-1",
+		EndToEndID:           "E2E-1",
+		Parties: []Party{
+			{Role: "DbtrAcct", Path: "PmtInf/DbtrAcct/Id/IBAN", IBAN: "PL61109010140000071219812874"},
+			{Role: "DbtrAgt", Path: "PmtInf/DbtrAgt/FinInstnId/BICFI", BIC: "WBKPPLPPXXX"},
+			{Role: "CdtrAgt", Path: "CdtTrfTxInf/CdtrAgt/FinInstnId/BICFI", BIC: "DEUTDEFF"},
+			{Role: "CdtrAcct", Path: "CdtTrfTxInf/CdtrAcct/Id/IBAN", IBAN: "DE89370400440532013000"},
+		},
+	}, transactions[0])
+
+	// The parties of the payment information are repeated in every transaction
+	assert.Equal(t, "E2E-2", transactions[1].EndToEndID)
+	assert.Empty(t, transactions[1].InstructionID)
+	assert.Equal(t, []Party{
+		{Role: "DbtrAcct", Path: "PmtInf/DbtrAcct/Id/IBAN", IBAN: "PL61109010140000071219812874"},
+		{Role: "DbtrAgt", Path: "PmtInf/DbtrAgt/FinInstnId/BICFI", BIC: "WBKPPLPPXXX"},
+		{Role: "IntrmyAgt1", Path: "CdtTrfTxInf/IntrmyAgt1/FinInstnId/BICFI", BIC: "COBADEFFXXX"},
+	}, transactions[1].Parties)
+
+	// A new payment information replaces the parties
+	assert.Equal(t, "PMT-2", transactions[2].PaymentInformationID)
+	assert.Equal(t, []Party{{Role: "DbtrAgt", Path: "PmtInf/DbtrAgt/FinInstnId/BICFI", BIC: "BPKOPLPW"}}, transactions[2].Parties)
+}
+
+func TestScanPacs008(t *testing.T) {
+	transactions := []Transaction{}
+	messageType, err := Scan(strings.NewReader(pacs008), func(transaction Transaction) error {
+		transactions = append(transactions, transaction)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "pacs.008.001.08", messageType)
+	require.Len(t, transactions, 1)
+	assert.Equal(t, "TX-1", transactions[0].TransactionID)
+	assert.Equal(t, "eb6305c9-1f7f-49de-aed0-16487c27b42d", transactions[0].UETR)
+	assert.Equal(t, []Party{
+		{Role: "InstgAgt", Path: "GrpHdr/InstgAgt/FinInstnId/BICFI", BIC: "BNPAFRPPXXX"},
+		{Role: "DbtrAgt", Path: "CdtTrfTxInf/DbtrAgt/FinInstnId/BICFI", BIC: "BNPAFRPP"},
+		{Role: "DbtrAgtAcct", Path: "CdtTrfTxInf/DbtrAgtAcct/Id/IBAN", IBAN: "FR1420041010050500013M02606"},
+	}, transactions[0].Parties)
+	assert.Equal(t, "DbtrAgt", transactions[0].Parties[2].AgentRole())
+}
+
+func TestScanErrors(t *testing.T) {
+	noop := func(Transaction) error { return nil }
+
+	_, err := Scan(strings.NewReader(`<Document xmlns="urn:iso:std:iso:20022:tech:xsd:camt.053.001.08"/>`), noop)
+	assert.ErrorIs(t, err, ErrUnsupportedMessage)
+
+	_, err = Scan(strings.NewReader(`<Document><CstmrCdtTrfInitn/></Document>`), noop)
+	assert.ErrorIs(t, err, ErrUnsupportedMessage)
+
+	_, err = Scan(strings.NewReader(pain001[:400]), noop)
+	assert.ErrorIs(t, err, ErrMalformedMessage)
+
+	_, err = Scan(strings.NewReader(""), noop)
+	assert.ErrorIs(t, err, ErrMalformedMessage)
+
+	// The error of the function stops the scan
+	stop := errors.New("stop")
+	count := 0
+	_, err = Scan(strings.NewReader(pain001), func(Transaction) error {
+		count++
+		return stop
+	})
+	assert.ErrorIs(t, err, stop)
+	assert.Equal(t, 1, count)
+}
+
+func TestPartyAgentRole(t *testing.T) {
+	assert.Equal(t, "DbtrAgt", Party{Role: "DbtrAcct"}.AgentRole())
+	assert.Equal(t, "CdtrAgt", Party{Role: "CdtrAcct"}.AgentRole())
+	assert.Equal(t, "CdtrAgt", Party{Role: "CdtrAgtAcct"}.AgentRole())
+	assert.Equal(t, "IntrmyAgt1", Party{Role: "IntrmyAgt1Acct"}.AgentRole())
+}
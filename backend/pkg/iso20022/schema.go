@@ -0,0 +1,282 @@
+package iso20022
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+var ErrInvalidDocument = errors.New("document doesn't match the schema")
+
+//go:embed data/fininstnid.xsd
+var schemaData []byte
+
+// The parts of XSD used by the embedded schema: sequences and choices of elements, and string restrictions
+type xsdSchema struct {
+	Elements     []xsdElement     `xml:"element"`
+	ComplexTypes []xsdComplexType `xml:"complexType"`
+	SimpleTypes  []xsdSimpleType  `xml:"simpleType"`
+}
+
+type xsdElement struct {
+	Name      string `xml:"name,attr"`
+	Type      string `xml:"type,attr"`
+	MinOccurs string `xml:"minOccurs,attr"`
+	MaxOccurs string `xml:"maxOccurs,attr"`
+}
+
+type xsdGroup struct {
+	Elements []xsdElement `xml:"element"`
+}
+
+type xsdComplexType struct {
+	Name     string    `xml:"name,attr"`
+	Sequence *xsdGroup `xml:"sequence"`
+	Choice   *xsdGroup `xml:"choice"`
+}
+
+type xsdValue struct {
+	Value string `xml:"value,attr"`
+}
+
+type xsdSimpleType struct {
+	Name        string `xml:"name,attr"`
+	Restriction struct {
+		Base         string     `xml:"base,attr"`
+		Patterns     []xsdValue `xml:"pattern"`
+		MinLength    *xsdValue  `xml:"minLength"`
+		MaxLength    *xsdValue  `xml:"maxLength"`
+		Enumerations []xsdValue `xml:"enumeration"`
+	} `xml:"restriction"`
+}
+
+// particle is an element allowed in a complex type
+type particle struct {
+	name     string
+	typeName string
+	min      int
+	// -1 when unbounded
+	max int
+}
+
+type complexType struct {
+	particles []particle
+	choice    bool
+}
+
+type simpleType struct {
+	patterns     []*regexp.Regexp
+	minLength    int
+	maxLength    int
+	enumerations []string
+}
+
+type schema struct {
+	roots   map[string]string
+	complex map[string]complexType
+	simple  map[string]simpleType
+}
+
+var embeddedSchema = mustParseSchema(schemaData)
+
+// mustParseSchema compiles the XSD, it panics on malformed data since the schema is embedded
+func mustParseSchema(data []byte) schema {
+	var definition xsdSchema
+	if err := xml.Unmarshal(data, &definition); err != nil {
+		panic(fmt.Sprintf("iso20022: malformed schema: %v", err))
+	}
+
+	result := schema{
+		roots:   map[string]string{},
+		complex: map[string]complexType{},
+		simple:  map[string]simpleType{},
+	}
+	for _, element := range definition.Elements {
+		result.roots[element.Name] = element.Type
+	}
+	for _, definedType := range definition.ComplexTypes {
+		group, choice := definedType.Sequence, false
+		if definedType.Choice != nil {
+			group, choice = definedType.Choice, true
+		}
+		if group == nil {
+			panic(fmt.Sprintf("iso20022: complex type %s has no sequence or choice", definedType.Name))
+		}
+		compiled := complexType{choice: choice}
+		for _, element := range group.Elements {
+			compiled.particles = append(compiled.particles, particle{
+				name:     element.Name,
+				typeName: element.Type,
+				min:      mustParseOccurs(element.MinOccurs, definedType.Name),
+				max:      mustParseOccurs(element.MaxOccurs, definedType.Name),
+			})
+		}
+		result.complex[definedType.Name] = compiled
+	}
+	for _, definedType := range definition.SimpleTypes {
+		restriction := definedType.Restriction
+		if restriction.Base != "xs:string" {
+			panic(fmt.Sprintf("iso20022: simple type %s has unsupported base %s", definedType.Name, restriction.Base))
+		}
+		compiled := simpleType{minLength: -1, maxLength: -1}
+		for _, pattern := range restriction.Patterns {
+			// The XSD patterns match the whole value
+			compiled.patterns = append(compiled.patterns, regexp.MustCompile("^(?:"+pattern.Value+")$"))
+		}
+		if restriction.MinLength != nil {
+			compiled.minLength = mustParseOccurs(restriction.MinLength.Value, definedType.Name)
+		}
+		if restriction.MaxLength != nil {
+			compiled.maxLength = mustParseOccurs(restriction.MaxLength.Value, definedType.Name)
+		}
+		for _, enumeration := range restriction.Enumerations {
+			compiled.enumerations = append(compiled.enumerations, enumeration.Value)
+		}
+		result.simple[definedType.Name] = compiled
+	}
+
+	// Every referenced type has to be defined, otherwise the validation would silently accept anything
+	for name, particles := range result.complex {
+		for _, p := range particles.particles {
+			if _, ok := result.complex[p.typeName]; ok {
+				continue
+			}
+			if _, ok := result.simple[p.typeName]; !ok {
+				panic(fmt.Sprintf("iso20022: type %s of %s/%s is not defined", p.typeName, name, p.name))
+			}
+		}
+	}
+	return result
+}
+
+// mustParseOccurs parses the occurrence and length attributes, the omitted occurrences default to 1
+func mustParseOccurs(value, typeName string) int {
+	switch value {
+	case "":
+		return 1
+	case "unbounded":
+		return -1
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		panic(fmt.Sprintf("iso20022: malformed occurrence %q in %s", value, typeName))
+	}
+	return n
+}
+
+// node is a decoded element of the validated document
+type node struct {
+	XMLName xml.Name
+	Attrs   []xml.Attr `xml:",any,attr"`
+	Nodes   []node     `xml:",any"`
+	Text    string     `xml:",chardata"`
+}
+
+// Validate checks the element structure of the XML document against the embedded schema: the order and the number
+// of the elements, and the patterns and lengths of the values
+func Validate(document []byte) error {
+	var root node
+	decoder := xml.NewDecoder(bytes.NewReader(document))
+	if err := decoder.Decode(&root); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidDocument, err)
+	}
+	typeName, ok := embeddedSchema.roots[root.XMLName.Local]
+	if !ok {
+		return fmt.Errorf("%w: unexpected root element %s", ErrInvalidDocument, root.XMLName.Local)
+	}
+	return embeddedSchema.validate(root, typeName, root.XMLName.Local)
+}
+
+func (s schema) validate(element node, typeName, path string) error {
+	if simple, ok := s.simple[typeName]; ok {
+		if len(element.Nodes) > 0 {
+			return fmt.Errorf("%w: %s: unexpected element %s", ErrInvalidDocument, path, element.Nodes[0].XMLName.Local)
+		}
+		if err := simple.check(element.Text); err != nil {
+			return fmt.Errorf("%w: %s: %v", ErrInvalidDocument, path, err)
+		}
+		return nil
+	}
+
+	definition := s.complex[typeName]
+	if strings.TrimSpace(element.Text) != "" {
+		return fmt.Errorf("%w: %s: unexpected text", ErrInvalidDocument, path)
+	}
+
+	if definition.choice {
+		if len(element.Nodes) != 1 {
+			return fmt.Errorf("%w: %s: expected exactly one of %s", ErrInvalidDocument, path, definition.names())
+		}
+		child := element.Nodes[0]
+		for _, p := range definition.particles {
+			if p.name == child.XMLName.Local {
+				return s.validate(child, p.typeName, path+"/"+p.name)
+			}
+		}
+		return fmt.Errorf("%w: %s: unexpected element %s, expected one of %s", ErrInvalidDocument, path,
+			child.XMLName.Local, definition.names())
+	}
+
+	// The sequence takes the consecutive elements of each particle in turn
+	children := element.Nodes
+	for _, p := range definition.particles {
+		count := 0
+		for len(children) > 0 && children[0].XMLName.Local == p.name {
+			if err := s.validate(children[0], p.typeName, path+"/"+p.name); err != nil {
+				return err
+			}
+			children = children[1:]
+			count++
+		}
+		if count < p.min {
+			return fmt.Errorf("%w: %s: missing element %s", ErrInvalidDocument, path, p.name)
+		}
+		if p.max >= 0 && count > p.max {
+			return fmt.Errorf("%w: %s: element %s occurs %d times, at most %d allowed", ErrInvalidDocument, path,
+				p.name, count, p.max)
+		}
+	}
+	if len(children) > 0 {
+		return fmt.Errorf("%w: %s: unexpected element %s", ErrInvalidDocument, path, children[0].XMLName.Local)
+	}
+	return nil
+}
+
+func (c complexType) names() string {
+	names := make([]string, len(c.particles))
+	for i, p := range c.particles {
+		names[i] = p.name
+	}
+	return strings.Join(names, ", ")
+}
+
+// check applies the restrictions, the lengths are counted in characters
+func (t simpleType) check(value string) error {
+	length := utf8.RuneCountInString(value)
+	if t.minLength >= 0 && length < t.minLength {
+		return fmt.Errorf("length %d is below the minimum of %d", length, t.minLength)
+	}
+	if t.maxLength >= 0 && length > t.maxLength {
+		return fmt.Errorf("length %d exceeds the maximum of %d", length, t.maxLength)
+	}
+	for _, pattern := range t.patterns {
+		if !pattern.MatchString(value) {
+			return fmt.Errorf("value %q doesn't match the pattern %s", value, pattern.String())
+		}
+	}
+	if len(t.enumerations) > 0 {
+		for _, enumeration := range t.enumerations {
+			if value == enumeration {
+				return nil
+			}
+		}
+		return fmt.Errorf("value %q is not one of %s", value, strings.Join(t.enumerations, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,147 @@
+// Package iso3166 is an embedded registry of the ISO 3166-1 country codes
+package iso3166
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/csv"
+	"sort"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+//go:embed data/iso3166-1.csv
+var registryData []byte
+
+// Country is a single ISO 3166-1 entry
+type Country struct {
+	Alpha2 string `json:"alpha2"`
+	Alpha3 string `json:"alpha3"`
+	// Numeric code is kept as a string, since the leading zeros are significant
+	Numeric string `json:"numeric"`
+	// Official short name (in English) as assigned by ISO
+	Name         string `json:"name"`
+	OfficialName string `json:"officialName,omitempty"`
+	CommonName   string `json:"commonName,omitempty"`
+}
+
+var (
+	countries = []Country{}
+	byAlpha2  = make(map[string]Country)
+	byAlpha3  = make(map[string]Country)
+	byName    = make(map[string]Country)
+)
+
+func init() {
+	reader := csv.NewReader(bytes.NewReader(registryData))
+	reader.Comma = ';'
+	reader.Comment = '#'
+	records, err := reader.ReadAll()
+	if err != nil {
+		// The file is embedded, so this can only happen with a broken build
+		panic("iso3166: malformed registry data: " + err.Error())
+	}
+
+	// Skip the header
+	for _, record := range records[1:] {
+		country := Country{
+			Alpha2:       record[0],
+			Alpha3:       record[1],
+			Numeric:      record[2],
+			Name:         record[3],
+			OfficialName: record[4],
+			CommonName:   record[5],
+		}
+		countries = append(countries, country)
+		byAlpha2[country.Alpha2] = country
+		byAlpha3[country.Alpha3] = country
+		for _, name := range country.nameVariants() {
+			byName[name] = country
+		}
+	}
+}
+
+// Lookup finds a country by its alpha-2 code
+func Lookup(alpha2 string) (Country, bool) {
+	country, ok := byAlpha2[strings.ToUpper(strings.TrimSpace(alpha2))]
+	return country, ok
+}
+
+// LookupAlpha3 finds a country by its alpha-3 code
+func LookupAlpha3(alpha3 string) (Country, bool) {
+	country, ok := byAlpha3[strings.ToUpper(strings.TrimSpace(alpha3))]
+	return country, ok
+}
+
+// LookupName finds a country by any of its names, ignoring case, accents and punctuation
+func LookupName(name string) (Country, bool) {
+	country, ok := byName[NormalizeName(name)]
+	return country, ok
+}
+
+// All returns every country sorted by the alpha-2 code
+func All() []Country {
+	result := make([]Country, len(countries))
+	copy(result, countries)
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Alpha2 < result[j].Alpha2
+	})
+	return result
+}
+
+// MatchesName checks whether the name refers to this country
+func (c Country) MatchesName(name string) bool {
+	normalized := NormalizeName(name)
+	for _, variant := range c.nameVariants() {
+		if variant == normalized {
+			return true
+		}
+	}
+	return false
+}
+
+// nameVariants lists the normalized names under which the country is known. ISO writes
+// some of the names with a qualifier after a comma ("Korea, Republic of"), so the part
+// before the comma and the reordered form are accepted as well
+func (c Country) nameVariants() []string {
+	variants := []string{}
+	for _, name := range []string{c.Name, c.OfficialName, c.CommonName} {
+		if name == "" {
+			continue
+		}
+		variants = append(variants, NormalizeName(name))
+		if head, qualifier, found := strings.Cut(name, ","); found {
+			variants = append(variants, NormalizeName(head))
+			variants = append(variants, NormalizeName(qualifier+" "+head))
+		}
+	}
+	return variants
+}
+
+var foldAccents = transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+
+// NormalizeName uppercases the name, removes the accents and keeps only letters, digits and single spaces
+func NormalizeName(name string) string {
+	folded, _, err := transform.String(foldAccents, name)
+	if err != nil {
+		folded = name
+	}
+
+	var builder strings.Builder
+	lastSpace := true
+	for _, r := range strings.ToUpper(folded) {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			builder.WriteRune(r)
+			lastSpace = false
+		case !lastSpace:
+			builder.WriteRune(' ')
+			lastSpace = true
+		}
+	}
+	return strings.TrimSpace(builder.String())
+}
@@ -0,0 +1,63 @@
+package iso3166
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLookup(t *testing.T) {
+	country, ok := Lookup("pl")
+	require.True(t, ok)
+	assert.Equal(t, Country{Alpha2: "PL", Alpha3: "POL", Numeric: "616", Name: "Poland", OfficialName: "Republic of Poland"}, country)
+
+	country, ok = LookupAlpha3("DEU")
+	require.True(t, ok)
+	assert.Equal(t, "DE", country.Alpha2)
+	assert.Equal(t, "276", country.Numeric)
+
+	_, ok = Lookup("XX")
+	assert.False(t, ok)
+
+	// Used in BICs even though it's not officially assigned
+	_, ok = Lookup("XK")
+	assert.True(t, ok)
+}
+
+func TestLookupName(t *testing.T) {
+	country, ok := LookupName("POLAND")
+	require.True(t, ok)
+	assert.Equal(t, "PL", country.Alpha2)
+
+	for _, name := range []string{"Korea, Republic of", "REPUBLIC OF KOREA", "South Korea", "korea"} {
+		country, ok = LookupName(name)
+		require.True(t, ok, name)
+		assert.Equal(t, "KR", country.Alpha2, name)
+	}
+
+	country, ok = LookupName("TURKIYE")
+	require.True(t, ok)
+	assert.Equal(t, "TR", country.Alpha2)
+
+	_, ok = LookupName("Atlantis")
+	assert.False(t, ok)
+}
+
+func TestMatchesName(t *testing.T) {
+	country, _ := Lookup("BO")
+	assert.True(t, country.MatchesName("BOLIVIA"))
+	assert.True(t, country.MatchesName("Plurinational State of Bolivia"))
+	assert.False(t, country.MatchesName("PERU"))
+}
+
+func TestAll(t *testing.T) {
+	all := All()
+	assert.Len(t, all, 250)
+	assert.Equal(t, "AD", all[0].Alpha2)
+}
+
+func TestNormalizeName(t *testing.T) {
+	assert.Equal(t, "COTE D IVOIRE", NormalizeName("Côte d'Ivoire"))
+	assert.Equal(t, "KOREA REPUBLIC OF", NormalizeName("  Korea,   Republic of "))
+}
@@ -0,0 +1,56 @@
+// Package iso4217 is an embedded registry of the ISO 4217 currency codes
+package iso4217
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/csv"
+	"strings"
+)
+
+//go:embed data/iso4217.csv
+var registryData []byte
+
+// Currency is a single ISO 4217 entry
+type Currency struct {
+	Code string `json:"code"`
+	// Numeric code is kept as a string, since the leading zeros are significant
+	Numeric string `json:"numeric"`
+	Name    string `json:"name"`
+}
+
+var (
+	currencies = []Currency{}
+	byCode     = make(map[string]Currency)
+)
+
+func init() {
+	reader := csv.NewReader(bytes.NewReader(registryData))
+	reader.Comma = ';'
+	reader.Comment = '#'
+	records, err := reader.ReadAll()
+	if err != nil {
+		// The file is embedded, so this can only happen with a broken build
+		panic("iso4217: malformed registry data: " + err.Error())
+	}
+
+	// Skip the header, the file is sorted by the code
+	for _, record := range records[1:] {
+		currency := Currency{Code: record[0], Numeric: record[1], Name: record[2]}
+		currencies = append(currencies, currency)
+		byCode[currency.Code] = currency
+	}
+}
+
+// Lookup finds a currency by its alphabetic code
+func Lookup(code string) (Currency, bool) {
+	currency, ok := byCode[strings.ToUpper(strings.TrimSpace(code))]
+	return currency, ok
+}
+
+// All returns every currency sorted by the code
+func All() []Currency {
+	result := make([]Currency, len(currencies))
+	copy(result, currencies)
+	return result
+}
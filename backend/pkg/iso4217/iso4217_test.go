@@ -0,0 +1,28 @@
+package iso4217
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLookup(t *testing.T) {
+	currency, ok := Lookup("eur")
+	require.True(t, ok)
+	assert.Equal(t, Currency{Code: "EUR", Numeric: "978", Name: "Euro"}, currency)
+
+	currency, ok = Lookup(" PLN ")
+	require.True(t, ok)
+	assert.Equal(t, "985", currency.Numeric)
+
+	_, ok = Lookup("ABC")
+	assert.False(t, ok)
+}
+
+func TestAll(t *testing.T) {
+	all := All()
+	assert.Greater(t, len(all), 150)
+	assert.True(t, sort.SliceIsSorted(all, func(i, j int) bool { return all[i].Code < all[j].Code }))
+}
@@ -0,0 +1,49 @@
+// Package lei validates Legal Entity Identifiers (ISO 17442)
+package lei
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Hbrtjm/SWIFT_API/backend/pkg/iban"
+)
+
+var (
+	ErrInvalidLength    = errors.New("LEI must have 20 characters")
+	ErrIllegalCharacter = errors.New("LEI contains illegal characters")
+	ErrInvalidChecksum  = errors.New("invalid LEI check digits")
+)
+
+// Length of a LEI, 18 alphanumerics followed by two check digits
+const Length = 20
+
+// Normalize uppercases the LEI and removes the surrounding whitespace
+func Normalize(value string) string {
+	return strings.ToUpper(strings.TrimSpace(value))
+}
+
+// Validate checks the format and the ISO 7064 mod 97-10 check digits of the LEI
+func Validate(value string) (string, error) {
+	value = Normalize(value)
+	if len(value) != Length {
+		return "", fmt.Errorf("%w, got %d", ErrInvalidLength, len(value))
+	}
+	for i, r := range value {
+		digit := r >= '0' && r <= '9'
+		letter := r >= 'A' && r <= 'Z'
+		// The check digits are always numeric
+		if !digit && (!letter || i >= Length-2) {
+			return "", ErrIllegalCharacter
+		}
+	}
+	if iban.Mod97(value) != 1 {
+		return "", ErrInvalidChecksum
+	}
+	return value, nil
+}
+
+// CheckDigits computes the check digits of the first 18 characters of a LEI
+func CheckDigits(prefix string) string {
+	return fmt.Sprintf("%02d", 98-iban.Mod97(Normalize(prefix)+"00"))
+}
@@ -0,0 +1,38 @@
+package lei
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected error
+	}{
+		{"5493001KJTIIGC8Y1R12", nil},
+		{" 7lTWFZYICNSX8D621K86 ", nil},
+		{"5493001KJTIIGC8Y1R1", ErrInvalidLength},
+		{"5493001KJTIIGC8Y1R1A", ErrIllegalCharacter},
+		{"5493001KJTIIGC8Y_R12", ErrIllegalCharacter},
+		{"5493001KJTIIGC8Y1R13", ErrInvalidChecksum},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			_, err := Validate(tt.value)
+			if tt.expected == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.True(t, errors.Is(err, tt.expected), "got %v", err)
+		})
+	}
+}
+
+func TestCheckDigits(t *testing.T) {
+	assert.Equal(t, "12", CheckDigits("5493001KJTIIGC8Y1R"))
+	assert.Equal(t, "86", CheckDigits("7LTWFZYICNSX8D621K"))
+}
@@ -0,0 +1,331 @@
+// Package mt parses the SWIFT MT (FIN) messages, such as MT103 and MT202, and extracts the BICs of the header blocks
+// and of the institution fields. The structural problems of a message are reported along with it, so that a file
+// with a few broken messages can still be checked
+package mt
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+var ErrMalformedMessage = errors.New("malformed FIN message")
+
+// Directions of the message, seen from the SWIFT network
+const (
+	DirectionInput  = "input"
+	DirectionOutput = "output"
+)
+
+// Roles of the BICs
+const (
+	RoleSender                 = "sender"
+	RoleReceiver               = "receiver"
+	RoleOrderingInstitution    = "orderingInstitution"
+	RoleIntermediary           = "intermediary"
+	RoleAccountWithInstitution = "accountWithInstitution"
+	RoleBeneficiaryInstitution = "beneficiaryInstitution"
+)
+
+// institutionFields are the option A fields identifying the institutions by their BICs
+var institutionFields = map[string]string{
+	"52A": RoleOrderingInstitution,
+	"56A": RoleIntermediary,
+	"57A": RoleAccountWithInstitution,
+	"58A": RoleBeneficiaryInstitution,
+}
+
+// mandatoryFields of the text block per message type, each entry lists the options of a field
+var mandatoryFields = map[string][][]string{
+	"103": {{"20"}, {"23B"}, {"32A"}, {"50A", "50F", "50K"}, {"59", "59A", "59F"}, {"71A"}},
+	"202": {{"20"}, {"21"}, {"32A"}, {"58A", "58D"}},
+}
+
+var (
+	// F01, the logical terminal address, the session and the sequence numbers
+	basicHeaderPattern = regexp.MustCompile(`^F01([A-Z0-9]{12})(\d{4})(\d{6})$`)
+	// I, the message type, the receiver's logical terminal address and the optional priority and delivery options
+	inputHeaderPattern = regexp.MustCompile(`^I(\d{3})([A-Z0-9]{12})([SUN](\d(\d{3})?)?)?$`)
+	// O, the message type, the input time, the message input reference with the sender's logical terminal address,
+	// the output date and time and the optional priority
+	outputHeaderPattern = regexp.MustCompile(`^O(\d{3})(\d{4})(\d{6})([A-Z0-9]{12})(\d{4})(\d{6})(\d{6})(\d{4})([SUN])?$`)
+	fieldPattern        = regexp.MustCompile(`^:(\d{2}[A-Z]?):(.*)$`)
+)
+
+// Field is a field of the text block
+type Field struct {
+	Tag   string `json:"tag"`
+	Value string `json:"value"`
+}
+
+// BICReference is a BIC found in the message
+type BICReference struct {
+	// Block holding the BIC: 1 and 2 are the headers, 4 the text
+	Block string `json:"block"`
+	// Tag of the field in the text block
+	Tag  string `json:"tag,omitempty"`
+	Role string `json:"role"`
+	BIC  string `json:"bic"`
+}
+
+// Message is a parsed FIN message
+type Message struct {
+	// 1-based position in the file
+	Index int `json:"index"`
+	// MT type without the prefix, e.g. 103
+	Type      string `json:"type"`
+	Direction string `json:"direction"`
+	// Transaction reference number, field 20
+	Reference string         `json:"reference,omitempty"`
+	Fields    []Field        `json:"fields"`
+	BICs      []BICReference `json:"bics"`
+	// Structural problems of the message
+	Issues []string `json:"issues"`
+
+	blocks map[string]string
+}
+
+// Scan reads the FIN messages one by one and calls the function with each of them. The messages follow each other,
+// optionally separated with whitespace, $ or the SOH and ETX characters of the RJE files. A stream which isn't made
+// of blocks fails with ErrMalformedMessage, the errors of the function stop the scan and are returned as they are
+func Scan(r io.Reader, fn func(Message) error) error {
+	reader := bufio.NewReader(r)
+	var current *Message
+	index := 0
+
+	for {
+		c, err := skipSeparators(reader)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrMalformedMessage, err)
+		}
+		if c != '{' {
+			return fmt.Errorf("%w: unexpected %q between the blocks of message %d", ErrMalformedMessage, c, index)
+		}
+
+		id, err := readBlockID(reader)
+		if err != nil {
+			return err
+		}
+		content, err := readBlock(reader, id)
+		if err != nil {
+			return err
+		}
+
+		// The basic header starts the next message
+		if current == nil || id == "1" {
+			if current != nil {
+				if err := fn(current.parse()); err != nil {
+					return err
+				}
+			}
+			index++
+			current = &Message{Index: index, blocks: map[string]string{}}
+		}
+		if _, ok := current.blocks[id]; ok {
+			current.Issues = append(current.Issues, fmt.Sprintf("block %s is repeated", id))
+			continue
+		}
+		current.blocks[id] = content
+	}
+
+	if current == nil {
+		return fmt.Errorf("%w: no messages", ErrMalformedMessage)
+	}
+	return fn(current.parse())
+}
+
+func skipSeparators(reader *bufio.Reader) (byte, error) {
+	for {
+		c, err := reader.ReadByte()
+		if err != nil {
+			return 0, err
+		}
+		switch c {
+		case ' ', '\t', '\r', '\n', '$', '\x01', '\x03':
+			continue
+		}
+		return c, nil
+	}
+}
+
+// readBlockID reads the identifier of the block up to the colon: 1 to 5, or S for the system trailer
+func readBlockID(reader *bufio.Reader) (string, error) {
+	id := []byte{}
+	for {
+		c, err := reader.ReadByte()
+		if err != nil {
+			return "", fmt.Errorf("%w: unterminated block identifier", ErrMalformedMessage)
+		}
+		if c == ':' {
+			break
+		}
+		id = append(id, c)
+		if len(id) > 3 {
+			return "", fmt.Errorf("%w: malformed block identifier %q", ErrMalformedMessage, string(id))
+		}
+	}
+	if len(id) == 0 {
+		return "", fmt.Errorf("%w: empty block identifier", ErrMalformedMessage)
+	}
+	return string(id), nil
+}
+
+// readBlock reads the content of the block up to its closing brace. The text block ends with "-}" at the start of a
+// line, the other blocks contain nested {tag:value} fields
+func readBlock(reader *bufio.Reader, id string) (string, error) {
+	content := []byte{}
+	depth := 1
+	for {
+		c, err := reader.ReadByte()
+		if err != nil {
+			return "", fmt.Errorf("%w: block %s is not terminated", ErrMalformedMessage, id)
+		}
+
+		if id == "4" {
+			n := len(content)
+			if c == '}' && n > 0 && content[n-1] == '-' && (n == 1 || content[n-2] == '\n') {
+				return string(content[:n-1]), nil
+			}
+			content = append(content, c)
+			continue
+		}
+
+		switch c {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return string(content), nil
+			}
+		}
+		content = append(content, c)
+	}
+}
+
+// parse decodes the blocks and checks the structure of the message
+func (m Message) parse() Message {
+	m.Fields = []Field{}
+	m.BICs = []BICReference{}
+	if m.Issues == nil {
+		m.Issues = []string{}
+	}
+
+	basicTerminal := ""
+	if basicHeader, ok := m.blocks["1"]; !ok {
+		m.Issues = append(m.Issues, "basic header block 1 is missing")
+	} else if match := basicHeaderPattern.FindStringSubmatch(basicHeader); match != nil {
+		basicTerminal = match[1]
+	} else {
+		m.Issues = append(m.Issues, fmt.Sprintf("malformed basic header %q", basicHeader))
+	}
+
+	applicationHeader, ok := m.blocks["2"]
+	if !ok {
+		m.Issues = append(m.Issues, "application header block 2 is missing")
+	} else if match := inputHeaderPattern.FindStringSubmatch(applicationHeader); match != nil {
+		m.Type, m.Direction = match[1], DirectionInput
+		m.addHeaderBIC("1", RoleSender, basicTerminal)
+		m.addHeaderBIC("2", RoleReceiver, match[2])
+	} else if match := outputHeaderPattern.FindStringSubmatch(applicationHeader); match != nil {
+		m.Type, m.Direction = match[1], DirectionOutput
+		m.addHeaderBIC("1", RoleReceiver, basicTerminal)
+		m.addHeaderBIC("2", RoleSender, match[4])
+	} else {
+		m.Issues = append(m.Issues, fmt.Sprintf("malformed application header %q", applicationHeader))
+	}
+
+	text, ok := m.blocks["4"]
+	if !ok {
+		m.Issues = append(m.Issues, "text block 4 is missing")
+		return m
+	}
+	m.parseText(text)
+	m.checkMandatoryFields()
+	return m
+}
+
+// addHeaderBIC adds the BIC of the logical terminal address, which is the BIC8, the terminal code and the branch code
+func (m *Message) addHeaderBIC(block, role, terminal string) {
+	if len(terminal) != 12 {
+		return
+	}
+	m.BICs = append(m.BICs, BICReference{Block: block, Role: role, BIC: terminal[:8] + terminal[9:]})
+}
+
+func (m *Message) parseText(text string) {
+	text = strings.TrimSuffix(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		if match := fieldPattern.FindStringSubmatch(line); match != nil {
+			m.Fields = append(m.Fields, Field{Tag: match[1], Value: match[2]})
+			continue
+		}
+		if n := len(m.Fields); n > 0 {
+			m.Fields[n-1].Value += "\n" + line
+			continue
+		}
+		// The text block starts with a line break
+		if i > 0 || line != "" {
+			m.Issues = append(m.Issues, fmt.Sprintf("text %q before the first field", line))
+		}
+	}
+
+	for _, field := range m.Fields {
+		if field.Tag == "20" {
+			m.Reference = field.Value
+			if strings.HasPrefix(field.Value, "/") || strings.HasSuffix(field.Value, "/") || strings.Contains(field.Value, "//") {
+				m.Issues = append(m.Issues, "field 20 cannot start or end with a slash or contain two consecutive slashes")
+			}
+		}
+
+		role, ok := institutionFields[field.Tag]
+		if !ok {
+			continue
+		}
+		// The optional party identifier (/account or /C/account) is on the line before the BIC
+		lines := strings.Split(field.Value, "\n")
+		if strings.HasPrefix(lines[0], "/") {
+			lines = lines[1:]
+		}
+		if len(lines) == 0 || strings.TrimSpace(lines[0]) == "" {
+			m.Issues = append(m.Issues, fmt.Sprintf("field %s has no BIC", field.Tag))
+			continue
+		}
+		if len(lines) > 1 {
+			m.Issues = append(m.Issues, fmt.Sprintf("field %s has text after the BIC", field.Tag))
+		}
+		m.BICs = append(m.BICs, BICReference{Block: "4", Tag: field.Tag, Role: role, BIC: strings.TrimSpace(lines[0])})
+	}
+}
+
+func (m *Message) checkMandatoryFields() {
+	mandatory, ok := mandatoryFields[m.Type]
+	if !ok {
+		return
+	}
+	present := map[string]bool{}
+	for _, field := range m.Fields {
+		present[field.Tag] = true
+	}
+	for _, options := range mandatory {
+		found := false
+		for _, tag := range options {
+			found = found || present[tag]
+		}
+		if !found {
+			name := options[0]
+			if len(options) > 1 {
+				// The options are written with a lowercase letter, e.g. 50a
+				name = options[0][:2] + "a"
+			}
+			m.Issues = append(m.Issues, fmt.Sprintf("mandatory field %s is missing", name))
+		}
+	}
+}
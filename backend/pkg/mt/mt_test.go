@@ -0,0 +1,150 @@
+package mt
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const mt103 = "{1:F01BANKBEBBAXXX0000000000}{2:I103BANKDEFFXXXXN}{3:{108:MT103 001}{121:eb6305c9-1f7f-49de-aed0-16487c27b42d}}{4:\r\n" +
+	":20:REF-123\r\n" +
+	":23B:CRED\r\n" +
+	":32A:250102EUR1000,00\r\n" +
+	":50K:/BE68539007547034\r\n" +
+	"JOHN DOE\r\n" +
+	"MAIN STREET 1\r\n" +
+	":52A:/D/12345\r\n" +
+	"BANKBEBB\r\n" +
+	":57A:BANKDEFFXXX\r\n" +
+	":59:/DE89370400440532013000\r\n" +
+	"JANE DOE\r\n" +
+	":71A:SHA\r\n" +
+	"-}{5:{CHK:123456789ABC}}"
+
+const mt202 = "{1:F01BANKDEFFAXXX0000000000}{2:O2021200250102BANKBEBBAXXX00000000002501021200N}{4:\n" +
+	":20:REF-456\n" +
+	":21:REL-1\n" +
+	":32A:250102EUR1000,00\n" +
+	":56A:INTEFRPP\n" +
+	":58A:/987654\n" +
+	"BENEFRPPXXX\n" +
+	"-}"
+
+func scanAll(t *testing.T, input string) []Message {
+	t.Helper()
+	messages := []Message{}
+	err := Scan(strings.NewReader(input), func(message Message) error {
+		messages = append(messages, message)
+		return nil
+	})
+	require.NoError(t, err)
+	return messages
+}
+
+func TestScanMT103(t *testing.T) {
+	messages := scanAll(t, mt103)
+	require.Len(t, messages, 1)
+	message := messages[0]
+
+	assert.Equal(t, 1, message.Index)
+	assert.Equal(t, "103", message.Type)
+	assert.Equal(t, DirectionInput, message.Direction)
+	assert.Equal(t, "REF-123", message.Reference)
+	assert.Empty(t, message.Issues)
+	assert.Equal(t, []BICReference{
+		{Block: "1", Role: RoleSender, BIC: "BANKBEBBXXX"},
+		{Block: "2", Role: RoleReceiver, BIC: "BANKDEFFXXX"},
+		{Block: "4", Tag: "52A", Role: RoleOrderingInstitution, BIC: "BANKBEBB"},
+		{Block: "4", Tag: "57A", Role: RoleAccountWithInstitution, BIC: "BANKDEFFXXX"},
+	}, message.BICs)
+
+	require.Len(t, message.Fields, 8)
+	assert.Equal(t, Field{Tag: "50K", Value: "/BE68539007547034\nJOHN DOE\nMAIN STREET 1"}, message.Fields[3])
+	assert.Equal(t, Field{Tag: "71A", Value: "SHA"}, message.Fields[7])
+}
+
+func TestScanSeveralMessages(t *testing.T) {
+	// The output message has the receiver in the basic header and the sender in the input reference
+	messages := scanAll(t, mt103+"\r\n$"+mt202+"\n")
+	require.Len(t, messages, 2)
+
+	message := messages[1]
+	assert.Equal(t, 2, message.Index)
+	assert.Equal(t, "202", message.Type)
+	assert.Equal(t, DirectionOutput, message.Direction)
+	assert.Empty(t, message.Issues)
+	assert.Equal(t, []BICReference{
+		{Block: "1", Role: RoleReceiver, BIC: "BANKDEFFXXX"},
+		{Block: "2", Role: RoleSender, BIC: "BANKBEBBXXX"},
+		{Block: "4", Tag: "56A", Role: RoleIntermediary, BIC: "INTEFRPP"},
+		{Block: "4", Tag: "58A", Role: RoleBeneficiaryInstitution, BIC: "BENEFRPPXXX"},
+	}, message.BICs)
+}
+
+func TestScanStructuralIssues(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		issues []string
+	}{
+		{
+			name:   "Missing mandatory fields",
+			input:  "{1:F01BANKBEBBAXXX0000000000}{2:I202BANKDEFFXXXXN}{4:\n:20:/REF\n:32A:250102EUR1,00\n-}",
+			issues: []string{"field 20 cannot start or end with a slash or contain two consecutive slashes", "mandatory field 21 is missing", "mandatory field 58a is missing"},
+		},
+		{
+			name:   "Malformed headers",
+			input:  "{1:F01BANKBEBB}{2:X103}{4:\n:20:REF\n-}",
+			issues: []string{"malformed basic header \"F01BANKBEBB\"", "malformed application header \"X103\""},
+		},
+		{
+			name:   "Missing blocks",
+			input:  "{1:F01BANKBEBBAXXX0000000000}",
+			issues: []string{"application header block 2 is missing", "text block 4 is missing"},
+		},
+		{
+			name:   "Institution field without a BIC",
+			input:  "{1:F01BANKBEBBAXXX0000000000}{2:I999BANKDEFFXXXXN}{4:\n:57A:/12345\n-}",
+			issues: []string{"field 57A has no BIC"},
+		},
+		{
+			name:   "Text before the fields",
+			input:  "{1:F01BANKBEBBAXXX0000000000}{2:I999BANKDEFFXXXXN}{4:\nHELLO\n:20:REF\n-}",
+			issues: []string{"text \"HELLO\" before the first field"},
+		},
+		{
+			name:   "Repeated block",
+			input:  "{1:F01BANKBEBBAXXX0000000000}{2:I999BANKDEFFXXXXN}{2:I999BANKDEFFXXXXN}{4:\n:20:REF\n-}",
+			issues: []string{"block 2 is repeated"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			messages := scanAll(t, tt.input)
+			require.Len(t, messages, 1)
+			assert.Equal(t, tt.issues, messages[0].Issues)
+		})
+	}
+}
+
+func TestScanErrors(t *testing.T) {
+	noop := func(Message) error { return nil }
+
+	assert.ErrorIs(t, Scan(strings.NewReader(""), noop), ErrMalformedMessage)
+	assert.ErrorIs(t, Scan(strings.NewReader("MT103 SENDER BANKBEBB"), noop), ErrMalformedMessage)
+	assert.ErrorIs(t, Scan(strings.NewReader("{1:F01BANKBEBBAXXX0000000000}{4:\n:20:REF\n"), noop), ErrMalformedMessage)
+	assert.ErrorIs(t, Scan(strings.NewReader("{1234:X}"), noop), ErrMalformedMessage)
+
+	stop := errors.New("stop")
+	count := 0
+	err := Scan(strings.NewReader(mt103+mt202), func(Message) error {
+		count++
+		return stop
+	})
+	assert.ErrorIs(t, err, stop)
+	assert.Equal(t, 1, count)
+}
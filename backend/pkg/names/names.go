@@ -0,0 +1,320 @@
+// Package names compares the names of the institutions as they're written in the payment instructions with the
+// directory. The names are transliterated to the Latin alphabet, the legal forms are reduced to their abbreviations
+// ("SPOLKA AKCYJNA", "S.A." and "SA" are all SA) and the tokens are matched with the Jaro-Winkler similarity
+package names
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Kinds of the tokens, which weigh differently in the comparison
+const (
+	// A word identifying the institution
+	KindWord = "word"
+	// A word shared by many institutions, such as BANK
+	KindGeneric = "generic"
+	// A legal form, reduced to its abbreviation
+	KindLegalForm = "legalForm"
+)
+
+// Token is a word of a name
+type Token struct {
+	// Transliterated, uppercase and without the punctuation
+	Text string `json:"text"`
+	// Words of the name the token comes from, the legal forms span several words
+	Original string `json:"original"`
+	Kind     string `json:"kind"`
+}
+
+// Name is a parsed name
+type Name struct {
+	Original string
+	// Tokens joined with single spaces
+	Normalized string
+	Tokens     []Token
+}
+
+// IsEmpty checks whether the name has no tokens
+func (n Name) IsEmpty() bool {
+	return len(n.Tokens) == 0
+}
+
+// legalForms maps the spelled out legal forms and the abbreviations split by Normalize (S.A. written as "S. A.") to
+// the abbreviations
+var legalForms = map[string]string{
+	"SA":                                    "SA",
+	"S A":                                   "SA",
+	"SPOLKA AKCYJNA":                        "SA",
+	"SOCIEDAD ANONIMA":                      "SA",
+	"SOCIETE ANONYME":                       "SA",
+	"SOCIEDADE ANONIMA":                     "SA",
+	"SOCIETATEA PE ACTIUNI":                 "SA",
+	"AG":                                    "AG",
+	"AKTIENGESELLSCHAFT":                    "AG",
+	"GMBH":                                  "GMBH",
+	"GESELLSCHAFT MIT BESCHRANKTER HAFTUNG": "GMBH",
+	"SPA":                                   "SPA",
+	"S P A":                                 "SPA",
+	"SOCIETA PER AZIONI":                    "SPA",
+	"NV":                                    "NV",
+	"N V":                                   "NV",
+	"NAAMLOZE VENNOOTSCHAP":                 "NV",
+	"BV":                                    "BV",
+	"B V":                                   "BV",
+	"BESLOTEN VENNOOTSCHAP":                 "BV",
+	"AS":                                    "AS",
+	"A S":                                   "AS",
+	"AKCIOVA SPOLECNOST":                    "AS",
+	"AKCIOVA SPOLOCNOST":                    "AS",
+	"AKSJESELSKAP":                          "AS",
+	"AKTIESELSKAB":                          "AS",
+	"AB":                                    "AB",
+	"AKTIEBOLAG":                            "AB",
+	"OYJ":                                   "OYJ",
+	"JULKINEN OSAKEYHTIO":                   "OYJ",
+	"PLC":                                   "PLC",
+	"PUBLIC LIMITED COMPANY":                "PLC",
+	"LTD":                                   "LTD",
+	"LIMITED":                               "LTD",
+	"LLC":                                   "LLC",
+	"LIMITED LIABILITY COMPANY":             "LLC",
+	"INC":                                   "INC",
+	"INCORPORATED":                          "INC",
+	"CORP":                                  "CORP",
+	"CORPORATION":                           "CORP",
+	"CO":                                    "CO",
+	"COMPANY":                               "CO",
+	"SPZOO":                                 "SPZOO",
+	"SP Z O O":                              "SPZOO",
+	"SP Z OO":                               "SPZOO",
+	"SP ZOO":                                "SPZOO",
+	"SPOLKA Z OGRANICZONA ODPOWIEDZIALNOSCIA": "SPZOO",
+	"SARL":                              "SARL",
+	"SOCIETE A RESPONSABILITE LIMITEE":  "SARL",
+	"SRL":                               "SRL",
+	"SOCIETA A RESPONSABILITA LIMITATA": "SRL",
+	"PJSC":                              "PJSC",
+	"PUBLIC JOINT STOCK COMPANY":        "PJSC",
+	"JSC":                               "JSC",
+	"JOINT STOCK COMPANY":               "JSC",
+	"OAO":                               "OAO",
+	"ZAO":                               "ZAO",
+	"OOO":                               "OOO",
+}
+
+// maxLegalFormWords is the length of the longest spelled out legal form
+const maxLegalFormWords = 4
+
+// genericWords are shared by many institutions and tell little about the match
+var genericWords = map[string]bool{
+	"BANK": true, "BANCO": true, "BANCA": true, "BANQUE": true, "BANC": true, "BANKA": true, "BANKI": true,
+	"BANKASI": true, "BANKEN": true, "BANKU": true, "BANKOVE": true, "SPARKASSE": true, "GROUP": true, "HOLDING": true,
+	"INTERNATIONAL": true, "NATIONAL": true, "COMMERCIAL": true, "TRUST": true, "SAVINGS": true, "FINANCE": true,
+	"FINANCIAL": true, "CAPITAL": true, "INVESTMENT": true, "BRANCH": true,
+}
+
+// stopWords are dropped from the names
+var stopWords = map[string]bool{
+	"THE": true, "OF": true, "AND": true, "DE": true, "DU": true, "DES": true, "DEL": true, "DELLA": true, "DI": true,
+	"DER": true, "DIE": true, "DAS": true, "LA": true, "LE": true, "LES": true, "ET": true, "Y": true, "E": true,
+	"UND": true, "I": true,
+}
+
+// Parse normalizes the name and splits it into tokens
+func Parse(name string) Name {
+	words := split(name)
+	parsed := Name{Original: name, Tokens: []Token{}}
+
+	for i := 0; i < len(words); {
+		if length, abbreviation := legalFormAt(words[i:]); length > 0 {
+			original := name[words[i].start:words[i+length-1].end]
+			parsed.Tokens = append(parsed.Tokens, Token{Text: abbreviation, Original: original, Kind: KindLegalForm})
+			i += length
+			continue
+		}
+		word := words[i]
+		i++
+		token := Token{Text: word.text, Original: name[word.start:word.end], Kind: KindWord}
+		switch {
+		case stopWords[word.text]:
+			continue
+		case genericWords[word.text]:
+			token.Kind = KindGeneric
+		}
+		parsed.Tokens = append(parsed.Tokens, token)
+	}
+
+	texts := make([]string, len(parsed.Tokens))
+	for i, token := range parsed.Tokens {
+		texts[i] = token.Text
+	}
+	parsed.Normalized = strings.Join(texts, " ")
+	return parsed
+}
+
+// PrefixLength is the number of leading letters of the words the names are looked up by
+const PrefixLength = 3
+
+// Prefix is the beginning of a token the names are looked up by
+func Prefix(text string) string {
+	letters := []rune(text)
+	return string(letters[:min(len(letters), PrefixLength)])
+}
+
+// Prefixes lists the distinct prefixes of every token of the names, stored with the directory entries so that they
+// can be looked up by any of their words
+func Prefixes(names ...string) []string {
+	seen := map[string]bool{}
+	prefixes := []string{}
+	for _, name := range names {
+		for _, token := range Parse(name).Tokens {
+			if prefix := Prefix(token.Text); !seen[prefix] {
+				seen[prefix] = true
+				prefixes = append(prefixes, prefix)
+			}
+		}
+	}
+	return prefixes
+}
+
+// legalFormAt finds the longest legal form starting with the first word
+func legalFormAt(words []word) (int, string) {
+	for length := min(len(words), maxLegalFormWords); length > 0; length-- {
+		texts := make([]string, length)
+		for i := range texts {
+			texts[i] = words[i].text
+		}
+		if abbreviation, ok := legalForms[strings.Join(texts, " ")]; ok {
+			return length, abbreviation
+		}
+	}
+	return 0, ""
+}
+
+// Normalize transliterates the name, uppercases it and keeps only the letters, the digits and single spaces. The dots
+// of the abbreviations are dropped ("S.A." is SA), the other punctuation separates the words
+func Normalize(name string) string {
+	words := split(name)
+	texts := make([]string, len(words))
+	for i, word := range words {
+		texts[i] = word.text
+	}
+	return strings.Join(texts, " ")
+}
+
+// word is a normalized word with the byte offsets of its original text
+type word struct {
+	text       string
+	start, end int
+}
+
+var foldAccents = transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+
+// split normalizes the name word by word
+func split(name string) []word {
+	words := []word{}
+	var builder strings.Builder
+	start, end := 0, 0
+	// Runes of the word since the last dot
+	segmentLength := 0
+
+	closeWord := func() {
+		if builder.Len() > 0 {
+			words = append(words, word{text: builder.String(), start: start, end: end})
+			builder.Reset()
+		}
+		segmentLength = 0
+	}
+
+	for i, r := range name {
+		size := utf8.RuneLen(r)
+		if unicode.Is(unicode.Mn, r) {
+			// The combining accents of the decomposed text
+			if builder.Len() > 0 {
+				end = i + size
+			}
+			continue
+		}
+
+		latin, ok := transliterate(r)
+
+		switch {
+		case latin != "":
+			if builder.Len() == 0 {
+				start = i
+			}
+			builder.WriteString(latin)
+			end = i + size
+			segmentLength++
+		case ok:
+			// The hard and the soft signs are dropped
+			if builder.Len() > 0 {
+				end = i + size
+			}
+		case r == '.' && segmentLength == 1:
+			// A single letter followed by a dot is a part of an abbreviation
+			end = i + size
+			segmentLength = 0
+		default:
+			closeWord()
+		}
+	}
+	closeWord()
+	return words
+}
+
+// transliterate gives the uppercase Latin letters of a letter or a digit, and whether the rune is a letter at all
+func transliterate(r rune) (string, bool) {
+	if latin, ok := transliterations[unicode.ToUpper(r)]; ok {
+		return latin, true
+	}
+	if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+		return "", false
+	}
+	folded, _, err := transform.String(foldAccents, string(unicode.ToUpper(r)))
+	if err != nil || folded == "" {
+		return string(unicode.ToUpper(r)), true
+	}
+	var builder strings.Builder
+	for _, f := range folded {
+		if latin, ok := transliterations[f]; ok {
+			builder.WriteString(latin)
+		} else {
+			builder.WriteRune(f)
+		}
+	}
+	return builder.String(), true
+}
+
+// transliterateText transliterates the letters and keeps the rest of the text, the punctuation included
+func transliterateText(text string) string {
+	var builder strings.Builder
+	for _, r := range text {
+		if latin, ok := transliterate(r); ok {
+			builder.WriteString(latin)
+		} else if !unicode.Is(unicode.Mn, r) {
+			builder.WriteRune(r)
+		}
+	}
+	return builder.String()
+}
+
+// transliterations of the letters which don't decompose into a Latin letter and accents, the uppercase Cyrillic and
+// Greek letters follow the romanizations of the passports. The letters with diacritics, such as Й, are decomposed
+// before
+var transliterations = map[rune]string{
+	'Ł': "L", 'Đ': "D", 'Ø': "O", 'ß': "SS", 'ẞ': "SS", 'Æ': "AE", 'Œ': "OE", 'Þ': "TH", 'Ð': "D", 'Ħ': "H",
+	'А': "A", 'Б': "B", 'В': "V", 'Г': "G", 'Ґ': "G", 'Д': "D", 'Е': "E", 'Є': "YE", 'Ж': "ZH", 'З': "Z", 'И': "I",
+	'І': "I", 'К': "K", 'Л': "L", 'М': "M", 'Н': "N", 'О': "O", 'П': "P", 'Р': "R", 'С': "S",
+	'Т': "T", 'У': "U", 'Ф': "F", 'Х': "KH", 'Ц': "TS", 'Ч': "CH", 'Ш': "SH", 'Щ': "SHCH", 'Ъ': "", 'Ы': "Y", 'Ь': "",
+	'Э': "E", 'Ю': "YU", 'Я': "YA", 'Ј': "J", 'Љ': "LJ", 'Њ': "NJ", 'Ћ': "C", 'Џ': "DZ", 'Ђ': "D",
+	'Α': "A", 'Β': "V", 'Γ': "G", 'Δ': "D", 'Ε': "E", 'Ζ': "Z", 'Η': "I", 'Θ': "TH", 'Ι': "I", 'Κ': "K", 'Λ': "L",
+	'Μ': "M", 'Ν': "N", 'Ξ': "X", 'Ο': "O", 'Π': "P", 'Ρ': "R", 'Σ': "S", 'Τ': "T", 'Υ': "Y", 'Φ': "F", 'Χ': "CH",
+	'Ψ': "PS", 'Ω': "O",
+}
@@ -0,0 +1,118 @@
+package names
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{name: "Punctuation and spaces", input: "  Pekao  TFI, (Warsaw) ", expected: "PEKAO TFI WARSAW"},
+		{name: "Dotted abbreviation", input: "Bank Handlowy w Warszawie S.A.", expected: "BANK HANDLOWY W WARSZAWIE SA"},
+		{name: "Dot after a word", input: "St.Gallen Kantonalbank", expected: "ST GALLEN KANTONALBANK"},
+		{name: "Polish letters", input: "Spółdzielczy Bank Łódź", expected: "SPOLDZIELCZY BANK LODZ"},
+		{name: "German letters", input: "Großbank München", expected: "GROSSBANK MUNCHEN"},
+		{name: "Cyrillic", input: "Сбербанк России", expected: "SBERBANK ROSSII"},
+		{name: "Greek", input: "Τράπεζα Πειραιώς", expected: "TRAPEZA PEIRAIOS"},
+		{name: "Decomposed accents", input: "Societé Générale", expected: "SOCIETE GENERALE"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Normalize(tt.input))
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	name := Parse("The Bank of Pekao Spółka Akcyjna")
+	assert.Equal(t, "BANK PEKAO SA", name.Normalized)
+	assert.Equal(t, []Token{
+		{Text: "BANK", Original: "Bank", Kind: KindGeneric},
+		{Text: "PEKAO", Original: "Pekao", Kind: KindWord},
+		{Text: "SA", Original: "Spółka Akcyjna", Kind: KindLegalForm},
+	}, name.Tokens)
+
+	assert.Equal(t, "PKO SA", Parse("PKO S. A.").Normalized)
+	assert.Equal(t, "ALIOR SPZOO", Parse("Alior sp. z o.o.").Normalized)
+	assert.True(t, Parse(" - . ").IsEmpty())
+}
+
+func TestPrefixes(t *testing.T) {
+	assert.Equal(t, "SBE", Prefix("SBERBANK"))
+	assert.Equal(t, "SA", Prefix("SA"))
+	// Every word once, the stop words left out
+	assert.Equal(t, []string{"BAN", "POL", "SA", "WAR"}, Prefixes("Bank Polski S.A.", "Warszawa", "BANK OF POLAND"))
+}
+
+func TestCompare(t *testing.T) {
+	t.Run("Abbreviated legal form", func(t *testing.T) {
+		comparison := Compare(Parse("Pekao TFI S.A."), Parse("PEKAO TFI SPOLKA AKCYJNA"))
+		assert.Equal(t, 1.0, comparison.Score)
+		assert.Equal(t, 1.0, comparison.Coverage)
+		require.Len(t, comparison.Matches, 3)
+		assert.Equal(t, TokenMatch{Query: "Pekao", Candidate: "PEKAO", Similarity: 1, Reason: ReasonExact}, comparison.Matches[0])
+		assert.Equal(t, TokenMatch{Query: "S.A.", Candidate: "SPOLKA AKCYJNA", Similarity: 1, Reason: ReasonAbbreviation}, comparison.Matches[2])
+	})
+
+	t.Run("Transliteration", func(t *testing.T) {
+		comparison := Compare(Parse("Сбербанк"), Parse("SBERBANK"))
+		assert.Equal(t, 1.0, comparison.Score)
+		assert.Equal(t, []TokenMatch{{Query: "Сбербанк", Candidate: "SBERBANK", Similarity: 1, Reason: ReasonTransliteration}}, comparison.Matches)
+
+		// The legal form is only spelled with the accents
+		comparison = Compare(Parse("Spółka Akcyjna"), Parse("SPOLKA AKCYJNA"))
+		assert.Equal(t, ReasonTransliteration, comparison.Matches[0].Reason)
+	})
+
+	t.Run("Typo and extra tokens", func(t *testing.T) {
+		comparison := Compare(Parse("Peako"), Parse("PEKAO TOWARZYSTWO FUNDUSZY INWESTYCYJNYCH SA"))
+		assert.Equal(t, ReasonFuzzy, comparison.Matches[0].Reason)
+		assert.Equal(t, "PEKAO", comparison.Matches[0].Candidate)
+		assert.Greater(t, comparison.Coverage, 0.9)
+		assert.Less(t, comparison.Score, 0.5)
+		assert.Equal(t, TokenMatch{Candidate: "TOWARZYSTWO", Reason: ReasonExtra}, comparison.Matches[1])
+	})
+
+	t.Run("Acronym", func(t *testing.T) {
+		comparison := Compare(Parse("Pekao TFI"), Parse("PEKAO TOWARZYSTWO FUNDUSZY INWESTYCYJNYCH SA"))
+		assert.Equal(t, []TokenMatch{
+			{Query: "Pekao", Candidate: "PEKAO", Similarity: 1, Reason: ReasonExact},
+			{Query: "TFI", Candidate: "TOWARZYSTWO FUNDUSZY INWESTYCYJNYCH", Similarity: 1, Reason: ReasonAcronym},
+			{Candidate: "SA", Reason: ReasonExtra},
+		}, comparison.Matches)
+		assert.Equal(t, 1.0, comparison.Coverage)
+
+		// The other way round
+		comparison = Compare(Parse("Towarzystwo Funduszy Inwestycyjnych Pekao"), Parse("PEKAO TFI"))
+		assert.Equal(t, 1.0, comparison.Score)
+		assert.Equal(t, TokenMatch{Query: "Towarzystwo Funduszy Inwestycyjnych", Candidate: "TFI", Similarity: 1, Reason: ReasonAcronym}, comparison.Matches[0])
+	})
+
+	t.Run("Generic words weigh less", func(t *testing.T) {
+		generic := Compare(Parse("Alpha Bank"), Parse("ALPHA"))
+		distinctive := Compare(Parse("Alpha Beta"), Parse("ALPHA"))
+		assert.Greater(t, generic.Score, distinctive.Score)
+		assert.Equal(t, TokenMatch{Query: "Beta", Reason: ReasonUnmatched}, distinctive.Matches[1])
+	})
+
+	t.Run("Legal forms only match legal forms", func(t *testing.T) {
+		comparison := Compare(Parse("AG"), Parse("AGRO"))
+		assert.Equal(t, 0.0, comparison.Score)
+	})
+}
+
+func TestJaroWinkler(t *testing.T) {
+	assert.Equal(t, 1.0, JaroWinkler("WARSZAWA", "WARSZAWA"))
+	assert.Equal(t, 0.0, JaroWinkler("", "WARSZAWA"))
+	assert.Equal(t, 0.0, JaroWinkler("ABC", "XYZ"))
+	assert.InDelta(t, 0.961, JaroWinkler("MARTHA", "MARHTA"), 0.001)
+	assert.InDelta(t, 0.84, JaroWinkler("DWAYNE", "DUANE"), 0.001)
+	assert.Greater(t, JaroWinkler("WARSAW", "WARSZAWA"), MinTokenSimilarity)
+}
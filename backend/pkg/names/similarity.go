@@ -0,0 +1,286 @@
+package names
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Reasons of the token matches
+const (
+	ReasonExact = "exact"
+	// The same legal form written differently, e.g. "S.A." and "SPOLKA AKCYJNA"
+	ReasonAbbreviation = "abbreviation"
+	// The same word in another script or with accents, e.g. "Сбербанк" and "SBERBANK"
+	ReasonTransliteration = "transliteration"
+	// Similar words, e.g. a typo
+	ReasonFuzzy = "fuzzy"
+	// The initials of several words
+	ReasonAcronym = "acronym"
+	// A token of the query missing from the candidate
+	ReasonUnmatched = "unmatched"
+	// A token of the candidate missing from the query
+	ReasonExtra = "extra"
+)
+
+// MinTokenSimilarity is the Jaro-Winkler similarity from which two words match
+const MinTokenSimilarity = 0.85
+
+// kindWeights are the weights of the tokens in the score, the generic words and the legal forms tell little about the
+// institution
+var kindWeights = map[string]float64{
+	KindWord:      1,
+	KindGeneric:   0.4,
+	KindLegalForm: 0.3,
+}
+
+// TokenMatch explains how a token was matched
+type TokenMatch struct {
+	Query      string  `json:"query,omitempty"`
+	Candidate  string  `json:"candidate,omitempty"`
+	Similarity float64 `json:"similarity"`
+	Reason     string  `json:"reason"`
+}
+
+// Comparison is the outcome of comparing two names
+type Comparison struct {
+	// Weighted similarity of the tokens of both names, from 0 to 1
+	Score float64 `json:"score"`
+	// Weighted share of the query found in the candidate, from 0 to 1, for the queries made of fragments
+	Coverage float64 `json:"coverage"`
+	// The query tokens in order, followed by the extra tokens of the candidate
+	Matches []TokenMatch `json:"matches"`
+}
+
+type tokenPair struct {
+	query, candidate int
+	similarity       float64
+}
+
+// link matches one or more tokens of the query with one or more tokens of the candidate, the acronyms span several
+// tokens
+type link struct {
+	query, candidate []int
+	similarity       float64
+	reason           string
+}
+
+// Compare pairs the tokens of the names, the most similar first, then matches the acronyms with the remaining tokens
+// (TFI and TOWARZYSTWO FUNDUSZY INWESTYCYJNYCH). The similarities are weighed against all of the tokens. The legal
+// forms are only paired with the legal forms
+func Compare(query, candidate Name) Comparison {
+	pairs := []tokenPair{}
+	for i, q := range query.Tokens {
+		for j, c := range candidate.Tokens {
+			if (q.Kind == KindLegalForm) != (c.Kind == KindLegalForm) {
+				continue
+			}
+			similarity := 0.0
+			if q.Kind == KindLegalForm {
+				if q.Text == c.Text {
+					similarity = 1
+				}
+			} else {
+				similarity = JaroWinkler(q.Text, c.Text)
+			}
+			if similarity >= MinTokenSimilarity {
+				pairs = append(pairs, tokenPair{query: i, candidate: j, similarity: similarity})
+			}
+		}
+	}
+	// Stable, so that the ties go to the earlier tokens
+	sort.SliceStable(pairs, func(a, b int) bool { return pairs[a].similarity > pairs[b].similarity })
+
+	links := []link{}
+	queryLinked := make([]bool, len(query.Tokens))
+	candidateLinked := make([]bool, len(candidate.Tokens))
+	for _, pair := range pairs {
+		if queryLinked[pair.query] || candidateLinked[pair.candidate] {
+			continue
+		}
+		queryLinked[pair.query], candidateLinked[pair.candidate] = true, true
+		links = append(links, link{
+			query:      []int{pair.query},
+			candidate:  []int{pair.candidate},
+			similarity: pair.similarity,
+			reason:     reason(query.Tokens[pair.query], candidate.Tokens[pair.candidate], pair.similarity),
+		})
+	}
+	for i, token := range query.Tokens {
+		if span := acronymSpan(token, queryLinked[i], candidate.Tokens, candidateLinked); span != nil {
+			queryLinked[i] = true
+			links = append(links, link{query: []int{i}, candidate: span, similarity: 1, reason: ReasonAcronym})
+		}
+	}
+	for j, token := range candidate.Tokens {
+		if span := acronymSpan(token, candidateLinked[j], query.Tokens, queryLinked); span != nil {
+			candidateLinked[j] = true
+			links = append(links, link{query: span, candidate: []int{j}, similarity: 1, reason: ReasonAcronym})
+		}
+	}
+
+	queryWeight, candidateWeight := totalWeight(query.Tokens), totalWeight(candidate.Tokens)
+	matched, covered := 0.0, 0.0
+	// Links by their first query token
+	firstTokens := map[int]link{}
+	for _, l := range links {
+		linkedQueryWeight := weightOf(query.Tokens, l.query)
+		matched += l.similarity * (linkedQueryWeight + weightOf(candidate.Tokens, l.candidate))
+		covered += l.similarity * linkedQueryWeight
+		firstTokens[l.query[0]] = l
+	}
+
+	comparison := Comparison{Matches: []TokenMatch{}}
+	for i, token := range query.Tokens {
+		if l, ok := firstTokens[i]; ok {
+			comparison.Matches = append(comparison.Matches, TokenMatch{
+				Query:      originals(query.Tokens, l.query),
+				Candidate:  originals(candidate.Tokens, l.candidate),
+				Similarity: Round(l.similarity),
+				Reason:     l.reason,
+			})
+		} else if !queryLinked[i] {
+			comparison.Matches = append(comparison.Matches, TokenMatch{Query: token.Original, Reason: ReasonUnmatched})
+		}
+	}
+	for j, token := range candidate.Tokens {
+		if !candidateLinked[j] {
+			comparison.Matches = append(comparison.Matches, TokenMatch{Candidate: token.Original, Reason: ReasonExtra})
+		}
+	}
+
+	if total := queryWeight + candidateWeight; total > 0 {
+		comparison.Score = Round(matched / total)
+	}
+	if queryWeight > 0 {
+		comparison.Coverage = Round(covered / queryWeight)
+	}
+	return comparison
+}
+
+// acronymSpan finds the consecutive unlinked tokens, at least two, whose initials spell the token
+func acronymSpan(token Token, linked bool, tokens []Token, tokensLinked []bool) []int {
+	if linked || token.Kind == KindLegalForm || len(token.Text) < 2 || !isASCII(token.Text) {
+		return nil
+	}
+	for start := range tokens {
+		span := []int{}
+		for k := start; k < len(tokens) && len(span) < len(token.Text); k++ {
+			if tokensLinked[k] || tokens[k].Kind == KindLegalForm || tokens[k].Text[0] != token.Text[len(span)] {
+				break
+			}
+			span = append(span, k)
+		}
+		if len(span) == len(token.Text) {
+			for _, k := range span {
+				tokensLinked[k] = true
+			}
+			return span
+		}
+	}
+	return nil
+}
+
+func totalWeight(tokens []Token) float64 {
+	total := 0.0
+	for _, token := range tokens {
+		total += kindWeights[token.Kind]
+	}
+	return total
+}
+
+func weightOf(tokens []Token, indices []int) float64 {
+	total := 0.0
+	for _, i := range indices {
+		total += kindWeights[tokens[i].Kind]
+	}
+	return total
+}
+
+func originals(tokens []Token, indices []int) string {
+	texts := make([]string, len(indices))
+	for k, i := range indices {
+		texts[k] = tokens[i].Original
+	}
+	return strings.Join(texts, " ")
+}
+
+func reason(query, candidate Token, similarity float64) string {
+	switch {
+	case similarity < 1:
+		return ReasonFuzzy
+	case query.Kind == KindLegalForm && transliterateText(query.Original) != transliterateText(candidate.Original):
+		return ReasonAbbreviation
+	case !isASCII(query.Original) || !isASCII(candidate.Original):
+		return ReasonTransliteration
+	}
+	return ReasonExact
+}
+
+func isASCII(s string) bool {
+	for _, r := range s {
+		if r > unicode.MaxASCII {
+			return false
+		}
+	}
+	return true
+}
+
+// JaroWinkler gives the similarity of the strings from 0 to 1, favoring the strings with a common prefix
+func JaroWinkler(a, b string) float64 {
+	if a == b {
+		return 1
+	}
+	s, t := []rune(a), []rune(b)
+	if len(s) == 0 || len(t) == 0 {
+		return 0
+	}
+
+	window := max(len(s), len(t))/2 - 1
+	window = max(window, 0)
+	sMatched := make([]bool, len(s))
+	tMatched := make([]bool, len(t))
+	matches := 0
+	for i := range s {
+		for j := max(0, i-window); j < min(len(t), i+window+1); j++ {
+			if tMatched[j] || s[i] != t[j] {
+				continue
+			}
+			sMatched[i], tMatched[j] = true, true
+			matches++
+			break
+		}
+	}
+	if matches == 0 {
+		return 0
+	}
+
+	transpositions := 0
+	j := 0
+	for i := range s {
+		if !sMatched[i] {
+			continue
+		}
+		for !tMatched[j] {
+			j++
+		}
+		if s[i] != t[j] {
+			transpositions++
+		}
+		j++
+	}
+
+	m := float64(matches)
+	jaro := (m/float64(len(s)) + m/float64(len(t)) + (m-float64(transpositions)/2)/m) / 3
+
+	prefix := 0
+	for prefix < min(4, len(s), len(t)) && s[prefix] == t[prefix] {
+		prefix++
+	}
+	return jaro + float64(prefix)*0.1*(1-jaro)
+}
+
+// Round keeps three decimals, enough to explain a score
+func Round(value float64) float64 {
+	return float64(int(value*1000+0.5)) / 1000
+}
@@ -0,0 +1,113 @@
+// Package routing finds the shortest chains of correspondent banks between two banks
+package routing
+
+import "sort"
+
+// Graph is an undirected graph of the banks, an edge is an account relationship which carries payments both ways
+type Graph struct {
+	neighbours map[string]map[string]bool
+}
+
+// NewGraph creates an empty graph
+func NewGraph() *Graph {
+	return &Graph{neighbours: make(map[string]map[string]bool)}
+}
+
+// AddEdge links two banks, self links are ignored
+func (g *Graph) AddEdge(a, b string) {
+	if a == b {
+		return
+	}
+	g.link(a, b)
+	g.link(b, a)
+}
+
+func (g *Graph) link(from, to string) {
+	if g.neighbours[from] == nil {
+		g.neighbours[from] = make(map[string]bool)
+	}
+	g.neighbours[from][to] = true
+}
+
+// Neighbours lists the banks linked to the bank in the alphabetical order
+func (g *Graph) Neighbours(node string) []string {
+	result := make([]string, 0, len(g.neighbours[node]))
+	for neighbour := range g.neighbours[node] {
+		result = append(result, neighbour)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// ShortestPaths returns the shortest paths from one bank to the other, each path starts with from and ends with to.
+// Paths longer than maxHops edges aren't searched. The paths come in the alphabetical order of the intermediate banks
+// and the search stops after limit paths, so the dense graphs with many paths of the same length stay cheap. Nil is
+// returned when the banks aren't connected
+func (g *Graph) ShortestPaths(from, to string, maxHops, limit int) [][]string {
+	if from == to {
+		return [][]string{{from}}
+	}
+
+	// Breadth first search of the layers of the banks by their distance from the first one
+	distance := map[string]int{from: 0}
+	frontier := []string{from}
+	for hops := 1; hops <= maxHops && len(frontier) > 0; hops++ {
+		next := []string{}
+		for _, node := range frontier {
+			for neighbour := range g.neighbours[node] {
+				if _, visited := distance[neighbour]; !visited {
+					distance[neighbour] = hops
+					next = append(next, neighbour)
+				}
+			}
+		}
+		if _, found := distance[to]; found {
+			break
+		}
+		frontier = next
+	}
+
+	if _, found := distance[to]; !found {
+		return nil
+	}
+
+	// The banks on a shortest path, found walking the layers back from the last bank
+	onPath := map[string]bool{to: true}
+	layer := []string{to}
+	for hops := distance[to]; hops > 0; hops-- {
+		previous := []string{}
+		for _, node := range layer {
+			for neighbour := range g.neighbours[node] {
+				if d, ok := distance[neighbour]; ok && d == hops-1 && !onPath[neighbour] {
+					onPath[neighbour] = true
+					previous = append(previous, neighbour)
+				}
+			}
+		}
+		layer = previous
+	}
+
+	// Every step forward through the sorted neighbours on a shortest path ends at the last bank, so the paths are
+	// found in order and no walk is wasted
+	paths := [][]string{}
+	path := []string{from}
+	var walk func(node string)
+	walk = func(node string) {
+		if node == to {
+			paths = append(paths, append([]string(nil), path...))
+			return
+		}
+		for _, neighbour := range g.Neighbours(node) {
+			if len(paths) >= limit {
+				return
+			}
+			if onPath[neighbour] && distance[neighbour] == distance[node]+1 {
+				path = append(path, neighbour)
+				walk(neighbour)
+				path = path[:len(path)-1]
+			}
+		}
+	}
+	walk(from)
+	return paths
+}
@@ -0,0 +1,85 @@
+package routing
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShortestPaths(t *testing.T) {
+	graph := NewGraph()
+	graph.AddEdge("A", "C1")
+	graph.AddEdge("C1", "B")
+	graph.AddEdge("A", "C2")
+	graph.AddEdge("C2", "B")
+	graph.AddEdge("A", "D1")
+	graph.AddEdge("D1", "D2")
+	graph.AddEdge("D2", "B")
+
+	paths := graph.ShortestPaths("A", "B", 4, 10)
+	assert.Equal(t, [][]string{{"A", "C1", "B"}, {"A", "C2", "B"}}, paths)
+
+	// The links carry payments both ways
+	paths = graph.ShortestPaths("B", "A", 4, 10)
+	assert.Equal(t, [][]string{{"B", "C1", "A"}, {"B", "C2", "A"}}, paths)
+
+	assert.Len(t, graph.ShortestPaths("A", "B", 4, 1), 1)
+	assert.Nil(t, graph.ShortestPaths("A", "B", 1, 10))
+	assert.Equal(t, [][]string{{"A", "D1"}}, graph.ShortestPaths("A", "D1", 1, 10))
+	assert.Equal(t, [][]string{{"A"}}, graph.ShortestPaths("A", "A", 4, 10))
+}
+
+func TestShortestPathsLimit(t *testing.T) {
+	graph := NewGraph()
+	graph.AddEdge("A", "B")
+	graph.AddEdge("A", "C")
+	graph.AddEdge("B", "X")
+	graph.AddEdge("B", "Y")
+	graph.AddEdge("C", "X")
+	graph.AddEdge("X", "Z")
+	graph.AddEdge("Y", "Z")
+
+	// The search reaches A-C-X-Z before A-B-Y-Z, the limit still keeps the first paths in the alphabetical order
+	paths := graph.ShortestPaths("A", "Z", 4, 2)
+	assert.Equal(t, [][]string{{"A", "B", "X", "Z"}, {"A", "B", "Y", "Z"}}, paths)
+	assert.Len(t, graph.ShortestPaths("A", "Z", 4, 10), 3)
+}
+
+func TestShortestPathsDense(t *testing.T) {
+	// Five fully linked layers of 30 banks hold 30^5 shortest paths, only the first ones are walked
+	graph := NewGraph()
+	previous := []string{"A"}
+	for layer := 1; layer <= 5; layer++ {
+		current := []string{}
+		for i := 0; i < 30; i++ {
+			node := fmt.Sprintf("L%d-%02d", layer, i)
+			for _, p := range previous {
+				graph.AddEdge(p, node)
+			}
+			current = append(current, node)
+		}
+		previous = current
+	}
+	for _, p := range previous {
+		graph.AddEdge(p, "Z")
+	}
+
+	paths := graph.ShortestPaths("A", "Z", 6, 2)
+	assert.Equal(t, [][]string{
+		{"A", "L1-00", "L2-00", "L3-00", "L4-00", "L5-00", "Z"},
+		{"A", "L1-00", "L2-00", "L3-00", "L4-00", "L5-01", "Z"},
+	}, paths)
+}
+
+func TestShortestPathsDisconnected(t *testing.T) {
+	graph := NewGraph()
+	graph.AddEdge("A", "B")
+	graph.AddEdge("C", "D")
+	graph.AddEdge("C", "C")
+
+	assert.Nil(t, graph.ShortestPaths("A", "D", 6, 10))
+	assert.Nil(t, graph.ShortestPaths("X", "A", 6, 10))
+	assert.Empty(t, graph.Neighbours("X"))
+	assert.Equal(t, []string{"D"}, graph.Neighbours("C"))
+}
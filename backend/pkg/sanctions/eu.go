@@ -0,0 +1,113 @@
+package sanctions
+
+import (
+	"encoding/xml"
+	"io"
+	"strings"
+
+	"github.com/Hbrtjm/SWIFT_API/backend/pkg/iso3166"
+)
+
+// euEntity is a sanctionEntity element of the EU consolidated list (the XML export of the Financial Sanctions
+// Database). The namespace of the export is ignored
+type euEntity struct {
+	LogicalID   string   `xml:"logicalId,attr"`
+	EUReference string   `xml:"euReferenceNumber,attr"`
+	Remarks     []string `xml:"remark"`
+	Regulations []struct {
+		Programme string `xml:"programme,attr"`
+	} `xml:"regulation"`
+	SubjectType struct {
+		Code string `xml:"code,attr"`
+	} `xml:"subjectType"`
+	NameAliases []struct {
+		WholeName string `xml:"wholeName,attr"`
+	} `xml:"nameAlias"`
+	Addresses       []euCountry `xml:"address"`
+	Citizenships    []euCountry `xml:"citizenship"`
+	Identifications []struct {
+		TypeCode        string `xml:"identificationTypeCode,attr"`
+		TypeDescription string `xml:"identificationTypeDescription,attr"`
+		Number          string `xml:"number,attr"`
+	} `xml:"identification"`
+}
+
+type euCountry struct {
+	CountryISO2 string `xml:"countryIso2Code,attr"`
+}
+
+// euTypes map the subject types of the EU list
+var euTypes = map[string]string{
+	"enterprise": TypeEntity,
+	"person":     TypeIndividual,
+}
+
+// ParseEU parses the EU consolidated list, entity by entity. The BICs come from the identifications of the SWIFT/BIC
+// type and from the remarks, the countries from the addresses and the citizenships
+func ParseEU(r io.Reader) ([]Entry, error) {
+	decoder := xml.NewDecoder(r)
+	entries := []Entry{}
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return entries, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		start, ok := token.(xml.StartElement)
+		if !ok || start.Name.Local != "sanctionEntity" {
+			continue
+		}
+
+		var entity euEntity
+		if err := decoder.DecodeElement(&entity, &start); err != nil {
+			return nil, err
+		}
+		if entry, ok := entity.entry(); ok {
+			entries = append(entries, entry)
+		}
+	}
+}
+
+func (e euEntity) entry() (Entry, bool) {
+	entry := Entry{
+		List:      ListEU,
+		ID:        strings.TrimSpace(e.EUReference),
+		Names:     []string{},
+		Type:      strings.ToLower(strings.TrimSpace(e.SubjectType.Code)),
+		Programs:  []string{},
+		BICs:      []string{},
+		Countries: []string{},
+	}
+	if entry.ID == "" {
+		entry.ID = strings.TrimSpace(e.LogicalID)
+	}
+	if subjectType, ok := euTypes[entry.Type]; ok {
+		entry.Type = subjectType
+	}
+
+	for _, alias := range e.NameAliases {
+		entry.Names = appendUnique(entry.Names, alias.WholeName)
+	}
+	for _, regulation := range e.Regulations {
+		entry.Programs = appendUnique(entry.Programs, regulation.Programme)
+	}
+	for _, identification := range e.Identifications {
+		kind := strings.ToLower(identification.TypeCode + " " + identification.TypeDescription)
+		if strings.Contains(kind, "swift") || strings.Contains(kind, "bic") {
+			entry.BICs = appendUnique(entry.BICs, strings.ToUpper(strings.ReplaceAll(identification.Number, " ", "")))
+		}
+	}
+	for _, remark := range e.Remarks {
+		entry.BICs = appendUnique(entry.BICs, bicsIn(remark)...)
+	}
+	// The unknown countries are written as 00
+	for _, country := range append(e.Addresses, e.Citizenships...) {
+		if registered, ok := iso3166.Lookup(country.CountryISO2); ok {
+			entry.Countries = appendUnique(entry.Countries, registered.Alpha2)
+		}
+	}
+
+	return entry, entry.ID != "" && len(entry.Names) > 0
+}
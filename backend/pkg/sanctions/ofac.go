@@ -0,0 +1,169 @@
+package sanctions
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"strings"
+
+	"github.com/Hbrtjm/SWIFT_API/backend/pkg/iso3166"
+)
+
+// Columns of the OFAC files, which come without a header
+const (
+	// sdn.csv: ent_num, SDN_Name, SDN_Type, Program, Title, Call_Sign, Vess_type, Tonnage, GRT, Vess_flag,
+	// Vess_owner, Remarks
+	ofacSDNID      = 0
+	ofacSDNName    = 1
+	ofacSDNType    = 2
+	ofacSDNProgram = 3
+	ofacSDNRemarks = 11
+	// alt.csv: ent_num, alt_num, alt_type, alt_name, alt_remarks
+	ofacAltID   = 0
+	ofacAltName = 3
+	// add.csv: ent_num, add_num, address, City/State/Province/Postal Code, Country, add_remarks
+	ofacAddID      = 0
+	ofacAddCountry = 4
+)
+
+// ofacNull is written by OFAC in the empty fields
+const ofacNull = "-0-"
+
+// ofacCountries are the OFAC spellings of the countries missing from the ISO 3166 names, normalized
+var ofacCountries = map[string]string{
+	"BURMA":                            "MM",
+	"CONGO DEMOCRATIC REPUBLIC OF THE": "CD",
+	"CONGO REPUBLIC OF THE":            "CG",
+	"GAZA":                             "PS",
+	"KOREA NORTH":                      "KP",
+	"KOREA SOUTH":                      "KR",
+	"MACAU":                            "MO",
+	"NETHERLANDS ANTILLES":             "CW",
+	"RUSSIA":                           "RU",
+	"THE BAHAMAS":                      "BS",
+	"THE GAMBIA":                       "GM",
+	"TURKEY":                           "TR",
+	"WEST BANK":                        "PS",
+}
+
+// ParseOFAC parses the SDN file of the OFAC list. The aliases (alt.csv) and the addresses (add.csv) published along
+// with it are optional, nil readers are skipped. The BICs come from the remarks and the countries from the addresses
+func ParseOFAC(sdn, alt, add io.Reader) ([]Entry, error) {
+	if sdn == nil {
+		return nil, errors.New("missing SDN file")
+	}
+
+	entries := []Entry{}
+	byID := map[string]int{}
+	err := readOFAC(sdn, func(record []string) {
+		if len(record) <= ofacSDNName {
+			return
+		}
+		entry := Entry{
+			List:      ListOFAC,
+			ID:        ofacField(record, ofacSDNID),
+			Names:     appendUnique([]string{}, ofacField(record, ofacSDNName)),
+			Type:      ofacType(ofacField(record, ofacSDNType)),
+			Programs:  ofacPrograms(ofacField(record, ofacSDNProgram)),
+			BICs:      appendUnique([]string{}, bicsIn(ofacField(record, ofacSDNRemarks))...),
+			Countries: []string{},
+		}
+		if entry.ID == "" || len(entry.Names) == 0 {
+			return
+		}
+		byID[entry.ID] = len(entries)
+		entries = append(entries, entry)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if alt != nil {
+		err = readOFAC(alt, func(record []string) {
+			if i, ok := byID[ofacField(record, ofacAltID)]; ok {
+				entries[i].Names = appendUnique(entries[i].Names, ofacField(record, ofacAltName))
+			}
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if add != nil {
+		err = readOFAC(add, func(record []string) {
+			i, ok := byID[ofacField(record, ofacAddID)]
+			if !ok {
+				return
+			}
+			if country, ok := ofacCountry(ofacField(record, ofacAddCountry)); ok {
+				entries[i].Countries = appendUnique(entries[i].Countries, country)
+			}
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return entries, nil
+}
+
+// readOFAC calls the function with every record of the file. OFAC ends the files with a SUB character, which is
+// skipped along with the empty lines
+func readOFAC(r io.Reader, record func([]string)) error {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	for {
+		fields, err := reader.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if ofacField(fields, 0) == "" {
+			continue
+		}
+		record(fields)
+	}
+}
+
+// ofacField is the trimmed value of the column, empty for the missing and the null values
+func ofacField(record []string, column int) string {
+	if column >= len(record) {
+		return ""
+	}
+	value := strings.TrimSpace(strings.Trim(record[column], "\x1a"))
+	if value == ofacNull {
+		return ""
+	}
+	return value
+}
+
+// ofacType maps the SDN type, which is left empty for the entities
+func ofacType(sdnType string) string {
+	if sdnType == "" {
+		return TypeEntity
+	}
+	return strings.ToLower(sdnType)
+}
+
+// ofacPrograms splits the programmes, written as "IRAN] [SDGT"
+func ofacPrograms(program string) []string {
+	programs := []string{}
+	for _, part := range strings.Split(program, "]") {
+		programs = appendUnique(programs, strings.Trim(part, " ["))
+	}
+	return programs
+}
+
+func ofacCountry(name string) (string, bool) {
+	if name == "" {
+		return "", false
+	}
+	if country, ok := iso3166.LookupName(name); ok {
+		return country.Alpha2, true
+	}
+	alpha2, ok := ofacCountries[iso3166.NormalizeName(name)]
+	return alpha2, ok
+}
@@ -0,0 +1,77 @@
+// Package sanctions reads the sanctions lists published as files, the OFAC SDN list (CSV) and the EU consolidated
+// financial sanctions list (XML), and screens the banks of the directory against their entries by the BIC, the name
+// and the country
+package sanctions
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Lists read by the package
+const (
+	ListOFAC = "ofac"
+	ListEU   = "eu"
+)
+
+// Types of the listed subjects, only the entities are screened
+const (
+	TypeEntity     = "entity"
+	TypeIndividual = "individual"
+	TypeVessel     = "vessel"
+	TypeAircraft   = "aircraft"
+)
+
+// Entry is a subject of a sanctions list
+type Entry struct {
+	List string
+	// Identifier of the entry within its list
+	ID string
+	// The primary name first, followed by the aliases
+	Names []string
+	Type  string
+	// Sanctions programmes the entry is listed under, e.g. IRAN or RUS
+	Programs []string
+	// BICs of the entry, an 8 character BIC covers every branch of the institution
+	BICs []string
+	// ISO 3166 alpha-2 codes of the countries of the addresses and the citizenships
+	Countries []string
+}
+
+// IsList checks whether the list is read by the package
+func IsList(list string) bool {
+	return list == ListOFAC || list == ListEU
+}
+
+// bicPattern finds the BICs written in the remarks, e.g. "SWIFT/BIC BMJIIRTH" or "SWIFT code: BMJIIRTH"
+var bicPattern = regexp.MustCompile(`(?i:SWIFT(?:/BIC)?(?:\s+code)?)[\s:]+([A-Z]{6}[A-Z0-9]{2}(?:[A-Z0-9]{3})?)\b`)
+
+// bicsIn lists the BICs written in the text
+func bicsIn(text string) []string {
+	bics := []string{}
+	for _, match := range bicPattern.FindAllStringSubmatch(text, -1) {
+		bics = append(bics, match[1])
+	}
+	return bics
+}
+
+// appendUnique appends the values missing from the list, ignoring the empty ones
+func appendUnique(list []string, values ...string) []string {
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+		found := false
+		for _, existing := range list {
+			if existing == value {
+				found = true
+				break
+			}
+		}
+		if !found {
+			list = append(list, value)
+		}
+	}
+	return list
+}
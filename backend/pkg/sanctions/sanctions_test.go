@@ -0,0 +1,154 @@
+package sanctions
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const ofacSDN = `36,"BANK MELLI IRAN","-0- ","IRAN] [IFSR","-0- ","-0- ","-0- ","-0- ","-0- ","-0- ","-0- ","Additional Sanctions Information - Subject to Secondary Sanctions; SWIFT/BIC MELIIRTH; Website www.bmi.ir."
+173,"ANGLO-CARIBBEAN CO., LTD.","-0- ","CUBA","-0- ","-0- ","-0- ","-0- ","-0- ","-0- ","-0- ","-0- "
+2674,"HUSSEIN, Saddam","individual","IRAQ2","-0- ","-0- ","-0- ","-0- ","-0- ","-0- ","-0- ","DOB 28 Apr 1937."
+` + "\x1a"
+
+const ofacAlt = `36,12,"aka","MELLI BANK",-0-
+173,13,"aka","ANGLO CARIBBEAN TRADING COMPANY",-0-
+`
+
+const ofacAdd = `36,25,"Ferdowsi Avenue","Tehran","Iran",-0-
+36,26,"Hamburg Branch","Hamburg","Germany",-0-
+173,27,"Ibex House","London","United Kingdom",-0-
+2674,28,"-0- ","Baghdad","Iraq",-0-
+`
+
+const euList = `<?xml version="1.0" encoding="UTF-8"?>
+<export xmlns="http://eu.europa.ec/fpi/fsd/export" generationDate="2026-10-01T10:00:00.000+02:00">
+  <sanctionEntity designationDetails="" unitedNationId="" euReferenceNumber="EU.1234.56" logicalId="1234">
+    <remark>SWIFT code: MOSBRUMM</remark>
+    <regulation regulationType="amendment" programme="UKR" logicalId="1"/>
+    <subjectType code="enterprise" classificationCode="E"/>
+    <nameAlias wholeName="Bank Moskvy" logicalId="2"/>
+    <nameAlias wholeName="Банк Москвы" logicalId="3"/>
+    <address countryIso2Code="RU" countryDescription="RUSSIAN FEDERATION" logicalId="4"/>
+    <address countryIso2Code="00" countryDescription="UNKNOWN" logicalId="5"/>
+    <identification identificationTypeCode="swiftbic" identificationTypeDescription="SWIFT BIC" number="BKMB RU MM 001" logicalId="6"/>
+  </sanctionEntity>
+  <sanctionEntity designationDetails="" euReferenceNumber="" logicalId="13">
+    <regulation programme="IRQ" logicalId="7"/>
+    <subjectType code="person" classificationCode="P"/>
+    <nameAlias wholeName="Saddam Hussein Al-Tikriti" logicalId="8"/>
+    <citizenship countryIso2Code="IQ" logicalId="9"/>
+  </sanctionEntity>
+</export>`
+
+func TestParseOFAC(t *testing.T) {
+	entries, err := ParseOFAC(strings.NewReader(ofacSDN), strings.NewReader(ofacAlt), strings.NewReader(ofacAdd))
+	require.NoError(t, err)
+	require.Len(t, entries, 3)
+
+	assert.Equal(t, Entry{
+		List:      ListOFAC,
+		ID:        "36",
+		Names:     []string{"BANK MELLI IRAN", "MELLI BANK"},
+		Type:      TypeEntity,
+		Programs:  []string{"IRAN", "IFSR"},
+		BICs:      []string{"MELIIRTH"},
+		Countries: []string{"IR", "DE"},
+	}, entries[0])
+	assert.Equal(t, []string{"GB"}, entries[1].Countries)
+	assert.Empty(t, entries[1].BICs)
+	assert.Equal(t, TypeIndividual, entries[2].Type)
+
+	// The companion files are optional
+	entries, err = ParseOFAC(strings.NewReader(ofacSDN), nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"BANK MELLI IRAN"}, entries[0].Names)
+	assert.Empty(t, entries[0].Countries)
+}
+
+func TestParseEU(t *testing.T) {
+	entries, err := ParseEU(strings.NewReader(euList))
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+
+	assert.Equal(t, Entry{
+		List:      ListEU,
+		ID:        "EU.1234.56",
+		Names:     []string{"Bank Moskvy", "Банк Москвы"},
+		Type:      TypeEntity,
+		Programs:  []string{"UKR"},
+		BICs:      []string{"BKMBRUMM001", "MOSBRUMM"},
+		Countries: []string{"RU"},
+	}, entries[0])
+	assert.Equal(t, "13", entries[1].ID)
+	assert.Equal(t, TypeIndividual, entries[1].Type)
+	assert.Equal(t, []string{"IQ"}, entries[1].Countries)
+
+	_, err = ParseEU(strings.NewReader("<export><sanctionEntity>"))
+	assert.Error(t, err)
+}
+
+func TestScreen(t *testing.T) {
+	ofac, err := ParseOFAC(strings.NewReader(ofacSDN), strings.NewReader(ofacAlt), strings.NewReader(ofacAdd))
+	require.NoError(t, err)
+	eu, err := ParseEU(strings.NewReader(euList))
+	require.NoError(t, err)
+	screener := NewScreener(append(ofac, eu...))
+	assert.Equal(t, 3, screener.Len())
+
+	t.Run("BIC8 covers the branches", func(t *testing.T) {
+		hits := screener.Screen(Subject{SwiftCode: "MELIIRTH123", Name: "UNRELATED", CountryISO2: "IR"})
+		require.Len(t, hits, 1)
+		assert.Equal(t, Hit{
+			List:         ListOFAC,
+			EntryID:      "36",
+			EntryName:    "BANK MELLI IRAN",
+			MatchType:    MatchBIC,
+			Score:        1,
+			CountryMatch: true,
+			Programs:     []string{"IRAN", "IFSR"},
+		}, hits[0])
+	})
+
+	t.Run("BIC11 only covers the branch", func(t *testing.T) {
+		hits := screener.Screen(Subject{SwiftCode: "BKMBRUMM001", CountryISO2: "RU"})
+		require.Len(t, hits, 1)
+		assert.Equal(t, MatchBIC, hits[0].MatchType)
+		assert.Empty(t, screener.Screen(Subject{SwiftCode: "BKMBRUMM002"}))
+	})
+
+	t.Run("Name", func(t *testing.T) {
+		hits := screener.Screen(Subject{SwiftCode: "AAAADEFFXXX", Name: "Bank Melli Iran", CountryISO2: "FR"})
+		require.Len(t, hits, 1)
+		assert.Equal(t, MatchName, hits[0].MatchType)
+		assert.Equal(t, 1.0, hits[0].Score)
+		assert.False(t, hits[0].CountryMatch)
+
+		// A branch named after the bank
+		hits = screener.Screen(Subject{SwiftCode: "AAAADEFFXXX", Name: "BANK MELLI IRAN HAMBURG BRANCH", CountryISO2: "DE"})
+		require.Len(t, hits, 1)
+		assert.True(t, hits[0].CountryMatch)
+
+		// Alias in another script
+		hits = screener.Screen(Subject{SwiftCode: "AAAARUMMXXX", Name: "BANK MOSKVY", CountryISO2: "RU"})
+		require.Len(t, hits, 1)
+		assert.Equal(t, "EU.1234.56", hits[0].EntryID)
+	})
+
+	t.Run("Country lowers the threshold", func(t *testing.T) {
+		subject := Subject{SwiftCode: "AAAAGB2LXXX", Name: "ANGLO CARIBBEAN INVESTMENT CO"}
+		assert.Empty(t, screener.Screen(subject))
+		subject.CountryISO2 = "GB"
+		hits := screener.Screen(subject)
+		require.Len(t, hits, 1)
+		assert.Equal(t, "173", hits[0].EntryID)
+		assert.GreaterOrEqual(t, hits[0].Score, MinNameScoreInCountry)
+	})
+
+	t.Run("Individuals and other banks", func(t *testing.T) {
+		assert.Empty(t, screener.Screen(Subject{SwiftCode: "AAAAIQBAXXX", Name: "SADDAM HUSSEIN AL-TIKRITI", CountryISO2: "IQ"}))
+		assert.Empty(t, screener.Screen(Subject{SwiftCode: "TPEOPLPWXXX", Name: "PEKAO TFI S.A.", CountryISO2: "PL"}))
+	})
+}
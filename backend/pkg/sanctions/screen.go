@@ -0,0 +1,225 @@
+package sanctions
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/Hbrtjm/SWIFT_API/backend/pkg/names"
+)
+
+// Kinds of the matches
+const (
+	// The SWIFT code of the bank is a BIC of the entry, or a branch of an 8 character BIC
+	MatchBIC = "bic"
+	// The bank name is similar to a name of the entry
+	MatchName = "name"
+)
+
+const (
+	// Name score from which a bank is a hit
+	MinNameScore = 0.9
+	// Name score from which a bank in a country of the entry is a hit
+	MinNameScoreInCountry = 0.8
+	// Distinctive words of an entry name needed to match the name as a part of the bank name, such as a branch
+	// named after the sanctioned bank and its town
+	minCoveredWords = 2
+	// Length of the prefixes of the words indexing the names
+	prefixLength = 3
+)
+
+// Subject is a bank screened against the lists
+type Subject struct {
+	SwiftCode   string
+	Name        string
+	CountryISO2 string
+}
+
+// Hit is an entry matching a subject
+type Hit struct {
+	List    string
+	EntryID string
+	// Name of the entry which matched, the primary name for the BIC matches
+	EntryName string
+	MatchType string
+	// 1 for the BIC matches, the name similarity from 0 to 1 otherwise
+	Score float64
+	// Whether the subject is in a country of the entry
+	CountryMatch bool
+	Programs     []string
+}
+
+type screenedName struct {
+	entry    int
+	original string
+	name     names.Name
+	// Number of the distinctive words
+	words int
+}
+
+// Screener indexes the entities of the lists by the BICs and by the prefixes of the words of their names
+type Screener struct {
+	entries  []Entry
+	names    []screenedName
+	byBIC    map[string][]int
+	byPrefix map[string][]int
+}
+
+// NewScreener indexes the entries, the individuals, the vessels and the aircraft aren't banks and are left out
+func NewScreener(entries []Entry) *Screener {
+	screener := &Screener{byBIC: map[string][]int{}, byPrefix: map[string][]int{}}
+	for _, entry := range entries {
+		if entry.Type != TypeEntity {
+			continue
+		}
+		i := len(screener.entries)
+		screener.entries = append(screener.entries, entry)
+
+		for _, bic := range entry.BICs {
+			key := bicKey(bic)
+			screener.byBIC[key] = append(screener.byBIC[key], i)
+		}
+		for _, original := range entry.Names {
+			name := names.Parse(original)
+			if name.IsEmpty() {
+				continue
+			}
+			n := len(screener.names)
+			screener.names = append(screener.names, screenedName{entry: i, original: original, name: name, words: countWords(name)})
+			for _, prefix := range prefixes(name) {
+				screener.byPrefix[prefix] = append(screener.byPrefix[prefix], n)
+			}
+		}
+	}
+	return screener
+}
+
+// Len is the number of the screened entries
+func (s *Screener) Len() int {
+	return len(s.entries)
+}
+
+// Screen lists the entries matching the subject, the best first. An entry matches by the BIC or, when the BIC
+// doesn't match, by the most similar of its names. The names of the entries in the country of the subject need a
+// lower score
+func (s *Screener) Screen(subject Subject) []Hit {
+	best := map[int]Hit{}
+
+	code := strings.ToUpper(strings.TrimSpace(subject.SwiftCode))
+	keys := []string{code}
+	if len(code) > 8 {
+		keys = append(keys, code[:8])
+	}
+	for _, key := range keys {
+		for _, i := range s.byBIC[key] {
+			best[i] = s.hit(i, subject, MatchBIC, 1, s.entries[i].Names[0])
+		}
+	}
+
+	name := names.Parse(subject.Name)
+	compared := map[int]bool{}
+	for _, prefix := range prefixes(name) {
+		for _, n := range s.byPrefix[prefix] {
+			if compared[n] {
+				continue
+			}
+			compared[n] = true
+
+			candidate := s.names[n]
+			score := nameScore(candidate, name)
+			threshold := MinNameScore
+			if contains(s.entries[candidate.entry].Countries, subject.CountryISO2) {
+				threshold = MinNameScoreInCountry
+			}
+			if score < threshold {
+				continue
+			}
+			if hit, ok := best[candidate.entry]; ok && (hit.MatchType == MatchBIC || hit.Score >= score) {
+				continue
+			}
+			best[candidate.entry] = s.hit(candidate.entry, subject, MatchName, score, candidate.original)
+		}
+	}
+
+	hits := make([]Hit, 0, len(best))
+	for _, hit := range best {
+		hits = append(hits, hit)
+	}
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].Score != hits[j].Score {
+			return hits[i].Score > hits[j].Score
+		}
+		if hits[i].List != hits[j].List {
+			return hits[i].List < hits[j].List
+		}
+		return hits[i].EntryID < hits[j].EntryID
+	})
+	return hits
+}
+
+func (s *Screener) hit(i int, subject Subject, matchType string, score float64, entryName string) Hit {
+	entry := s.entries[i]
+	return Hit{
+		List:         entry.List,
+		EntryID:      entry.ID,
+		EntryName:    entryName,
+		MatchType:    matchType,
+		Score:        score,
+		CountryMatch: contains(entry.Countries, subject.CountryISO2),
+		Programs:     entry.Programs,
+	}
+}
+
+// nameScore compares the names both ways, the names with enough distinctive words match as well when the bank name
+// contains the whole of them
+func nameScore(candidate screenedName, name names.Name) float64 {
+	comparison := names.Compare(candidate.name, name)
+	if candidate.words >= minCoveredWords && comparison.Coverage > comparison.Score {
+		return comparison.Coverage
+	}
+	return comparison.Score
+}
+
+// bicKey is the 8 character BIC of the primary offices, which covers the branches, and the BIC11 of the branches
+func bicKey(bic string) string {
+	bic = strings.ToUpper(strings.TrimSpace(bic))
+	if len(bic) == 11 && strings.HasSuffix(bic, "XXX") {
+		return bic[:8]
+	}
+	return bic
+}
+
+// prefixes of the distinctive words of the name, of all of its words when it has none
+func prefixes(name names.Name) []string {
+	result := []string{}
+	for _, kinds := range [][]string{{names.KindWord}, {names.KindWord, names.KindGeneric, names.KindLegalForm}} {
+		for _, token := range name.Tokens {
+			if contains(kinds, token.Kind) {
+				prefix := []rune(token.Text)
+				result = appendUnique(result, string(prefix[:min(len(prefix), prefixLength)]))
+			}
+		}
+		if len(result) > 0 {
+			break
+		}
+	}
+	return result
+}
+
+func countWords(name names.Name) int {
+	count := 0
+	for _, token := range name.Tokens {
+		if token.Kind == names.KindWord {
+			count++
+		}
+	}
+	return count
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
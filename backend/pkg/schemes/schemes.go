@@ -0,0 +1,92 @@
+// Package schemes describes the payment schemes whose participant directories tell which banks a payment can reach
+package schemes
+
+import (
+	"errors"
+	"sort"
+	"strings"
+)
+
+var ErrUnknownScheme = errors.New("unknown payment scheme")
+
+// Scheme is a payment scheme or settlement system
+type Scheme struct {
+	// Code used in the API, e.g. SCT_INST
+	Code    string   `json:"code"`
+	Name    string   `json:"name"`
+	Aliases []string `json:"aliases"`
+}
+
+var schemes = []Scheme{
+	{
+		Code:    "SCT",
+		Name:    "SEPA Credit Transfer",
+		Aliases: []string{"SEPA SCT", "SEPA_SCT", "SEPA-SCT"},
+	},
+	{
+		Code:    "SCT_INST",
+		Name:    "SEPA Instant Credit Transfer",
+		Aliases: []string{"SCT INST", "SCTINST", "SCT-INST", "SEPA SCT INST", "SEPA INSTANT"},
+	},
+	{
+		Code:    "SDD_CORE",
+		Name:    "SEPA Direct Debit Core",
+		Aliases: []string{"SDD CORE", "SDD-CORE", "SDD"},
+	},
+	{
+		Code:    "SDD_B2B",
+		Name:    "SEPA Direct Debit Business to Business",
+		Aliases: []string{"SDD B2B", "SDD-B2B"},
+	},
+	{
+		Code:    "TARGET2",
+		Name:    "TARGET2 (T2 RTGS) of the Eurosystem",
+		Aliases: []string{"T2", "RTGS", "TARGET", "T2 RTGS"},
+	},
+	{
+		Code:    "EURO1",
+		Name:    "EURO1 of EBA Clearing",
+		Aliases: []string{"EBA EURO1"},
+	},
+	{
+		Code:    "STEP2",
+		Name:    "STEP2 of EBA Clearing",
+		Aliases: []string{"STEP2-T", "EBA STEP2"},
+	},
+	{
+		Code:    "CHAPS",
+		Name:    "Clearing House Automated Payment System",
+		Aliases: []string{},
+	},
+	{
+		Code:    "FEDWIRE",
+		Name:    "Fedwire Funds Service",
+		Aliases: []string{"FED"},
+	},
+}
+
+// Lookup finds a scheme by its code or one of its aliases, ignoring case
+func Lookup(name string) (Scheme, bool) {
+	name = strings.ToUpper(strings.TrimSpace(name))
+	for _, scheme := range schemes {
+		if scheme.Code == name {
+			return scheme, true
+		}
+		for _, alias := range scheme.Aliases {
+			if alias == name {
+				return scheme, true
+			}
+		}
+	}
+	return Scheme{}, false
+}
+
+// All lists the supported schemes sorted by their codes
+func All() []Scheme {
+	result := make([]Scheme, len(schemes))
+	copy(result, schemes)
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Code < result[j].Code
+	})
+	return result
+}
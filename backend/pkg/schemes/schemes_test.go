@@ -0,0 +1,40 @@
+package schemes
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLookup(t *testing.T) {
+	scheme, ok := Lookup("sct inst")
+	require.True(t, ok)
+	assert.Equal(t, "SCT_INST", scheme.Code)
+
+	scheme, ok = Lookup(" T2 ")
+	require.True(t, ok)
+	assert.Equal(t, "TARGET2", scheme.Code)
+
+	scheme, ok = Lookup("SCT")
+	require.True(t, ok)
+	assert.Equal(t, "SEPA Credit Transfer", scheme.Name)
+
+	_, ok = Lookup("SWIFT")
+	assert.False(t, ok)
+}
+
+func TestAll(t *testing.T) {
+	all := All()
+	assert.True(t, sort.SliceIsSorted(all, func(i, j int) bool { return all[i].Code < all[j].Code }))
+
+	// The aliases must not be shared
+	seen := make(map[string]string)
+	for _, scheme := range all {
+		for _, name := range append([]string{scheme.Code}, scheme.Aliases...) {
+			assert.Empty(t, seen[name], name)
+			seen[name] = scheme.Code
+		}
+	}
+}
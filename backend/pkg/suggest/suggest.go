@@ -0,0 +1,186 @@
+// Package suggest finds the SWIFT codes a mistyped code was likely meant to be. The candidates are the codes one edit
+// away (a transposition, a substitution, an insertion or a deletion), the codes with the look-alike characters
+// swapped (O and 0, I and 1) and the primary office of a BIC8, so that they can be looked up at once
+package suggest
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/Hbrtjm/SWIFT_API/backend/pkg/validators"
+)
+
+// Kinds of the edits turning the requested code into a candidate
+const (
+	// The BIC8 completed with the XXX branch code of the primary office
+	EditPrimaryOffice = "primaryOffice"
+	// O written instead of 0, I instead of 1, or the other way round
+	EditConfusion     = "confusion"
+	EditTransposition = "transposition"
+	EditSubstitution  = "substitution"
+	EditInsertion     = "insertion"
+	EditDeletion      = "deletion"
+)
+
+// editCosts rank the candidates, the look-alike characters and the swapped neighbours are the most common mistakes
+var editCosts = map[string]float64{
+	EditPrimaryOffice: 0.25,
+	EditConfusion:     0.5,
+	EditTransposition: 0.75,
+	EditSubstitution:  1,
+	EditInsertion:     1,
+	EditDeletion:      1,
+}
+
+const (
+	letters = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	digits  = "0123456789"
+	// Look-alike characters swapped at most, each of them doubles the candidates
+	maxConfusions = 4
+)
+
+var confusions = map[byte]byte{'O': '0', '0': 'O', 'I': '1', '1': 'I'}
+
+// Candidate is a code close to the requested one
+type Candidate struct {
+	Code string
+	// Edits applied to the requested code, the cheapest way to get the candidate
+	Edits []string
+	Cost  float64
+}
+
+// Candidates lists the BIC11 codes close to the code, from the cheapest. The code itself isn't a candidate, except
+// for the primary office of a BIC8
+func Candidates(code string) []Candidate {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	best := map[string]Candidate{}
+	add := func(variant string, edits ...string) {
+		if len(variant) == 8 {
+			variant += validators.PrimaryOfficeBranchCode
+			edits = append(edits, EditPrimaryOffice)
+		}
+		if variant == code || !wellFormed(variant) {
+			return
+		}
+		cost := 0.0
+		for _, edit := range edits {
+			cost += editCosts[edit]
+		}
+		if current, ok := best[variant]; !ok || cost < current.Cost {
+			best[variant] = Candidate{Code: variant, Edits: edits, Cost: cost}
+		}
+	}
+
+	add(code)
+	for _, variant := range confusionVariants(code) {
+		add(variant, EditConfusion)
+	}
+	for i := 0; i+1 < len(code); i++ {
+		if code[i] != code[i+1] {
+			add(code[:i]+string(code[i+1])+string(code[i])+code[i+2:], EditTransposition)
+		}
+	}
+	for i := 0; i < len(code); i++ {
+		for _, c := range alphabet(i) {
+			if byte(c) != code[i] {
+				add(code[:i]+string(c)+code[i+1:], EditSubstitution)
+			}
+		}
+	}
+	if len(code) == 7 || len(code) == 10 {
+		for i := 0; i <= len(code); i++ {
+			for _, c := range alphabet(i) {
+				add(code[:i]+string(c)+code[i:], EditInsertion)
+			}
+		}
+	}
+	if len(code) == 9 || len(code) == 12 {
+		for i := 0; i < len(code); i++ {
+			add(code[:i]+code[i+1:], EditDeletion)
+		}
+	}
+
+	candidates := make([]Candidate, 0, len(best))
+	for _, candidate := range best {
+		candidates = append(candidates, candidate)
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].Cost != candidates[j].Cost {
+			return candidates[i].Cost < candidates[j].Cost
+		}
+		return candidates[i].Code < candidates[j].Code
+	})
+	return candidates
+}
+
+// confusionVariants swaps every combination of the look-alike characters, the country code is made of letters only
+func confusionVariants(code string) []string {
+	positions := []int{}
+	for i := 0; i < len(code) && len(positions) < maxConfusions; i++ {
+		if _, ok := confusions[code[i]]; ok && (i < 4 || i > 5) {
+			positions = append(positions, i)
+		}
+	}
+
+	variants := []string{}
+	for mask := 1; mask < 1<<len(positions); mask++ {
+		variant := []byte(code)
+		for bit, i := range positions {
+			if mask&(1<<bit) != 0 {
+				variant[i] = confusions[variant[i]]
+			}
+		}
+		variants = append(variants, string(variant))
+	}
+	return variants
+}
+
+// alphabet of the position, the country code is made of letters
+func alphabet(position int) string {
+	if position == 4 || position == 5 {
+		return letters
+	}
+	return letters + digits
+}
+
+// wellFormed checks the length and the characters of a BIC11
+func wellFormed(code string) bool {
+	if len(code) != 11 {
+		return false
+	}
+	for i := 0; i < len(code); i++ {
+		c := code[i]
+		isLetter := c >= 'A' && c <= 'Z'
+		if !isLetter && (i == 4 || i == 5 || c < '0' || c > '9') {
+			return false
+		}
+	}
+	return true
+}
+
+// Distance is the edit distance of the codes, counting a transposition of neighbours as a single edit (the optimal
+// string alignment distance)
+func Distance(a, b string) int {
+	rows := make([][]int, len(a)+1)
+	for i := range rows {
+		rows[i] = make([]int, len(b)+1)
+		rows[i][0] = i
+	}
+	for j := range rows[0] {
+		rows[0][j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			rows[i][j] = min(rows[i-1][j]+1, rows[i][j-1]+1, rows[i-1][j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				rows[i][j] = min(rows[i][j], rows[i-2][j-2]+1)
+			}
+		}
+	}
+	return rows[len(a)][len(b)]
+}
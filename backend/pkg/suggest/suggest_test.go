@@ -0,0 +1,100 @@
+package suggest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func find(candidates []Candidate, code string) (Candidate, bool) {
+	for _, candidate := range candidates {
+		if candidate.Code == code {
+			return candidate, true
+		}
+	}
+	return Candidate{}, false
+}
+
+func TestCandidates(t *testing.T) {
+	tests := []struct {
+		name     string
+		code     string
+		expected Candidate
+	}{
+		{
+			name:     "Primary office of a BIC8",
+			code:     "tpeoplpw",
+			expected: Candidate{Code: "TPEOPLPWXXX", Edits: []string{EditPrimaryOffice}, Cost: 0.25},
+		},
+		{
+			name:     "Look-alike characters",
+			code:     "TPE0PLPWP6S",
+			expected: Candidate{Code: "TPEOPLPWP6S", Edits: []string{EditConfusion}, Cost: 0.5},
+		},
+		{
+			name:     "Several look-alike characters",
+			code:     "TPE0PLPWPIO",
+			expected: Candidate{Code: "TPEOPLPWP1O", Edits: []string{EditConfusion}, Cost: 0.5},
+		},
+		{
+			name:     "Transposition",
+			code:     "TPOEPLPWP65",
+			expected: Candidate{Code: "TPEOPLPWP65", Edits: []string{EditTransposition}, Cost: 0.75},
+		},
+		{
+			name:     "Substitution",
+			code:     "TPEOPLPWP66",
+			expected: Candidate{Code: "TPEOPLPWP65", Edits: []string{EditSubstitution}, Cost: 1},
+		},
+		{
+			name:     "Missing character",
+			code:     "TPEOPLPWP5",
+			expected: Candidate{Code: "TPEOPLPWP65", Edits: []string{EditInsertion}, Cost: 1},
+		},
+		{
+			name:     "Extra character",
+			code:     "TPEOPLPWP655",
+			expected: Candidate{Code: "TPEOPLPWP65", Edits: []string{EditDeletion}, Cost: 1},
+		},
+		{
+			name:     "Transposed BIC8",
+			code:     "TPEOPLWP",
+			expected: Candidate{Code: "TPEOPLPWXXX", Edits: []string{EditTransposition, EditPrimaryOffice}, Cost: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			candidate, ok := find(Candidates(tt.code), tt.expected.Code)
+			require.True(t, ok)
+			assert.Equal(t, tt.expected, candidate)
+		})
+	}
+}
+
+func TestCandidatesOrder(t *testing.T) {
+	candidates := Candidates("TPE0PLPWXXX")
+	require.NotEmpty(t, candidates)
+	assert.Equal(t, "TPEOPLPWXXX", candidates[0].Code)
+	for i := 1; i < len(candidates); i++ {
+		assert.LessOrEqual(t, candidates[i-1].Cost, candidates[i].Cost)
+	}
+
+	// The code itself and the malformed codes aren't candidates
+	_, ok := find(candidates, "TPE0PLPWXXX")
+	assert.False(t, ok)
+	for _, candidate := range candidates {
+		assert.Len(t, candidate.Code, 11)
+		assert.NotContains(t, "0123456789", candidate.Code[4:5])
+	}
+	assert.Empty(t, Candidates("ABC"))
+}
+
+func TestDistance(t *testing.T) {
+	assert.Equal(t, 0, Distance("TPEOPLPWXXX", "TPEOPLPWXXX"))
+	assert.Equal(t, 1, Distance("TPOEPLPWXXX", "TPEOPLPWXXX"))
+	assert.Equal(t, 1, Distance("TPEOPLPWXX", "TPEOPLPWXXX"))
+	assert.Equal(t, 2, Distance("TPE0PLPWXX1", "TPEOPLPWXXX"))
+	assert.Equal(t, 3, Distance("TPEOPLPW", "TPEOPLPWXXX"))
+}
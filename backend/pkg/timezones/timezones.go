@@ -0,0 +1,178 @@
+// Package timezones exposes the country assignment of the IANA time zones, based on the tz database tables
+package timezones
+
+import (
+	"bufio"
+	"bytes"
+	_ "embed"
+	"fmt"
+	"strings"
+	"time"
+
+	// The alpine image doesn't ship the system tz database, so time.LoadLocation needs the embedded one
+	_ "time/tzdata"
+)
+
+//go:embed data/zone1970.tab
+var zone1970Table []byte
+
+//go:embed data/zone.tab
+var zoneTable []byte
+
+//go:embed data/backward.tab
+var backwardTable []byte
+
+var (
+	// Own zones of each country in the order of zone.tab, which puts the zone of most of the country first
+	countryZones = make(map[string][]string)
+	// Countries in which each zone is used, including the countries sharing a zone of a neighbour in zone1970.tab
+	zoneCountries = make(map[string][]string)
+	// Countries whose zones all keep the time of the first one, such as Europe/Busingen in DE
+	singleTimeCountries = make(map[string]bool)
+	// Deprecated name to the canonical name
+	aliases = make(map[string]string)
+)
+
+func init() {
+	// zone.tab lists the own zones of every country, zone1970.tab merges the zones which only differ before 1970 and
+	// assigns them to every country using them (e.g. Europe/Brussels to NL), which only counts for the membership
+	parseTable(zoneTable, func(fields []string) {
+		countryZones[fields[0]] = append(countryZones[fields[0]], fields[2])
+		addCountry(fields[2], fields[0])
+	})
+	parseTable(zone1970Table, func(fields []string) {
+		for _, country := range strings.Split(fields[0], ",") {
+			addCountry(fields[2], country)
+		}
+	})
+	parseTable(backwardTable, func(fields []string) {
+		aliases[fields[1]] = fields[0]
+	})
+
+	for country, zones := range countryZones {
+		singleTimeCountries[country] = keepSameTime(zones)
+	}
+}
+
+func parseTable(table []byte, handleRow func(fields []string)) {
+	scanner := bufio.NewScanner(bytes.NewReader(table))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Split(line, "\t")
+		if len(fields) < 2 {
+			continue
+		}
+		handleRow(fields)
+	}
+}
+
+func addCountry(zone, country string) {
+	for _, existing := range zoneCountries[zone] {
+		if existing == country {
+			return
+		}
+	}
+	zoneCountries[zone] = append(zoneCountries[zone], country)
+}
+
+// keepSameTime checks whether the zones keep the time of the first one on the first day of every month of the year
+func keepSameTime(zones []string) bool {
+	first, err := Load(zones[0])
+	if err != nil {
+		return false
+	}
+	year := time.Now().Year()
+	for _, zone := range zones[1:] {
+		location, err := Load(zone)
+		if err != nil {
+			return false
+		}
+		for month := time.January; month <= time.December; month++ {
+			at := time.Date(year, month, 1, 12, 0, 0, 0, time.UTC)
+			_, firstOffset := at.In(first).Zone()
+			if _, offset := at.In(location).Zone(); offset != firstOffset {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Load returns the location of a zone name, rejecting the names which time.LoadLocation treats specially
+func Load(name string) (*time.Location, error) {
+	if name == "" || name == "Local" {
+		return nil, fmt.Errorf("unknown time zone %s", name)
+	}
+	return time.LoadLocation(name)
+}
+
+// Canonical returns the canonical name of a deprecated alias, the second value is false if the name is not an alias
+func Canonical(name string) (string, bool) {
+	canonical, ok := aliases[name]
+	return canonical, ok
+}
+
+// ZonesForCountry returns the own zones of a country in the order of zone.tab, the zone of most of the country first.
+// The zones the country shares with its neighbours in zone1970.tab (e.g. Europe/Zurich in DE) aren't included
+func ZonesForCountry(countryISO2 string) []string {
+	zones := countryZones[strings.ToUpper(countryISO2)]
+	result := make([]string, len(zones))
+	copy(result, zones)
+	return result
+}
+
+// CountriesForZone returns the countries in which the zone is used, per zone.tab and zone1970.tab
+func CountriesForZone(zone string) []string {
+	countries := zoneCountries[zone]
+	result := make([]string, len(countries))
+	copy(result, countries)
+	return result
+}
+
+// BelongsToCountry checks whether the zone is used in the country, either as its own zone or as a zone it shares with
+// a neighbour in zone1970.tab
+func BelongsToCountry(zone, countryISO2 string) bool {
+	countryISO2 = strings.ToUpper(countryISO2)
+	for _, country := range zoneCountries[zone] {
+		if country == countryISO2 {
+			return true
+		}
+	}
+	return false
+}
+
+// InferZone picks the zone of a town in a country. Countries whose own zones all keep the same time don't need the
+// town and get the zone of most of the country, otherwise the town is matched against the city part of the zone names (e.g. CHICAGO for America/Chicago) and against the main
+// banking towns of the country (e.g. DALLAS). An empty string is returned when the zone can't be told, for the
+// towns which match nothing and for the countries missing from the tables
+func InferZone(countryISO2, townName string) string {
+	countryISO2 = strings.ToUpper(countryISO2)
+	zones := ZonesForCountry(countryISO2)
+	if len(zones) == 0 {
+		return ""
+	}
+	if singleTimeCountries[countryISO2] {
+		return zones[0]
+	}
+
+	town := normalizeCity(townName)
+	if town == "" {
+		return ""
+	}
+	for _, zone := range zones {
+		if normalizeCity(zone[strings.LastIndex(zone, "/")+1:]) == town {
+			return zone
+		}
+	}
+	return townZones[countryISO2][town]
+}
+
+// normalizeCity brings both the zone cities (Sao_Paulo) and the town names (SAO PAULO) to the same form
+func normalizeCity(city string) string {
+	city = strings.ToUpper(strings.TrimSpace(city))
+	city = strings.NewReplacer("_", " ", "-", " ", ".", "").Replace(city)
+	return strings.Join(strings.Fields(city), " ")
+}
@@ -0,0 +1,87 @@
+package timezones
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestZonesForCountry(t *testing.T) {
+	assert.Equal(t, []string{"Europe/Warsaw"}, ZonesForCountry("pl"))
+
+	zones := ZonesForCountry("US")
+	assert.Equal(t, "America/New_York", zones[0])
+	assert.Contains(t, zones, "America/Los_Angeles")
+
+	// The zones shared with the neighbours in zone1970.tab aren't the own zones of the country
+	assert.Equal(t, []string{"Europe/Amsterdam"}, ZonesForCountry("NL"))
+	assert.Equal(t, []string{"Europe/Berlin", "Europe/Busingen"}, ZonesForCountry("DE"))
+	assert.Equal(t, []string{"Atlantic/Reykjavik"}, ZonesForCountry("IS"))
+
+	assert.Empty(t, ZonesForCountry("XX"))
+}
+
+func TestBelongsToCountry(t *testing.T) {
+	assert.True(t, BelongsToCountry("Europe/Warsaw", "PL"))
+	assert.True(t, BelongsToCountry("Europe/Zurich", "DE"))
+	assert.True(t, BelongsToCountry("Europe/Brussels", "NL"))
+	assert.True(t, BelongsToCountry("Europe/Amsterdam", "NL"))
+	assert.True(t, BelongsToCountry("Africa/Abidjan", "IS"))
+	assert.False(t, BelongsToCountry("Europe/Warsaw", "DE"))
+	assert.Equal(t, []string{"PL"}, CountriesForZone("Europe/Warsaw"))
+}
+
+func TestCanonical(t *testing.T) {
+	canonical, ok := Canonical("Europe/Kiev")
+	assert.True(t, ok)
+	assert.Equal(t, "Europe/Kyiv", canonical)
+
+	_, ok = Canonical("Europe/Kyiv")
+	assert.False(t, ok)
+}
+
+func TestLoad(t *testing.T) {
+	location, err := Load("Europe/Warsaw")
+	assert.NoError(t, err)
+	assert.Equal(t, "Europe/Warsaw", location.String())
+
+	_, err = Load("Local")
+	assert.EqualError(t, err, "unknown time zone Local")
+
+	_, err = Load("Europe/Warsaw21")
+	assert.Error(t, err)
+}
+
+func TestInferZone(t *testing.T) {
+	assert.Equal(t, "Europe/Warsaw", InferZone("PL", "WARSZAWA"))
+	assert.Equal(t, "America/Chicago", InferZone("US", "CHICAGO"))
+	assert.Equal(t, "America/Los_Angeles", InferZone("us", "los angeles"))
+	assert.Equal(t, "America/Sao_Paulo", InferZone("BR", "SAO PAULO"))
+	assert.Equal(t, "Europe/Moscow", InferZone("RU", "MOSCOW"))
+
+	// Countries sharing the zones of their neighbours in zone1970.tab get their own zone
+	assert.Equal(t, "Europe/Berlin", InferZone("DE", "FRANKFURT"))
+	assert.Equal(t, "Europe/Amsterdam", InferZone("NL", "ROTTERDAM"))
+	assert.Equal(t, "Europe/Stockholm", InferZone("SE", "GOTEBORG"))
+	assert.Equal(t, "Atlantic/Reykjavik", InferZone("IS", "REYKJAVIK"))
+	assert.Equal(t, "Europe/Oslo", InferZone("NO", ""))
+
+	// Towns missing from the zone names
+	assert.Equal(t, "America/Chicago", InferZone("US", "DALLAS"))
+	assert.Equal(t, "Europe/Moscow", InferZone("RU", "Saint-Petersburg"))
+	assert.Equal(t, "America/Sao_Paulo", InferZone("BR", "RIO DE JANEIRO"))
+
+	// The zone of an unknown town of a country with several zones can't be told
+	assert.Equal(t, "", InferZone("US", "SPRINGFIELD"))
+	assert.Equal(t, "", InferZone("RU", ""))
+	assert.Equal(t, "", InferZone("XX", "NOWHERE"))
+}
+
+func TestTownZones(t *testing.T) {
+	for country, towns := range townZones {
+		for town, zone := range towns {
+			assert.True(t, BelongsToCountry(zone, country), "%s of %s", zone, town)
+			assert.Equal(t, normalizeCity(town), town)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package timezones
+
+// townZones are the zones of the main banking towns of the countries spanning several zones, whose names don't match
+// the city part of a zone name. The town names are normalized
+var townZones = map[string]map[string]string{
+	"AU": {
+		"ADELAIDE": "Australia/Adelaide",
+		"BRISBANE": "Australia/Brisbane",
+		"CANBERRA": "Australia/Sydney",
+		"DARWIN":   "Australia/Darwin",
+		"HOBART":   "Australia/Hobart",
+		"PERTH":    "Australia/Perth",
+	},
+	"BR": {
+		"BELEM":          "America/Belem",
+		"BELO HORIZONTE": "America/Sao_Paulo",
+		"BRASILIA":       "America/Sao_Paulo",
+		"CURITIBA":       "America/Sao_Paulo",
+		"FLORIANOPOLIS":  "America/Sao_Paulo",
+		"PORTO ALEGRE":   "America/Sao_Paulo",
+		"RIO DE JANEIRO": "America/Sao_Paulo",
+		"SALVADOR":       "America/Bahia",
+	},
+	"CA": {
+		"CALGARY":  "America/Edmonton",
+		"MONTREAL": "America/Toronto",
+		"OTTAWA":   "America/Toronto",
+		"QUEBEC":   "America/Toronto",
+	},
+	"KZ": {
+		"ASTANA":     "Asia/Almaty",
+		"NUR SULTAN": "Asia/Almaty",
+	},
+	"MX": {
+		"CIUDAD DE MEXICO": "America/Mexico_City",
+		"GUADALAJARA":      "America/Mexico_City",
+		"MEXICO":           "America/Mexico_City",
+		"PUEBLA":           "America/Mexico_City",
+	},
+	"RU": {
+		"EKATERINBURG":     "Asia/Yekaterinburg",
+		"KAZAN":            "Europe/Moscow",
+		"NIZHNIY NOVGOROD": "Europe/Moscow",
+		"NIZHNY NOVGOROD":  "Europe/Moscow",
+		"ROSTOV ON DON":    "Europe/Moscow",
+		"SAINT PETERSBURG": "Europe/Moscow",
+		"SANKT PETERBURG":  "Europe/Moscow",
+		"ST PETERSBURG":    "Europe/Moscow",
+		"KHABAROVSK":       "Asia/Vladivostok",
+	},
+	"US": {
+		"ATLANTA":        "America/New_York",
+		"AUSTIN":         "America/Chicago",
+		"BALTIMORE":      "America/New_York",
+		"BOSTON":         "America/New_York",
+		"CHARLOTTE":      "America/New_York",
+		"CLEVELAND":      "America/New_York",
+		"DALLAS":         "America/Chicago",
+		"HOUSTON":        "America/Chicago",
+		"KANSAS CITY":    "America/Chicago",
+		"LAS VEGAS":      "America/Los_Angeles",
+		"MIAMI":          "America/New_York",
+		"MINNEAPOLIS":    "America/Chicago",
+		"NEW ORLEANS":    "America/Chicago",
+		"PHILADELPHIA":   "America/New_York",
+		"PITTSBURGH":     "America/New_York",
+		"PORTLAND":       "America/Los_Angeles",
+		"SALT LAKE CITY": "America/Denver",
+		"SAN ANTONIO":    "America/Chicago",
+		"SAN DIEGO":      "America/Los_Angeles",
+		"SAN FRANCISCO":  "America/Los_Angeles",
+		"SEATTLE":        "America/Los_Angeles",
+		"ST LOUIS":       "America/Chicago",
+		"SAINT LOUIS":    "America/Chicago",
+		"WASHINGTON":     "America/New_York",
+		"WILMINGTON":     "America/New_York",
+	},
+}
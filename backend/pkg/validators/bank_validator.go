@@ -1,97 +1,71 @@
 package validators
 
 import (
-	"fmt"
-	"regexp"
 	"strconv"
-	"strings"
 )
 
 type BankRequestValidator struct {
-	swiftValidator    *SwiftCodeValidator
-	countryValidator  *CountryISO2CodeValidator
-	timeZoneValidator *TimeZoneValidator
+	swiftValidator       *SwiftCodeValidator
+	countryValidator     *CountryISO2CodeValidator
+	countryNameValidator *CountryNameValidator
+	timeZoneValidator    *TimeZoneValidator
 	// codeTypeValidator *CodeTypeValidator
+	rules *Registry
 }
 
 func NewBankRequestValidator() *BankRequestValidator {
-	return &BankRequestValidator{
-		swiftValidator:    NewSwiftCodeValidator(),
-		countryValidator:  NewCountryISO2CodeValidator(),
-		timeZoneValidator: NewTimeZoneValidator(),
+	v := &BankRequestValidator{
+		swiftValidator:       NewSwiftCodeValidator(),
+		countryValidator:     NewCountryISO2CodeValidator(),
+		countryNameValidator: NewCountryNameValidator(),
+		timeZoneValidator:    NewTimeZoneValidator(),
 		// codeTypeValidator: NewCodeTypeValidator(),
 	}
+	v.rules = v.bankRules()
+	return v
 }
 
-func (v *BankRequestValidator) ValidateAndSanitize(data map[string]interface{}) error {
-	// Helper function to get string value from map
-	getString := func(key string) (string, error) {
-		val, ok := data[key]
-		if !ok {
-			return "", fmt.Errorf("%s is required", key)
-		}
-		str, ok := val.(string)
-		if !ok || strings.TrimSpace(str) == "" {
-			return "", fmt.Errorf("%s must be a non-empty string", key)
-		}
-		return str, nil
-	}
-
-	// No field should contain $, { or }, since that could lead to a MongoDB injection
-	illegalCharacters := regexp.MustCompile(`(\$|\}|\{})`)
-	var validationErrors []string
-
-	for key, fieldInterface := range data {
-		field, ok := fieldInterface.(string)
-		if !ok {
-			continue
-		}
-		if illegalCharacters.MatchString(field) {
-			validationErrors = append(validationErrors, strings.TrimSpace(fmt.Sprintf("field %s contains illegal value: %s", key, field)))
-		}
-	}
-
-	if len(validationErrors) > 0 {
-		return fmt.Errorf("validation errors:\n%s", strings.Join(validationErrors, "\n"))
-	}
-
+// bankRules composes the rules of a bank entry, the order of the fields is the order of the reported errors
+func (v *BankRequestValidator) bankRules() *Registry {
 	// Required fields
 	// I don't have valid ideas for address verification and I don't want to make it to complex
-	if _, err := getString("address"); err != nil {
-		return err
-	}
-	// Looking through the data
-	if _, err := getString("bankName"); err != nil {
-		return err
-	}
-	countryISO2, err := getString("countryISO2")
-	if err != nil {
-		return err
-	}
-	swiftCode, err := getString("swiftCode")
-	if err != nil {
-		return err
-	}
+	return NewRegistry().
+		Require("address", "bankName", "countryISO2", "swiftCode").
+		Register("countryISO2", CodeInvalidFormat, "countryISO2 invalid", v.countryValidator).
+		// Names differing from ISO 3166 are only flagged, the canonical name is stored anyway
+		Warn("countryName", CodeMismatch, "countryName mismatch", v.countryNameValidator).
+		Register("swiftCode", CodeInvalidFormat, "swiftCode invalid", v.swiftValidator).
+		Register("swiftCode", CodeMismatch, "swiftCode and countryISO2 mismatch", ValidatorFunc(v.validateSwiftCountry)).
+		// We can ignore any errors, if it's not present it will be infered from the SWFIT code itself
+		Register("isHeadquarter", CodeMismatch, "isHeadquarter and swift code mismatch", ValidatorFunc(v.validateIsHeadquarter)).
+		Register("timeZone", CodeInvalidFormat, "timeZone invalid", v.timeZoneValidator)
+}
 
-	// We can ignore any errors, if it's not present it will be infered from the SWFIT code itself
-	isHeadquarter := getBool(data, "isHeadquarter")
+func (v *BankRequestValidator) ValidateAndSanitize(data map[string]interface{}) error {
+	return v.Validate(data).Err()
+}
 
-	if err := v.countryValidator.Validate(countryISO2); err != nil {
-		return fmt.Errorf("countryISO2 invalid: %w", err)
-	}
+// Validate returns every failing field of the bank entry
+func (v *BankRequestValidator) Validate(data map[string]interface{}) *ValidationResult {
+	return v.rules.Validate(data)
+}
 
-	if err := v.swiftValidator.Validate(swiftCode); err != nil {
-		return fmt.Errorf("swiftCode invalid: %w", err)
-	}
-	if err := v.swiftValidator.ValidateWithCountryCode(swiftCode, countryISO2); err != nil {
-		return fmt.Errorf("swiftCode and countryISO2 mismatch: %w", err)
+func (v *BankRequestValidator) validateSwiftCountry(value interface{}, record map[string]interface{}) error {
+	countryISO2, _ := record["countryISO2"].(string)
+	// An invalid country code is already reported on its own field
+	if v.countryValidator.Validate(countryISO2, record) != nil {
+		return nil
 	}
+	return v.swiftValidator.ValidateWithCountryCode(value, countryISO2)
+}
 
-	if err := v.swiftValidator.ValidateWithIsHeadquarter(swiftCode, isHeadquarter); err != nil {
-		return fmt.Errorf("isHeadquarter and swift code mismatch: %w", err)
+func (v *BankRequestValidator) validateIsHeadquarter(value interface{}, record map[string]interface{}) error {
+	swiftCode, _ := record["swiftCode"].(string)
+	// An invalid SWIFT code is already reported on its own field
+	if v.swiftValidator.Validate(swiftCode, record) != nil {
+		return nil
 	}
-
-	return nil
+	return v.swiftValidator.ValidateWithIsHeadquarter(swiftCode, getBool(record, "isHeadquarter"))
 }
 
 // Helper function to safely extract boolean values from map
@@ -0,0 +1,96 @@
+package validators
+
+import (
+	"fmt"
+	"strings"
+)
+
+// BICAnalysis is the ISO 9362 decomposition of a BIC
+//
+//	DEUT  DE  FF  500
+//	 |     |   |   └ branch code (optional, XXX is the primary office)
+//	 |     |   └ location code
+//	 |     └ country code
+//	 └ institution (business party prefix)
+type BICAnalysis struct {
+	SwiftCode            string   `json:"swiftCode"`
+	BIC8                 string   `json:"bic8"`
+	InstitutionCode      string   `json:"institutionCode"`
+	CountryCode          string   `json:"countryCode"`
+	LocationCode         string   `json:"locationCode"`
+	BranchCode           string   `json:"branchCode"`
+	IsPrimaryOffice      bool     `json:"isPrimaryOffice"`
+	IsTestAndTraining    bool     `json:"isTestAndTraining"`
+	IsPassiveParticipant bool     `json:"isPassiveParticipant"`
+	IsReverseBilling     bool     `json:"isReverseBilling"`
+	IsReservedBranchCode bool     `json:"isReservedBranchCode"`
+	Issues               []string `json:"issues"`
+}
+
+// Branch code of the primary office, it's implied for BIC8 codes
+const PrimaryOfficeBranchCode = "XXX"
+
+// NormalizeSwiftCode uppercases the code and extends a BIC8 to the BIC11 of its primary office, the form the banks
+// are stored with. The code isn't validated
+func NormalizeSwiftCode(code string) string {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if len(code) == 8 {
+		code += PrimaryOfficeBranchCode
+	}
+	return code
+}
+
+type BICAnalyzer struct {
+	swiftValidator *SwiftCodeValidator
+}
+
+func NewBICAnalyzer() *BICAnalyzer {
+	return &BICAnalyzer{
+		swiftValidator: NewSwiftCodeValidator(),
+	}
+}
+
+// Analyze decomposes a structurally valid BIC and classifies it, the code doesn't have to be registered anywhere
+func (a *BICAnalyzer) Analyze(code string) (*BICAnalysis, error) {
+	if err := a.swiftValidator.Validate(code, nil); err != nil {
+		return nil, err
+	}
+
+	analysis := &BICAnalysis{
+		SwiftCode:       code,
+		BIC8:            code[:8],
+		InstitutionCode: code[:4],
+		CountryCode:     code[4:6],
+		LocationCode:    code[6:8],
+		BranchCode:      PrimaryOfficeBranchCode,
+		Issues:          []string{},
+	}
+	if len(code) == 11 {
+		analysis.BranchCode = code[8:]
+	}
+
+	// The second character of the location code carries the participant type
+	switch analysis.LocationCode[1] {
+	case '0':
+		analysis.IsTestAndTraining = true
+	case '1':
+		analysis.IsPassiveParticipant = true
+	case '2':
+		analysis.IsReverseBilling = true
+	}
+
+	// 0 and 1 are not assigned as the first location character, so that they don't collide with the flags above
+	if first := analysis.LocationCode[0]; first == '0' || first == '1' {
+		analysis.Issues = append(analysis.Issues, fmt.Sprintf("location code %s cannot start with %c", analysis.LocationCode, first))
+	}
+
+	analysis.IsPrimaryOffice = analysis.BranchCode == PrimaryOfficeBranchCode
+
+	// Branch codes starting with X are reserved, XXX being the only one in use
+	if analysis.BranchCode[0] == 'X' && !analysis.IsPrimaryOffice {
+		analysis.IsReservedBranchCode = true
+		analysis.Issues = append(analysis.Issues, fmt.Sprintf("branch code %s is reserved", analysis.BranchCode))
+	}
+
+	return analysis, nil
+}
@@ -0,0 +1,69 @@
+package validators
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBICAnalyzer_Decomposition(t *testing.T) {
+	analyzer := NewBICAnalyzer()
+
+	analysis, err := analyzer.Analyze("DEUTDEFF500")
+	require.NoError(t, err)
+	assert.Equal(t, "DEUTDEFF", analysis.BIC8)
+	assert.Equal(t, "DEUT", analysis.InstitutionCode)
+	assert.Equal(t, "DE", analysis.CountryCode)
+	assert.Equal(t, "FF", analysis.LocationCode)
+	assert.Equal(t, "500", analysis.BranchCode)
+	assert.False(t, analysis.IsPrimaryOffice)
+	assert.False(t, analysis.IsTestAndTraining)
+	assert.False(t, analysis.IsPassiveParticipant)
+	assert.Empty(t, analysis.Issues)
+
+	analysis, err = analyzer.Analyze("DEUTDEFF")
+	require.NoError(t, err)
+	assert.Equal(t, PrimaryOfficeBranchCode, analysis.BranchCode)
+	assert.True(t, analysis.IsPrimaryOffice)
+}
+
+func TestBICAnalyzer_ParticipantFlags(t *testing.T) {
+	analyzer := NewBICAnalyzer()
+
+	analysis, err := analyzer.Analyze("DEUTDEF0XXX")
+	require.NoError(t, err)
+	assert.True(t, analysis.IsTestAndTraining)
+
+	analysis, err = analyzer.Analyze("ABIEBGS1XXX")
+	require.NoError(t, err)
+	assert.True(t, analysis.IsPassiveParticipant)
+
+	analysis, err = analyzer.Analyze("AAISALT2XXX")
+	require.NoError(t, err)
+	assert.True(t, analysis.IsReverseBilling)
+}
+
+func TestBICAnalyzer_Issues(t *testing.T) {
+	analyzer := NewBICAnalyzer()
+
+	analysis, err := analyzer.Analyze("DEUTDEFFXAB")
+	require.NoError(t, err)
+	assert.True(t, analysis.IsReservedBranchCode)
+	assert.Equal(t, []string{"branch code XAB is reserved"}, analysis.Issues)
+
+	analysis, err = analyzer.Analyze("DEUTDE1FXXX")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"location code 1F cannot start with 1"}, analysis.Issues)
+
+	_, err = analyzer.Analyze("DEUT")
+	assert.EqualError(t, err, "invalid SWIFT code length: 4")
+}
+
+func TestNormalizeSwiftCode(t *testing.T) {
+	assert.Equal(t, "DEUTDEFFXXX", NormalizeSwiftCode("DEUTDEFF"))
+	assert.Equal(t, "DEUTDEFF500", NormalizeSwiftCode(" deutdeff500 "))
+	assert.Equal(t, "DEUTDEFFXXX", NormalizeSwiftCode("DEUTDEFFXXX"))
+	// Malformed codes are only uppercased
+	assert.Equal(t, "DEUT", NormalizeSwiftCode("deut"))
+}
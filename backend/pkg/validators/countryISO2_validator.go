@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+
+	"github.com/Hbrtjm/SWIFT_API/backend/pkg/iso3166"
 )
 
 // CountryCodeValidator validates country codes
@@ -14,7 +16,7 @@ func NewCountryISO2CodeValidator() *CountryISO2CodeValidator {
 	return &CountryISO2CodeValidator{}
 }
 
-func (ccv *CountryISO2CodeValidator) Validate(value interface{}) error {
+func (ccv *CountryISO2CodeValidator) Validate(value interface{}, record map[string]interface{}) error {
 	countryISO2, ok := value.(string)
 	if !ok {
 		return errors.New("country code must be a string")
@@ -33,5 +35,9 @@ func (ccv *CountryISO2CodeValidator) Validate(value interface{}) error {
 		return fmt.Errorf("invalid country code format: %s", countryISO2)
 	}
 
+	if _, known := iso3166.Lookup(countryISO2); !known {
+		return fmt.Errorf("unknown country code: %s", countryISO2)
+	}
+
 	return nil
 }
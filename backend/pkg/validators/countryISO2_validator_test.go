@@ -12,12 +12,12 @@ func TestValidation(t *testing.T) {
 	validator := NewCountryISO2CodeValidator()
 
 	correctValue := "PL"
-	assert.Equal(t, nil, validator.Validate(correctValue))
-	assert.NoError(t, validator.Validate(correctValue))
-	assert.Equal(t, errors.New("country code must be a string"), validator.Validate(1))
+	assert.Equal(t, nil, validator.Validate(correctValue, nil))
+	assert.NoError(t, validator.Validate(correctValue, nil))
+	assert.Equal(t, errors.New("country code must be a string"), validator.Validate(1, nil))
 
 	incorrectValue := "ABC"
-	assert.Equal(t, fmt.Errorf("country code is too long: %d", len(incorrectValue)), validator.Validate(incorrectValue))
+	assert.Equal(t, fmt.Errorf("country code is too long: %d", len(incorrectValue)), validator.Validate(incorrectValue, nil))
 	incorrectValue = "A"
-	assert.Equal(t, fmt.Errorf("country code is too short: %d", len(incorrectValue)), validator.Validate(incorrectValue))
+	assert.Equal(t, fmt.Errorf("country code is too short: %d", len(incorrectValue)), validator.Validate(incorrectValue, nil))
 }
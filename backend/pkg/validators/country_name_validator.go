@@ -0,0 +1,40 @@
+package validators
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Hbrtjm/SWIFT_API/backend/pkg/iso3166"
+)
+
+// CountryNameValidator compares a country name with the ISO 3166 name of the countryISO2 in the record
+type CountryNameValidator struct{}
+
+func NewCountryNameValidator() *CountryNameValidator {
+	return &CountryNameValidator{}
+}
+
+func (cnv *CountryNameValidator) Validate(value interface{}, record map[string]interface{}) error {
+	countryName, ok := value.(string)
+	if !ok {
+		return errors.New("country name must be a string")
+	}
+
+	countryISO2, _ := record["countryISO2"].(string)
+	country, known := iso3166.Lookup(countryISO2)
+	// Unknown codes are reported by the country code validator
+	if !known {
+		return nil
+	}
+
+	if !country.MatchesName(countryName) {
+		return &RuleError{
+			Code:       CodeMismatch,
+			Message:    fmt.Sprintf("country name %s doesn't match the ISO 3166 name of %s: %s", countryName, country.Alpha2, country.Name),
+			Suggestion: strings.ToUpper(country.Name),
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,46 @@
+package validators
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCountryNameValidator(t *testing.T) {
+	validator := NewCountryNameValidator()
+
+	assert.NoError(t, validator.Validate("POLAND", map[string]interface{}{"countryISO2": "PL"}))
+	assert.NoError(t, validator.Validate("Republic of Poland", map[string]interface{}{"countryISO2": "PL"}))
+	// Without a known code there is nothing to compare with
+	assert.NoError(t, validator.Validate("POLAND", map[string]interface{}{"countryISO2": "XX"}))
+
+	assert.EqualError(t, validator.Validate("GERMANY", map[string]interface{}{"countryISO2": "PL"}),
+		"country name GERMANY doesn't match the ISO 3166 name of PL: Poland")
+	assert.EqualError(t, validator.Validate(1, nil), "country name must be a string")
+}
+
+func TestBankRequestValidator_CountryNameMismatchIsAWarning(t *testing.T) {
+	validator := NewBankRequestValidator()
+
+	result := validator.Validate(map[string]interface{}{
+		"address":       "123 Main St",
+		"bankName":      "Test Bank",
+		"countryISO2":   "DE",
+		"countryName":   "DEUTSCHLAND",
+		"swiftCode":     "DEUTDEFF",
+		"isHeadquarter": false,
+	})
+
+	assert.True(t, result.Valid())
+	require.Len(t, result.Warnings, 1)
+	assert.Equal(t, "countryName", result.Warnings[0].Field)
+	assert.Equal(t, "GERMANY", result.Warnings[0].Suggestion)
+}
+
+func TestCountryISO2CodeValidator_UnknownCode(t *testing.T) {
+	validator := NewCountryISO2CodeValidator()
+
+	assert.EqualError(t, validator.Validate("XX", nil), "unknown country code: XX")
+	assert.NoError(t, validator.Validate("de", nil))
+}
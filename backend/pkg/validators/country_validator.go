@@ -1,66 +1,38 @@
 package validators
 
-import (
-	"fmt"
-	"strings"
-)
-
 type CountryValidator struct {
 	codeTypeValidator        *CodeTypeValidator
 	timeZoneValidator        *TimeZoneValidator
 	countryISO2CodeValidator *CountryISO2CodeValidator
+	countryNameValidator     *CountryNameValidator
+	rules                    *Registry
 }
 
 func NewCountryValidator() *CountryValidator {
-	return &CountryValidator{
+	cv := &CountryValidator{
 		codeTypeValidator:        NewCodeTypeValidator(),
 		timeZoneValidator:        NewTimeZoneValidator(),
 		countryISO2CodeValidator: NewCountryISO2CodeValidator(),
+		countryNameValidator:     NewCountryNameValidator(),
 	}
+	cv.rules = cv.countryRules()
+	return cv
 }
 
-func (cv *CountryValidator) ValidateAndSanitize(data map[string]interface{}) error {
-	// Helper function to get string value from map
-	getString := func(key string) (string, error) {
-		val, ok := data[key]
-		if !ok {
-			return "", fmt.Errorf("%s is required", key)
-		}
-		str, ok := val.(string)
-		if !ok || strings.TrimSpace(str) == "" {
-			return "", fmt.Errorf("%s must be a non-empty string", key)
-		}
-		return str, nil
-	}
-
-	err := Sanitize(data)
-	if err != nil {
-		return err
-	}
-
-	// Required fields
-	// I don't have valid ideas for address verification and I don't want to make it to complex
-	if _, err := getString("countryName"); err != nil {
-		return err
-	}
-
-	codeType, err := getString("codeType")
-	if err != nil {
-		return err
-	}
-	timeZone, err := getString("timeZone")
-	if err != nil {
-		return err
-	}
-	countryName, _ := getString("countryName")
-
-	if err := cv.codeTypeValidator.Validate(codeType); err != nil {
-		return fmt.Errorf("codeType invalid: %w", err)
-	}
+func (cv *CountryValidator) countryRules() *Registry {
+	return NewRegistry().
+		Require("countryName", "codeType", "timeZone").
+		Register("countryISO2", CodeInvalidFormat, "countryISO2 invalid", cv.countryISO2CodeValidator).
+		Warn("countryName", CodeMismatch, "countryName mismatch", cv.countryNameValidator).
+		Register("codeType", CodeInvalidFormat, "codeType invalid", cv.codeTypeValidator).
+		Register("timeZone", CodeInvalidFormat, "timeZone invalid", cv.timeZoneValidator)
+}
 
-	if err := cv.timeZoneValidator.Validate(timeZone, countryName); err != nil {
-		return fmt.Errorf("timeZone invalid: %w", err)
-	}
+func (cv *CountryValidator) ValidateAndSanitize(data map[string]interface{}) error {
+	return cv.Validate(data).Err()
+}
 
-	return nil
+// Validate returns every failing field of the country entry
+func (cv *CountryValidator) Validate(data map[string]interface{}) *ValidationResult {
+	return cv.rules.Validate(data)
 }
@@ -3,26 +3,28 @@ package validators
 import (
 	"fmt"
 	"regexp"
-	"strings"
 )
 
+// No field should contain $, { or }, since that could lead to a MongoDB injection
+var illegalCharacters = regexp.MustCompile(`(\$|\}|\{)`)
+
 func Sanitize(data map[string]interface{}) error {
-	// No field should contain $, { or }, since that could lead to a MongoDB injection
-	illegalCharacters := regexp.MustCompile(`(\$|\}|\{)`)
-	var validationErrors []string
+	return SanitizeFields(data).Err()
+}
 
-	for key, fieldInterface := range data {
-		field, ok := fieldInterface.(string)
+// SanitizeFields reports every string field containing illegal characters
+func SanitizeFields(data map[string]interface{}) *ValidationResult {
+	result := NewValidationResult()
+
+	for _, key := range sortedKeys(data) {
+		field, ok := data[key].(string)
 		if !ok {
 			continue
 		}
 		if illegalCharacters.MatchString(field) {
-			validationErrors = append(validationErrors, fmt.Sprintf("field %s contains illegal value: %s", key, field))
+			result.Add(key, CodeIllegalCharacters, fmt.Sprintf("field %s contains illegal value: %s", key, field), field)
 		}
 	}
 
-	if len(validationErrors) > 0 {
-		return fmt.Errorf("validation errors:\n%s", strings.Join(validationErrors, "\n"))
-	}
-	return nil
+	return result
 }
@@ -0,0 +1,146 @@
+package validators
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Validator is the common interface of all of the field validators in this package.
+// The whole record is passed along with the value, so that a rule can cross-check related fields
+type Validator interface {
+	Validate(value interface{}, record map[string]interface{}) error
+}
+
+// ValidatorFunc allows plain functions to be registered as rules
+type ValidatorFunc func(value interface{}, record map[string]interface{}) error
+
+func (f ValidatorFunc) Validate(value interface{}, record map[string]interface{}) error {
+	return f(value, record)
+}
+
+type rule struct {
+	code        string
+	description string
+	validator   Validator
+	warning     bool
+}
+
+// Registry composes validators per field of an entity. Every field is checked and all of the
+// failures are reported, but only the first failing rule of a given field is kept
+type Registry struct {
+	fields   []string
+	required map[string]bool
+	rules    map[string][]rule
+}
+
+func NewRegistry() *Registry {
+	return &Registry{
+		fields:   []string{},
+		required: make(map[string]bool),
+		rules:    make(map[string][]rule),
+	}
+}
+
+// Require marks fields which must be present and non-empty
+func (r *Registry) Require(fields ...string) *Registry {
+	for _, field := range fields {
+		r.addField(field)
+		r.required[field] = true
+	}
+	return r
+}
+
+// Register adds a rule for a field, the description prefixes the validator error in the message
+func (r *Registry) Register(field, code, description string, validator Validator) *Registry {
+	r.addField(field)
+	r.rules[field] = append(r.rules[field], rule{
+		code:        code,
+		description: description,
+		validator:   validator,
+	})
+	return r
+}
+
+// Warn adds a rule whose failure is only reported as a warning, it doesn't stop the following rules of the field
+func (r *Registry) Warn(field, code, description string, validator Validator) *Registry {
+	r.addField(field)
+	r.rules[field] = append(r.rules[field], rule{
+		code:        code,
+		description: description,
+		validator:   validator,
+		warning:     true,
+	})
+	return r
+}
+
+func (r *Registry) addField(field string) {
+	if _, exists := r.rules[field]; exists || r.required[field] {
+		return
+	}
+	r.fields = append(r.fields, field)
+	r.rules[field] = []rule{}
+}
+
+// Validate runs the sanitizer and every registered rule against the record
+func (r *Registry) Validate(data map[string]interface{}) *ValidationResult {
+	result := SanitizeFields(data)
+
+	for _, field := range r.fields {
+		if result.HasField(field) {
+			continue
+		}
+
+		value, present := data[field]
+		if r.required[field] {
+			if !present {
+				result.Add(field, CodeRequired, fmt.Sprintf("%s is required", field), nil)
+				continue
+			}
+			if str, ok := value.(string); !ok || strings.TrimSpace(str) == "" {
+				result.Add(field, CodeEmpty, fmt.Sprintf("%s must be a non-empty string", field), value)
+				continue
+			}
+		} else if str, ok := value.(string); !present || (ok && strings.TrimSpace(str) == "") {
+			// Optional fields are only checked when they carry a value
+			continue
+		}
+
+		for _, fieldRule := range r.rules[field] {
+			if err := fieldRule.validator.Validate(value, data); err != nil {
+				fieldError := FieldError{
+					Field:   field,
+					Code:    fieldRule.code,
+					Message: fmt.Sprintf("%s: %v", fieldRule.description, err),
+					Value:   value,
+				}
+				var ruleError *RuleError
+				if errors.As(err, &ruleError) {
+					if ruleError.Code != "" {
+						fieldError.Code = ruleError.Code
+					}
+					fieldError.Suggestion = ruleError.Suggestion
+				}
+				if fieldRule.warning {
+					result.Warnings = append(result.Warnings, fieldError)
+					continue
+				}
+				result.Errors = append(result.Errors, fieldError)
+				break
+			}
+		}
+	}
+
+	return result
+}
+
+// sortedKeys keeps the reported errors in a stable order
+func sortedKeys(data map[string]interface{}) []string {
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,87 @@
+package validators
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistry_ReportsEveryFailingField(t *testing.T) {
+	registry := NewRegistry().
+		Require("name", "code").
+		Register("code", CodeInvalidFormat, "code invalid", NewCodeTypeValidator())
+
+	result := registry.Validate(map[string]interface{}{
+		"code": "BIC12",
+	})
+
+	require.False(t, result.Valid())
+	require.Len(t, result.Errors, 2)
+	assert.Equal(t, FieldError{Field: "name", Code: CodeRequired, Message: "name is required"}, result.Errors[0])
+	assert.Equal(t, "code", result.Errors[1].Field)
+	assert.Equal(t, CodeInvalidFormat, result.Errors[1].Code)
+	assert.Equal(t, "BIC12", result.Errors[1].Value)
+	assert.Equal(t, "name is required; code invalid: invalid code type format: BIC12", result.Error())
+}
+
+func TestRegistry_FirstFailingRulePerField(t *testing.T) {
+	calls := 0
+	failing := ValidatorFunc(func(value interface{}, record map[string]interface{}) error {
+		calls++
+		return errors.New("failed")
+	})
+
+	registry := NewRegistry().
+		Register("field", "first", "first rule", failing).
+		Register("field", "second", "second rule", failing)
+
+	result := registry.Validate(map[string]interface{}{"field": "value"})
+	require.Len(t, result.Errors, 1)
+	assert.Equal(t, "first", result.Errors[0].Code)
+	assert.Equal(t, 1, calls)
+}
+
+func TestRegistry_OptionalFieldsAreSkipped(t *testing.T) {
+	registry := NewRegistry().
+		Register("codeType", CodeInvalidFormat, "codeType invalid", NewCodeTypeValidator())
+
+	result := registry.Validate(map[string]interface{}{})
+	assert.True(t, result.Valid())
+	assert.NoError(t, result.Err())
+}
+
+func TestRegistry_IllegalCharactersSkipRules(t *testing.T) {
+	registry := NewRegistry().
+		Require("codeType").
+		Register("codeType", CodeInvalidFormat, "codeType invalid", NewCodeTypeValidator())
+
+	result := registry.Validate(map[string]interface{}{"codeType": "${BIC}"})
+	require.Len(t, result.Errors, 1)
+	assert.Equal(t, CodeIllegalCharacters, result.Errors[0].Code)
+}
+
+func TestBankRequestValidator_AllFieldsReported(t *testing.T) {
+	validator := NewBankRequestValidator()
+
+	data := map[string]interface{}{
+		"address":       "",
+		"countryISO2":   "DEU",
+		"swiftCode":     "INVALID",
+		"isHeadquarter": true,
+	}
+
+	err := validator.ValidateAndSanitize(data)
+	require.Error(t, err)
+
+	var result *ValidationResult
+	require.True(t, errors.As(err, &result))
+
+	fields := make([]string, 0, len(result.Errors))
+	for _, fieldError := range result.Errors {
+		fields = append(fields, fieldError.Field)
+	}
+	// The headquarter check depends on a valid SWIFT code, so it's not reported twice
+	assert.Equal(t, []string{"address", "bankName", "countryISO2", "swiftCode"}, fields)
+}
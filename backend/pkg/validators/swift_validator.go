@@ -12,7 +12,7 @@ func NewSwiftCodeValidator() *SwiftCodeValidator {
 	return &SwiftCodeValidator{}
 }
 
-func (sv *SwiftCodeValidator) Validate(value interface{}) error {
+func (sv *SwiftCodeValidator) Validate(value interface{}, record map[string]interface{}) error {
 	swiftCode, ok := value.(string)
 	if !ok {
 		return errors.New("swift code value must be a string")
@@ -31,7 +31,7 @@ func (sv *SwiftCodeValidator) Validate(value interface{}) error {
 }
 
 func (sv *SwiftCodeValidator) ValidateWithCountryCode(value interface{}, countryISO2 string) error {
-	err := sv.Validate(value)
+	err := sv.Validate(value, nil)
 	if err != nil {
 		return err
 	}
@@ -52,7 +52,7 @@ func (sv *SwiftCodeValidator) ValidateWithCountryCode(value interface{}, country
 // Generally it is assumed that the the isHeadquarter field would be evaluated on our side
 func (sv *SwiftCodeValidator) ValidateWithIsHeadquarter(code string, isHeadquarter bool) error {
 
-	err := sv.Validate(code)
+	err := sv.Validate(code, nil)
 
 	if err != nil {
 		return fmt.Errorf("error checking if bank is headquarter: %w", err)
@@ -9,14 +9,14 @@ import (
 func TestSwiftCodeValidator(t *testing.T) {
 	validator := NewSwiftCodeValidator()
 
-	assert.NoError(t, validator.Validate("DEUTDEFF"))
-	assert.NoError(t, validator.Validate("DEUTDEFF500"))
+	assert.NoError(t, validator.Validate("DEUTDEFF", nil))
+	assert.NoError(t, validator.Validate("DEUTDEFF500", nil))
 
-	assert.EqualError(t, validator.Validate(123), "swift code value must be a string")
+	assert.EqualError(t, validator.Validate(123, nil), "swift code value must be a string")
 
-	assert.EqualError(t, validator.Validate("DEUT"), "invalid SWIFT code length: 4")
+	assert.EqualError(t, validator.Validate("DEUT", nil), "invalid SWIFT code length: 4")
 
-	assert.EqualError(t, validator.Validate("abcd1234"), "invalid SWIFT code format: abcd1234")
+	assert.EqualError(t, validator.Validate("abcd1234", nil), "invalid SWIFT code format: abcd1234")
 }
 
 func TestSwiftCodeValidator_CountryCode(t *testing.T) {
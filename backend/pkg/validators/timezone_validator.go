@@ -3,8 +3,9 @@ package validators
 import (
 	"errors"
 	"fmt"
-	"regexp"
 	"strings"
+
+	"github.com/Hbrtjm/SWIFT_API/backend/pkg/timezones"
 )
 
 type TimeZoneValidator struct{}
@@ -13,20 +14,42 @@ func NewTimeZoneValidator() *TimeZoneValidator {
 	return &TimeZoneValidator{}
 }
 
-func (tzv *TimeZoneValidator) Validate(value interface{}, countryName string) error {
+// Validate checks the zone against the tz database and, when the record carries a countryISO2, checks that
+// the zone is used in that country
+func (tzv *TimeZoneValidator) Validate(value interface{}, record map[string]interface{}) error {
 	timeZone, ok := value.(string)
 	if !ok {
 		return errors.New("timeZone value must be a string")
 	}
 
-	timeZone = strings.ToUpper(timeZone)
+	if _, err := timezones.Load(timeZone); err != nil {
+		return fmt.Errorf("unknown time zone: %s", timeZone)
+	}
+
+	if canonical, deprecated := timezones.Canonical(timeZone); deprecated {
+		return &RuleError{
+			Code:       CodeDeprecated,
+			Message:    fmt.Sprintf("time zone %s is deprecated, use %s", timeZone, canonical),
+			Suggestion: canonical,
+		}
+	}
 
-	capitalName := `[A-Z]{1}[a-zA-Z]+`
+	countryISO2, _ := record["countryISO2"].(string)
+	countryISO2 = strings.ToUpper(strings.TrimSpace(countryISO2))
+	if countryISO2 == "" {
+		return nil
+	}
 
-	re := regexp.MustCompile(`^[A-Za-z]+/` + capitalName + `$`)
-	if !re.MatchString(timeZone) {
-		return fmt.Errorf("invalid timeZone format: %s", timeZone)
+	ownZones := timezones.ZonesForCountry(countryISO2)
+	// Countries missing from the tz tables cannot be checked
+	if len(ownZones) == 0 || timezones.BelongsToCountry(timeZone, countryISO2) {
+		return nil
 	}
 
-	return nil
+	// The zone of most of the country is suggested, never a zone it shares with a neighbour
+	return &RuleError{
+		Code:       CodeMismatch,
+		Message:    fmt.Sprintf("time zone %s is not used in country %s", timeZone, countryISO2),
+		Suggestion: ownZones[0],
+	}
 }
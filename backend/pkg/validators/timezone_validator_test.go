@@ -1,19 +1,66 @@
 package validators
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestTimeZoneValidator(t *testing.T) {
 	validator := NewTimeZoneValidator()
 
-	assert.NoError(t, validator.Validate("Europe/Warsaw", "Poland"))
-	assert.NoError(t, validator.Validate("America/NewYork", "USA"))
-	assert.NoError(t, validator.Validate("EUROPE/WARSAW", "Poland"))
+	assert.NoError(t, validator.Validate("Europe/Warsaw", map[string]interface{}{"countryName": "Poland"}))
+	assert.NoError(t, validator.Validate("America/New_York", map[string]interface{}{"countryName": "USA"}))
 
-	assert.EqualError(t, validator.Validate(123, "USA"), "timeZone value must be a string")
+	assert.EqualError(t, validator.Validate(123, map[string]interface{}{"countryName": "USA"}), "timeZone value must be a string")
 
-	assert.EqualError(t, validator.Validate("EuropeWarsaw", "Poland"), "invalid timeZone format: EUROPEWARSAW")
+	assert.EqualError(t, validator.Validate("EuropeWarsaw", map[string]interface{}{"countryName": "Poland"}), "unknown time zone: EuropeWarsaw")
+	// The tz database names are case sensitive
+	assert.EqualError(t, validator.Validate("EUROPE/WARSAW", map[string]interface{}{"countryName": "Poland"}), "unknown time zone: EUROPE/WARSAW")
+	assert.EqualError(t, validator.Validate("America/NewYork", map[string]interface{}{"countryName": "USA"}), "unknown time zone: America/NewYork")
+	assert.EqualError(t, validator.Validate("", nil), "unknown time zone: ")
+}
+
+func TestTimeZoneValidator_Country(t *testing.T) {
+	validator := NewTimeZoneValidator()
+
+	assert.NoError(t, validator.Validate("Europe/Warsaw", map[string]interface{}{"countryISO2": "PL"}))
+	assert.NoError(t, validator.Validate("America/Chicago", map[string]interface{}{"countryISO2": "us"}))
+
+	err := validator.Validate("Europe/Berlin", map[string]interface{}{"countryISO2": "PL"})
+	var ruleError *RuleError
+	require.True(t, errors.As(err, &ruleError))
+	assert.Equal(t, CodeMismatch, ruleError.Code)
+	assert.Equal(t, "Europe/Warsaw", ruleError.Suggestion)
+	assert.EqualError(t, err, "time zone Europe/Berlin is not used in country PL")
+
+	// A zone shared with a neighbour is accepted, but the own zone of the country is suggested
+	assert.NoError(t, validator.Validate("Europe/Brussels", map[string]interface{}{"countryISO2": "NL"}))
+	err = validator.Validate("Europe/Warsaw", map[string]interface{}{"countryISO2": "NL"})
+	require.True(t, errors.As(err, &ruleError))
+	assert.Equal(t, CodeMismatch, ruleError.Code)
+	assert.Equal(t, "Europe/Amsterdam", ruleError.Suggestion)
+	err = validator.Validate("Europe/London", map[string]interface{}{"countryISO2": "IS"})
+	require.True(t, errors.As(err, &ruleError))
+	assert.Equal(t, "Atlantic/Reykjavik", ruleError.Suggestion)
+}
+
+func TestTimeZoneValidator_DeprecatedAlias(t *testing.T) {
+	validator := NewTimeZoneValidator()
+
+	err := validator.Validate("Europe/Kiev", map[string]interface{}{"countryISO2": "UA"})
+	var ruleError *RuleError
+	require.True(t, errors.As(err, &ruleError))
+	assert.Equal(t, CodeDeprecated, ruleError.Code)
+	assert.Equal(t, "Europe/Kyiv", ruleError.Suggestion)
+
+	// The suggestion is carried over to the field error
+	result := NewRegistry().
+		Register("timeZone", CodeInvalidFormat, "timeZone invalid", validator).
+		Validate(map[string]interface{}{"timeZone": "US/Eastern"})
+	require.Len(t, result.Errors, 1)
+	assert.Equal(t, CodeDeprecated, result.Errors[0].Code)
+	assert.Equal(t, "America/New_York", result.Errors[0].Suggestion)
 }
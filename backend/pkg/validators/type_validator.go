@@ -13,7 +13,7 @@ func NewCodeTypeValidator() *CodeTypeValidator {
 	return &CodeTypeValidator{}
 }
 
-func (ctv *CodeTypeValidator) Validate(value interface{}) error {
+func (ctv *CodeTypeValidator) Validate(value interface{}, record map[string]interface{}) error {
 	codeType, ok := value.(string)
 	if !ok {
 		return errors.New("code type value must be a string")
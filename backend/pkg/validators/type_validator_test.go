@@ -10,10 +10,10 @@ func TestCodeTypeValidator(t *testing.T) {
 	validator := NewCodeTypeValidator()
 
 	// Correct format, but in our current data we don't have any BIC-s 8
-	assert.NoError(t, validator.Validate("BIC8"))
-	assert.NoError(t, validator.Validate("bic11"))
+	assert.NoError(t, validator.Validate("BIC8", nil))
+	assert.NoError(t, validator.Validate("bic11", nil))
 
-	assert.EqualError(t, validator.Validate(123), "code type value must be a string")
-	assert.EqualError(t, validator.Validate("XYZ"), "invalid code type format: XYZ")
-	assert.EqualError(t, validator.Validate("BIC12"), "invalid code type format: BIC12")
+	assert.EqualError(t, validator.Validate(123, nil), "code type value must be a string")
+	assert.EqualError(t, validator.Validate("XYZ", nil), "invalid code type format: XYZ")
+	assert.EqualError(t, validator.Validate("BIC12", nil), "invalid code type format: BIC12")
 }
@@ -0,0 +1,101 @@
+package validators
+
+import (
+	"strings"
+)
+
+// Error codes reported in FieldError.Code
+const (
+	CodeRequired          = "required"
+	CodeEmpty             = "empty"
+	CodeIllegalCharacters = "illegal_characters"
+	CodeInvalidFormat     = "invalid_format"
+	CodeMismatch          = "mismatch"
+	CodeDeprecated        = "deprecated"
+)
+
+// FieldError describes a single failing field
+type FieldError struct {
+	Field   string      `json:"field"`
+	Code    string      `json:"code"`
+	Message string      `json:"message"`
+	Value   interface{} `json:"value,omitempty"`
+	// Suggested replacement of the value, if one is known
+	Suggestion string `json:"suggestion,omitempty"`
+}
+
+// RuleError can be returned by a validator to override the code of its rule and to suggest a replacement value
+type RuleError struct {
+	Code       string
+	Message    string
+	Suggestion string
+}
+
+func (re *RuleError) Error() string {
+	return re.Message
+}
+
+// ValidationResult collects every failing field of a validated entity, it implements error
+// so that it can be passed through the service layer and unwrapped by the handlers
+type ValidationResult struct {
+	Errors []FieldError `json:"errors"`
+	// Warnings flag suspicious values, they don't make the result invalid
+	Warnings []FieldError `json:"warnings,omitempty"`
+}
+
+func NewValidationResult() *ValidationResult {
+	return &ValidationResult{
+		Errors:   []FieldError{},
+		Warnings: []FieldError{},
+	}
+}
+
+// Add appends a failing field to the result
+func (vr *ValidationResult) Add(field, code, message string, value interface{}) {
+	vr.Errors = append(vr.Errors, FieldError{
+		Field:   field,
+		Code:    code,
+		Message: message,
+		Value:   value,
+	})
+}
+
+// Merge appends all of the errors from another result
+func (vr *ValidationResult) Merge(other *ValidationResult) {
+	if other == nil {
+		return
+	}
+	vr.Errors = append(vr.Errors, other.Errors...)
+	vr.Warnings = append(vr.Warnings, other.Warnings...)
+}
+
+// HasField reports whether the given field has already failed
+func (vr *ValidationResult) HasField(field string) bool {
+	for _, fieldError := range vr.Errors {
+		if fieldError.Field == field {
+			return true
+		}
+	}
+	return false
+}
+
+func (vr *ValidationResult) Valid() bool {
+	return len(vr.Errors) == 0
+}
+
+// Err returns the result as an error, or nil when every field passed.
+// Returning the result directly would produce a non-nil error interface holding a nil pointer
+func (vr *ValidationResult) Err() error {
+	if vr.Valid() {
+		return nil
+	}
+	return vr
+}
+
+func (vr *ValidationResult) Error() string {
+	messages := make([]string, 0, len(vr.Errors))
+	for _, fieldError := range vr.Errors {
+		messages = append(messages, fieldError.Message)
+	}
+	return strings.Join(messages, "; ")
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"log"
 	"os"
+	"path/filepath"
 	"sort"
 	"strings"
 	"testing"
@@ -14,8 +15,18 @@ import (
 	"github.com/Hbrtjm/SWIFT_API/backend/internal/db/repository"
 	"github.com/Hbrtjm/SWIFT_API/backend/internal/parser"
 	"github.com/Hbrtjm/SWIFT_API/backend/internal/service"
+	"github.com/Hbrtjm/SWIFT_API/backend/pkg/address"
+	"github.com/Hbrtjm/SWIFT_API/backend/pkg/clearing"
+	"github.com/Hbrtjm/SWIFT_API/backend/pkg/countryinfo"
+	"github.com/Hbrtjm/SWIFT_API/backend/pkg/iso20022"
+	"github.com/Hbrtjm/SWIFT_API/backend/pkg/mt"
+	"github.com/Hbrtjm/SWIFT_API/backend/pkg/names"
+	"github.com/Hbrtjm/SWIFT_API/backend/pkg/sanctions"
+	"github.com/Hbrtjm/SWIFT_API/backend/pkg/schemes"
+	"github.com/Hbrtjm/SWIFT_API/backend/pkg/suggest"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/mongo"
 )
 
 const (
@@ -50,7 +61,7 @@ func setupTestEnvironment() error {
 		return err
 	}
 	swiftParser = parser.NewSwiftFileParser()
-	swiftService = service.NewSwiftCodeService(repo, swiftParser, testLogger)
+	swiftService = service.NewSwiftCodeService(repo, swiftParser, testLogger, "v1")
 
 	return nil
 }
@@ -131,6 +142,11 @@ func TestLoadInitialData(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, "PEKAO TFI S.A.", bank.BankName)
 	assert.Equal(t, "PL", bank.CountryISO2)
+	assert.Equal(t, "Europe/Warsaw", bank.TimeZone)
+
+	country, err := repo.GetCountry("PL")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Europe/Warsaw"}, country.TimeZones)
 
 	// Test loading from non-existent file
 	err = swiftService.LoadInitialData("non_existent_file.csv")
@@ -227,6 +243,7 @@ func TestGetBySwiftCode(t *testing.T) {
 	// The headquarter should have branches in its branches list
 	assert.NotNil(t, response.Branches)
 	assert.Len(t, response.Branches, len(expectedBranches))
+	assert.False(t, response.BranchesTruncated)
 
 	// Sort the response branches by SWIFT code to ensure consistent comparison
 	sort.Slice(response.Branches, func(i, j int) bool {
@@ -292,8 +309,10 @@ func TestGetBySwiftCodesByCountry(t *testing.T) {
 	response, err := swiftService.GetBySwiftCodesByCountry("PL")
 	assert.NoError(t, err)
 	assert.NotNil(t, response)
-	assert.Len(t, response, 3)
+	assert.Len(t, response, 5)
 	assert.Len(t, response["swiftCodes"], 4)
+	assert.Equal(t, "POL", response["countryISO3"])
+	assert.Equal(t, "616", response["countryNumeric"])
 
 	response, err = swiftService.GetBySwiftCodesByCountry("XX")
 	assert.Error(t, err)
@@ -327,10 +346,10 @@ func TestDeleteSwiftCode(t *testing.T) {
 	assert.Equal(t, int64(3), count)
 
 	err = swiftService.DeleteSwiftCode("NONEXISTENT")
-	assert.Error(t, err)
+	assert.ErrorIs(t, err, service.ErrBankNotFound)
 
 	err = swiftService.DeleteSwiftCode("INVALID")
-	assert.Error(t, err)
+	assert.ErrorIs(t, err, service.ErrInvalidSwiftCode)
 	assert.Contains(t, err.Error(), "invalid SWIFT code")
 }
 
@@ -361,6 +380,8 @@ func TestIntegrationFlow(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, "PEKAO TOWARZYSTWO FUNDUSZY  INWESTYCYJNYCH SPOLKA AKCYJNA", response.BankName)
 	assert.Equal(t, "PL", response.CountryISO2)
+	// The zone was not posted, so it's inferred from the country
+	assert.Equal(t, "Europe/Warsaw", response.TimeZone)
 
 	// Query multiple codes
 	multiResponse, err := swiftService.GetMultipleSwiftCodes([]string{"TPEOPLPW123", "TPEOPLPWXXX"})
@@ -379,10 +400,997 @@ func TestIntegrationFlow(t *testing.T) {
 	// Query by country
 	countryResponse, err := swiftService.GetBySwiftCodesByCountry("PL")
 	assert.NoError(t, err)
-	assert.Len(t, countryResponse, 3)
+	assert.Len(t, countryResponse, 5)
 
 	// Final count verification
 	count, err := repo.Count()
 	assert.NoError(t, err)
 	assert.Equal(t, int64(4), count)
 }
+
+// TestAnalyzeSwiftCode tests the AnalyzeSwiftCode function
+func TestAnalyzeSwiftCode(t *testing.T) {
+	cleanup(t)
+
+	err := swiftService.LoadInitialData(testDataFilePath)
+	assert.NoError(t, err)
+
+	response, err := swiftService.AnalyzeSwiftCode("TPEOPLPWP65")
+	assert.NoError(t, err)
+	assert.True(t, response.InDirectory)
+	assert.Equal(t, "TPEO", response.InstitutionCode)
+	assert.Equal(t, "P65", response.BranchCode)
+
+	// Codes which are not registered are still analyzed
+	response, err = swiftService.AnalyzeSwiftCode("deutdef0")
+	assert.NoError(t, err)
+	assert.False(t, response.InDirectory)
+	assert.True(t, response.IsTestAndTraining)
+	assert.True(t, response.IsPrimaryOffice)
+
+	_, err = swiftService.AnalyzeSwiftCode("INVALID")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid SWIFT code")
+}
+
+// TestGetBankLocalTime tests the GetBankLocalTime function
+func TestGetBankLocalTime(t *testing.T) {
+	cleanup(t)
+
+	err := swiftService.LoadInitialData(testDataFilePath)
+	assert.NoError(t, err)
+
+	// Easter Monday in Poland and on TARGET2
+	response, err := swiftService.GetBankLocalTime("TPEOPLPWP65", time.Date(2026, time.April, 6, 10, 0, 0, 0, time.UTC))
+	assert.NoError(t, err)
+	assert.Equal(t, "Europe/Warsaw", response.TimeZone)
+	assert.Equal(t, "2026-04-06T12:00:00+02:00", response.LocalTime)
+	assert.True(t, response.HolidayCalendarAvailable)
+	assert.False(t, response.IsBusinessDay)
+	assert.Equal(t, "Easter Monday", response.Holiday)
+	assert.Equal(t, "2026-04-07", response.NextBusinessDay)
+	assert.NotNil(t, response.TARGET2)
+	assert.False(t, response.TARGET2.IsBusinessDay)
+
+	response, err = swiftService.GetBankLocalTime("TPEOPLPWP65", time.Date(2026, time.April, 7, 10, 0, 0, 0, time.UTC))
+	assert.NoError(t, err)
+	assert.True(t, response.IsWithinBusinessHours)
+
+	_, err = swiftService.GetBankLocalTime("NONEXISTENT", time.Now())
+	assert.ErrorIs(t, err, service.ErrBankNotFound)
+
+	// The 8 character code is the code of the headquarter
+	response, err = swiftService.GetBankLocalTime("tpeoplpw", time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, "TPEOPLPWXXX", response.SwiftCode)
+}
+
+// TestGetIBANDetails tests the LoadBankCodes and GetIBANDetails functions
+func TestGetIBANDetails(t *testing.T) {
+	cleanup(t)
+
+	err := swiftService.LoadInitialData(testDataFilePath)
+	assert.NoError(t, err)
+
+	bankCodesFile := filepath.Join(t.TempDir(), "bank_codes.csv")
+	err = os.WriteFile(bankCodesFile, []byte("COUNTRY ISO2 CODE;BANK CODE;SWIFT CODE\nPL;124;TPEOPLPW\nPL;12401037;TPEOPLPWP65\n"), 0644)
+	require.NoError(t, err)
+	require.NoError(t, swiftService.LoadBankCodes(bankCodesFile))
+
+	// The bank and branch code is more specific than the bank code alone
+	response, err := swiftService.GetIBANDetails("PL63 1240 1037 0000 0000 1234 5678")
+	assert.NoError(t, err)
+	assert.True(t, response.Valid)
+	assert.Equal(t, "124", response.BankCode)
+	assert.Equal(t, "01037", response.BranchCode)
+	assert.Equal(t, service.ResolvedByBankCodeTable, response.ResolvedBy)
+	require.NotNil(t, response.SwiftEntry)
+	assert.Equal(t, "TPEOPLPWP65", response.SwiftEntry.SwiftCode)
+
+	response, err = swiftService.GetIBANDetails("PL61109010140000071219812874")
+	assert.NoError(t, err)
+	assert.True(t, response.Valid)
+	assert.Nil(t, response.SwiftEntry)
+
+	response, err = swiftService.GetIBANDetails("PL62109010140000071219812874")
+	assert.NoError(t, err)
+	assert.False(t, response.Valid)
+	assert.Contains(t, response.Error, "check digits")
+}
+
+// TestGetByClearingCode tests the LoadClearingCodes and GetByClearingCode functions
+func TestGetByClearingCode(t *testing.T) {
+	cleanup(t)
+
+	err := swiftService.LoadInitialData(testDataFilePath)
+	assert.NoError(t, err)
+
+	clearingCodesFile := filepath.Join(t.TempDir(), "clearing_codes.csv")
+	content := "CLEARING SYSTEM;MEMBER ID;SWIFT CODE\nSORTCODE;60-16-13;TPEOPLPW\nUSABA;021000021;TPEOPLPWXXX\nUSABA;021000022;TPEOPLPWXXX\n"
+	err = os.WriteFile(clearingCodesFile, []byte(content), 0644)
+	require.NoError(t, err)
+	require.NoError(t, swiftService.LoadClearingCodes(clearingCodesFile))
+
+	response, err := swiftService.GetByClearingCode("gbdsc", "601613")
+	assert.NoError(t, err)
+	assert.Equal(t, "TPEOPLPWXXX", response.SwiftCode)
+	require.NotNil(t, response.SwiftEntry)
+	assert.Len(t, response.SwiftEntry.ClearingCodes, 2)
+
+	// The row with the invalid routing number check digit was skipped
+	_, err = swiftService.GetByClearingCode("ABA", "021000022")
+	assert.Error(t, err)
+
+	_, err = swiftService.GetByClearingCode("XXCLR", "1")
+	assert.Error(t, err)
+
+	bank, err := swiftService.GetBySwiftCode("TPEOPLPWXXX")
+	assert.NoError(t, err)
+	assert.Equal(t, []service.ClearingCodeEntry{{ClearingSystem: "GBDSC", MemberID: "601613"}, {ClearingSystem: "USABA", MemberID: "021000021"}}, bank.ClearingCodes)
+}
+
+// TestLEIMappings tests the LoadLEIMappings, GetSwiftCodesByLEI and GetMissingLEIReport functions
+func TestLEIMappings(t *testing.T) {
+	cleanup(t)
+	require.NoError(t, repo.LEIMappingsCollection().Drop(context.Background()))
+
+	err := swiftService.LoadInitialData(testDataFilePath)
+	assert.NoError(t, err)
+
+	leiMappingFile := filepath.Join(t.TempDir(), "lei_bic.csv")
+	content := "\ufeffLEI,BIC\n5493001KJTIIGC8Y1R12,TPEOPLPW\n5493001KJTIIGC8Y1R12,DEUTDEFFXXX\n5493001KJTIIGC8Y1R13,TPEOPLPWPAE\n"
+	err = os.WriteFile(leiMappingFile, []byte(content), 0644)
+	require.NoError(t, err)
+	require.NoError(t, swiftService.LoadLEIMappings(leiMappingFile))
+
+	response, err := swiftService.GetSwiftCodesByLEI("5493001kjtiigc8y1r12")
+	assert.NoError(t, err)
+	assert.Len(t, response.SwiftCodes, 1)
+	assert.Equal(t, []string{"DEUTDEFFXXX"}, response.UnknownSwiftCodes)
+
+	// Branches share the LEI of the headquarter
+	bank, err := swiftService.GetBySwiftCode("TPEOPLPWP65")
+	assert.NoError(t, err)
+	assert.Equal(t, "5493001KJTIIGC8Y1R12", bank.LEI)
+
+	_, err = swiftService.GetSwiftCodesByLEI("5493001KJTIIGC8Y1R13")
+	assert.Error(t, err)
+
+	_, err = swiftService.GetSwiftCodesByLEI("7LTWFZYICNSX8D621K86")
+	assert.ErrorIs(t, err, repository.ErrLEINotFound)
+
+	report, err := swiftService.GetMissingLEIReport("PL")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, report.Count)
+
+	require.NoError(t, repo.LEIMappingsCollection().Drop(context.Background()))
+	report, err = swiftService.GetMissingLEIReport("")
+	assert.NoError(t, err)
+	assert.Equal(t, 4, report.Count)
+}
+
+// TestGetInstitution tests the GetInstitution function
+func TestGetInstitution(t *testing.T) {
+	cleanup(t)
+
+	err := swiftService.LoadInitialData(testDataFilePath)
+	assert.NoError(t, err)
+
+	bank, err := repo.FindBySwiftCode("TPEOPLPWP65")
+	assert.NoError(t, err)
+	assert.Equal(t, "TPEO", bank.InstitutionCode)
+
+	response, err := swiftService.GetInstitution("tpeo")
+	assert.NoError(t, err)
+	assert.Equal(t, 4, response.Count)
+	require.Len(t, response.Countries, 1)
+	assert.Equal(t, service.InstitutionCountry{CountryISO2: "PL", CountryName: "POLAND", CountryDisplayName: "Poland", Count: 4, BIC8Count: 1}, response.Countries[0])
+	require.Len(t, response.BIC8s, 1)
+	assert.Equal(t, "TPEOPLPW", response.BIC8s[0].BIC8)
+	assert.Equal(t, "TPEOPLPWXXX", response.BIC8s[0].Headquarter["swiftCode"])
+	assert.Len(t, response.BIC8s[0].Branches, 3)
+
+	_, err = swiftService.GetInstitution("ABCD")
+	assert.Error(t, err)
+
+	_, err = swiftService.GetInstitution("TPEOPL")
+	assert.ErrorIs(t, err, service.ErrInvalidInstitutionCode)
+}
+
+// TestHeadquarterNavigation tests the GetHeadquarter and GetBranches functions
+func TestHeadquarterNavigation(t *testing.T) {
+	cleanup(t)
+
+	err := swiftService.LoadInitialData(testDataFilePath)
+	assert.NoError(t, err)
+
+	headquarter, err := swiftService.GetHeadquarter("TPEOPLPWP65")
+	assert.NoError(t, err)
+	assert.Equal(t, "TPEOPLPWXXX", headquarter.SwiftCode)
+	assert.Equal(t, "/v1/swift-codes/TPEOPLPWXXX/branches", headquarter.Links.Branches)
+
+	bank, err := swiftService.GetBySwiftCode("TPEOPLPWP65")
+	assert.NoError(t, err)
+	assert.Equal(t, "/v1/swift-codes/TPEOPLPWXXX", bank.Links.Headquarter)
+	assert.Equal(t, "/v1/swift-codes/country/PL", bank.Links.Country)
+
+	page, err := swiftService.GetBranches("TPEOPLPWXXX", 2, "")
+	assert.NoError(t, err)
+	require.Len(t, page.Branches, 2)
+	assert.Equal(t, "TPEOPLPWP65", page.Branches[0]["swiftCode"])
+	assert.NotEmpty(t, page.NextCursor)
+
+	page, err = swiftService.GetBranches("TPEOPLPWP65", 2, page.NextCursor)
+	assert.NoError(t, err)
+	require.Len(t, page.Branches, 1)
+	assert.Equal(t, "TPEOPLPWPFI", page.Branches[0]["swiftCode"])
+	assert.Empty(t, page.NextCursor)
+	assert.Empty(t, page.Links.Next)
+
+	_, err = swiftService.GetBranches("TPEOPLPWXXX", 1000, "")
+	assert.ErrorIs(t, err, service.ErrInvalidLimit)
+
+	_, err = swiftService.GetBranches("TPEOPLPWXXX", 10, "not base64!")
+	assert.ErrorIs(t, err, service.ErrInvalidCursor)
+
+	_, err = swiftService.GetHeadquarter("NONEXISTENT")
+	assert.ErrorIs(t, err, service.ErrBankNotFound)
+}
+
+// TestReferentialIntegrity tests the integrity rules of the branches and headquarters and the orphan report
+func TestReferentialIntegrity(t *testing.T) {
+	cleanup(t)
+	defer swiftService.SetIntegrityRules(service.DefaultIntegrityRules())
+
+	err := swiftService.LoadInitialData(testDataFilePath)
+	assert.NoError(t, err)
+
+	orphanBranch := map[string]interface{}{
+		"countryISO2":   "PL",
+		"swiftCode":     "ORPHPLPW123",
+		"bankName":      "ORPHAN BANK",
+		"address":       "1 TEST STREET",
+		"townName":      "WARSAW",
+		"countryName":   "POLAND",
+		"isHeadquarter": false,
+	}
+
+	err = swiftService.SetIntegrityRules(service.IntegrityRules{BranchWithoutHeadquarter: service.BranchRuleReject, HeadquarterDeleteMode: service.DeleteModeReject})
+	assert.NoError(t, err)
+	err = swiftService.PostBankData(orphanBranch)
+	assert.ErrorIs(t, err, service.ErrIntegrityViolation)
+
+	_, err = swiftService.DeleteSwiftCodeWithMode("TPEOPLPWXXX", "")
+	assert.ErrorIs(t, err, service.ErrIntegrityViolation)
+
+	err = swiftService.SetIntegrityRules(service.IntegrityRules{BranchWithoutHeadquarter: "ignore", HeadquarterDeleteMode: service.DeleteModeDetach})
+	assert.Error(t, err)
+
+	err = swiftService.SetIntegrityRules(service.DefaultIntegrityRules())
+	assert.NoError(t, err)
+	err = swiftService.PostBankData(orphanBranch)
+	assert.NoError(t, err)
+
+	report, err := swiftService.GetOrphanReport("PL")
+	assert.NoError(t, err)
+	require.Len(t, report.BranchesWithoutHeadquarter, 1)
+	assert.Equal(t, "ORPHPLPW123", report.BranchesWithoutHeadquarter[0]["swiftCode"])
+	// The branches are named differently than the headquarter
+	assert.Len(t, report.NameMismatches, 3)
+	assert.Equal(t, "PEKAO TFI S.A.", report.NameMismatches[0].HeadquarterBankName)
+
+	_, err = swiftService.DeleteSwiftCodeWithMode("TPEOPLPWXXX", "unknown")
+	assert.ErrorIs(t, err, service.ErrInvalidDeleteMode)
+
+	// The 8 character code is the headquarter
+	deleted, err := swiftService.DeleteSwiftCodeWithMode("TPEOPLPW", service.DeleteModeCascade)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), deleted)
+
+	count, err := repo.Count()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+}
+
+// TestBankStatus tests the status changes of the banks and the scheduler
+func TestBankStatus(t *testing.T) {
+	cleanup(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 6*time.Second)
+	defer cancel()
+	require.NoError(t, repo.StatusChangesCollection().Drop(ctx))
+
+	err := swiftService.LoadInitialData(testDataFilePath)
+	assert.NoError(t, err)
+
+	now := time.Date(2026, time.March, 2, 12, 0, 0, 0, time.UTC)
+
+	status, err := swiftService.GetBankStatus("TPEOPLPWPAE")
+	assert.NoError(t, err)
+	assert.Equal(t, "active", status.Status)
+
+	_, _, err = swiftService.SetBankStatus("TPEOPLPWPAE", service.BankStatusRequest{Status: "closed"}, now)
+	assert.ErrorIs(t, err, service.ErrInvalidBankStatus)
+
+	_, _, err = swiftService.SetBankStatus("TPEOPLPWPAE", service.BankStatusRequest{Status: "active", SuccessorSwiftCode: "TPEOPLPWXXX"}, now)
+	assert.ErrorIs(t, err, service.ErrInvalidSuccessorSwift)
+
+	_, _, err = swiftService.SetBankStatus("TPEOPLPWPAE", service.BankStatusRequest{Status: "retired", EffectiveDate: "02/03/2026"}, now)
+	assert.ErrorIs(t, err, service.ErrInvalidEffectiveDate)
+
+	// Retired at once
+	status, scheduled, err := swiftService.SetBankStatus("TPEOPLPWPAE", service.BankStatusRequest{Status: "retired", SuccessorSwiftCode: "TPEOPLPW"}, now)
+	assert.NoError(t, err)
+	assert.False(t, scheduled)
+	assert.Equal(t, "TPEOPLPWXXX", status.SuccessorSwiftCode)
+
+	response, err := swiftService.GetBySwiftCode("TPEOPLPWPAE")
+	assert.NoError(t, err)
+	assert.Equal(t, "retired", response.Status)
+	assert.Equal(t, "/v1/swift-codes/TPEOPLPWXXX", response.Links.Successor)
+
+	// Merged in the future
+	status, scheduled, err = swiftService.SetBankStatus("TPEOPLPWPFI", service.BankStatusRequest{Status: "merged", SuccessorSwiftCode: "TPEOPLPWXXX", EffectiveDate: "2026-04-01"}, now)
+	assert.NoError(t, err)
+	assert.True(t, scheduled)
+	assert.Equal(t, "active", status.Status)
+	require.Len(t, status.PendingChanges, 1)
+
+	applied, err := swiftService.ApplyDueStatusChanges(now.AddDate(0, 0, 7))
+	assert.NoError(t, err)
+	assert.Equal(t, 0, applied)
+
+	applied, err = swiftService.ApplyDueStatusChanges(time.Date(2026, time.April, 1, 0, 0, 0, 0, time.UTC))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, applied)
+
+	status, err = swiftService.GetBankStatus("TPEOPLPWPFI")
+	assert.NoError(t, err)
+	assert.Equal(t, "merged", status.Status)
+	assert.Empty(t, status.PendingChanges)
+
+	_, err = swiftService.GetBankStatus("NONEXISTENT")
+	assert.ErrorIs(t, err, service.ErrBankNotFound)
+}
+
+// TestCorrespondentRoutes tests the correspondent relationships and the route search
+func TestCorrespondentRoutes(t *testing.T) {
+	cleanup(t)
+	require.NoError(t, repo.CorrespondentsCollection().Drop(context.Background()))
+
+	err := swiftService.LoadInitialData(testDataFilePath)
+	assert.NoError(t, err)
+
+	// Correspondents outside the database can be imported, but not added through the API
+	correspondentsFile := filepath.Join(t.TempDir(), "correspondents.csv")
+	content := "SWIFT CODE;CORRESPONDENT SWIFT CODE;CURRENCY\nTPEOPLPWP65;DEUTDEFF;EUR\nTPEOPLPWPFI;DEUTDEFFXXX;EUR\nTPEOPLPWPAE;TPEOPLPWPAE;EUR\nTPEOPLPWPAE;DEUTDEFF;ABC\n"
+	require.NoError(t, os.WriteFile(correspondentsFile, []byte(content), 0644))
+	require.NoError(t, swiftService.LoadCorrespondents(correspondentsFile))
+
+	routes, err := swiftService.FindRoutes("TPEOPLPWP65", "tpeoplpwpfi", "eur", 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, routes.Hops)
+	require.Len(t, routes.Routes, 1)
+	require.Len(t, routes.Routes[0].Path, 3)
+	assert.Equal(t, "DEUTDEFFXXX", routes.Routes[0].Path[1].SwiftCode)
+	assert.Empty(t, routes.Routes[0].Path[1].BankName)
+	assert.Equal(t, "DE", routes.Routes[0].Path[1].CountryISO2)
+
+	// A direct relationship is shorter
+	correspondent, err := swiftService.AddCorrespondent(models.Correspondent{SwiftCode: "TPEOPLPWP65", CorrespondentSwiftCode: "TPEOPLPW", Currency: "EUR"})
+	assert.NoError(t, err)
+	assert.Equal(t, "TPEOPLPWXXX", correspondent.CorrespondentSwiftCode)
+	_, err = swiftService.AddCorrespondent(models.Correspondent{SwiftCode: "TPEOPLPWPFI", CorrespondentSwiftCode: "TPEOPLPWXXX", Currency: "EUR"})
+	assert.NoError(t, err)
+
+	routes, err = swiftService.FindRoutes("TPEOPLPWP65", "TPEOPLPWPFI", "EUR", 0)
+	assert.NoError(t, err)
+	assert.Len(t, routes.Routes, 2)
+
+	routes, err = swiftService.FindRoutes("TPEOPLPWP65", "TPEOPLPWPFI", "USD", 0)
+	assert.NoError(t, err)
+	assert.Empty(t, routes.Routes)
+
+	_, err = swiftService.AddCorrespondent(models.Correspondent{SwiftCode: "TPEOPLPWP65", CorrespondentSwiftCode: "DEUTDEFFXXX", Currency: "EUR"})
+	assert.ErrorIs(t, err, service.ErrBankNotFound)
+
+	_, err = swiftService.FindRoutes("TPEOPLPWP65", "TPEOPLPWPFI", "EURO", 0)
+	assert.ErrorIs(t, err, service.ErrInvalidCurrency)
+
+	_, err = swiftService.FindRoutes("TPEOPLPWP65", "TPEOPLPWPFI", "EUR", 10)
+	assert.ErrorIs(t, err, service.ErrInvalidMaxHops)
+
+	_, err = swiftService.FindRoutes("TPEOPLPWP65", "TPEO", "EUR", 0)
+	assert.ErrorIs(t, err, service.ErrInvalidSwiftCode)
+	_, err = swiftService.FindRoutes("TPEOPLPWP65", "BREXPLPWXXX", "EUR", 0)
+	assert.ErrorIs(t, err, service.ErrBankNotFound)
+
+	list, err := swiftService.GetCorrespondents("TPEOPLPWXXX", "")
+	assert.NoError(t, err)
+	assert.Len(t, list.Correspondents, 2)
+
+	err = swiftService.DeleteCorrespondent(models.Correspondent{SwiftCode: "TPEOPLPWP65", CorrespondentSwiftCode: "TPEOPLPWXXX", Currency: "EUR"})
+	assert.NoError(t, err)
+	err = swiftService.DeleteCorrespondent(models.Correspondent{SwiftCode: "TPEOPLPWP65", CorrespondentSwiftCode: "TPEOPLPWXXX", Currency: "EUR"})
+	assert.ErrorIs(t, err, service.ErrCorrespondentNotFound)
+}
+
+// TestSchemeReachability tests the import of the scheme participants and the scheme filters
+func TestSchemeReachability(t *testing.T) {
+	cleanup(t)
+	require.NoError(t, repo.SchemeParticipationsCollection().Drop(context.Background()))
+
+	err := swiftService.LoadInitialData(testDataFilePath)
+	assert.NoError(t, err)
+
+	dir := t.TempDir()
+	content := "SWIFT CODE;ADHERENCE DATE\nTPEOPLPW;2017-11-21\nTPEOPLPWP65;2018-01-02\nDEUTDEFF;not a date\n"
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "SCT_INST.csv"), []byte(content), 0644))
+	content = "SCHEME;SWIFT CODE;ADHERENCE DATE\nSEPA SCT;TPEOPLPWPAE;2008-01-28\nT2;TPEOPLPWPFI;2999-01-01\nUNKNOWN;TPEOPLPWPFI;2008-01-28\n"
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "participants.csv"), []byte(content), 0644))
+	require.NoError(t, swiftService.LoadSchemeParticipants(dir))
+
+	// The own participation of the branch takes precedence over the headquarter
+	bank, err := swiftService.GetBySwiftCode("TPEOPLPWP65")
+	assert.NoError(t, err)
+	require.Len(t, bank.Schemes, 1)
+	assert.Equal(t, "SCT_INST", bank.Schemes[0].Scheme)
+	assert.False(t, bank.Schemes[0].ViaHeadquarter)
+	assert.Equal(t, "2018-01-02", bank.Schemes[0].AdherenceDate.Format(time.DateOnly))
+
+	bank, err = swiftService.GetBySwiftCode("TPEOPLPWPAE")
+	assert.NoError(t, err)
+	require.Len(t, bank.Schemes, 2)
+	assert.Equal(t, "SCT", bank.Schemes[0].Scheme)
+	assert.True(t, bank.Schemes[1].ViaHeadquarter)
+
+	// The TARGET2 adherence is in the future
+	bank, err = swiftService.GetBySwiftCode("TPEOPLPWPFI")
+	assert.NoError(t, err)
+	require.Len(t, bank.Schemes, 1)
+	assert.Equal(t, "SCT_INST", bank.Schemes[0].Scheme)
+
+	country, err := swiftService.GetBySwiftCodesByCountryFiltered("PL", service.BankFilter{Scheme: "sepa sct"})
+	assert.NoError(t, err)
+	assert.Len(t, country["swiftCodes"], 1)
+
+	country, err = swiftService.GetBySwiftCodesByCountryFiltered("PL", service.BankFilter{Scheme: "SCT_INST"})
+	assert.NoError(t, err)
+	assert.Len(t, country["swiftCodes"], 4)
+
+	institution, err := swiftService.GetInstitutionFiltered("TPEO", service.BankFilter{Scheme: "TARGET2"})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, institution.Count)
+
+	_, err = swiftService.GetBySwiftCodesByCountryFiltered("PL", service.BankFilter{Scheme: "SWIFT"})
+	assert.ErrorIs(t, err, schemes.ErrUnknownScheme)
+}
+
+// TestCountryMetadata tests the country reference data and the region filters
+func TestCountryMetadata(t *testing.T) {
+	cleanup(t)
+
+	err := swiftService.LoadInitialData(testDataFilePath)
+	assert.NoError(t, err)
+
+	country, err := swiftService.GetBySwiftCodesByCountry("PL")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"PLN"}, country["currencies"])
+	assert.Equal(t, "Eastern Europe", country["subRegion"])
+	assert.Equal(t, true, country["sepa"])
+
+	country, err = swiftService.GetBySwiftCodesByCountryFiltered("PL", service.BankFilter{Region: "SEPA"})
+	assert.NoError(t, err)
+	assert.Len(t, country["swiftCodes"], 4)
+
+	institution, err := swiftService.GetInstitutionFiltered("TPEO", service.BankFilter{Region: "western-europe"})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, institution.Count)
+
+	_, err = swiftService.GetInstitutionFiltered("TPEO", service.BankFilter{Region: "Atlantis"})
+	assert.ErrorIs(t, err, countryinfo.ErrUnknownRegion)
+
+	// The region listing spans the countries and is paginated
+	page, err := swiftService.GetBySwiftCodesByRegion("sepa", service.BankFilter{}, 3, "")
+	require.NoError(t, err)
+	assert.Equal(t, "SEPA", page.Region)
+	assert.Contains(t, page.Countries, "PL")
+	require.Len(t, page.SwiftCodes, 3)
+	assert.NotEmpty(t, page.NextCursor)
+	page, err = swiftService.GetBySwiftCodesByRegion("SEPA", service.BankFilter{}, 3, page.NextCursor)
+	require.NoError(t, err)
+	require.Len(t, page.SwiftCodes, 1)
+	assert.Equal(t, "TPEOPLPWXXX", page.SwiftCodes[0]["swiftCode"])
+	assert.Empty(t, page.NextCursor)
+
+	page, err = swiftService.GetBySwiftCodesByRegion("western-europe", service.BankFilter{}, 0, "")
+	require.NoError(t, err)
+	assert.Empty(t, page.SwiftCodes)
+
+	_, err = swiftService.GetBySwiftCodesByRegion("Atlantis", service.BankFilter{}, 0, "")
+	assert.ErrorIs(t, err, countryinfo.ErrUnknownRegion)
+
+	countries, err := swiftService.GetCountries("EEA")
+	assert.NoError(t, err)
+	assert.Len(t, countries, 37)
+
+	info, err := swiftService.GetCountryInfo("no")
+	assert.NoError(t, err)
+	assert.False(t, info.EU)
+	assert.True(t, info.EEA)
+	assert.Equal(t, "/v1/swift-codes/country/NO", info.Links.SwiftCodes)
+
+	_, err = swiftService.GetCountryInfo("XX")
+	assert.ErrorIs(t, err, repository.ErrCountryNotFound)
+}
+
+// TestCountryDisplayNames tests that the canonical uppercase names are stored separately from the display names
+func TestCountryDisplayNames(t *testing.T) {
+	cleanup(t)
+
+	err := swiftService.LoadInitialData(testDataFilePath)
+	assert.NoError(t, err)
+
+	// The SWIFT file only has the uppercase names, so the display name is the ISO 3166 one
+	bank, err := swiftService.GetBySwiftCode("TPEOPLPWXXX")
+	assert.NoError(t, err)
+	assert.Equal(t, "POLAND", bank.CountryName)
+	assert.Equal(t, "Poland", bank.CountryDisplayName)
+
+	err = swiftService.PostBankData(map[string]interface{}{
+		"countryISO2":   "CZ",
+		"swiftCode":     "KOMBCZPPXXX",
+		"codeType":      "BIC11",
+		"bankName":      "KOMERCNI BANKA, A.S.",
+		"address":       "NA PRIKOPE 33  PRAHA, PRAHA, 114 07",
+		"townName":      "PRAHA",
+		"countryName":   "Czechia",
+		"isHeadquarter": true,
+	})
+	require.NoError(t, err)
+
+	bank, err = swiftService.GetBySwiftCode("KOMBCZPPXXX")
+	assert.NoError(t, err)
+	assert.Equal(t, "CZECHIA", bank.CountryName)
+	assert.Equal(t, "Czechia", bank.CountryDisplayName)
+
+	country, err := repo.GetCountry("CZ")
+	assert.NoError(t, err)
+	assert.Equal(t, "Czechia", country.DisplayName)
+}
+
+// TestStructuredAddress tests the parsing of the addresses at import and on POST
+func TestStructuredAddress(t *testing.T) {
+	cleanup(t)
+
+	err := swiftService.LoadInitialData(testDataFilePath)
+	assert.NoError(t, err)
+
+	bank, err := repo.FindBySwiftCode("TPEOPLPWXXX")
+	assert.NoError(t, err)
+	assert.Equal(t, address.PostalAddress{
+		StreetName:         "FOREST ZUBRA",
+		BuildingNumber:     "1",
+		Floor:              "1",
+		PostCode:           "01-066",
+		TownName:           "WARSZAWA",
+		CountrySubDivision: "MAZOWIECKIE",
+		Country:            "PL",
+		Confidence:         address.ConfidenceHigh,
+	}, bank.StructuredAddress)
+
+	err = swiftService.PostBankData(map[string]interface{}{
+		"countryISO2":   "MC",
+		"swiftCode":     "BAERMCMCXXX",
+		"codeType":      "BIC11",
+		"bankName":      "BANK JULIUS BAER (MONACO) S.A.M.",
+		"address":       "12 BOULEVARD DES MOULINS  MONACO, MONACO, 98000",
+		"townName":      "MONACO",
+		"countryName":   "MONACO",
+		"isHeadquarter": true,
+	})
+	require.NoError(t, err)
+
+	// Both forms are returned
+	response, err := swiftService.GetBySwiftCode("BAERMCMCXXX")
+	assert.NoError(t, err)
+	assert.Equal(t, "12 BOULEVARD DES MOULINS  MONACO, MONACO, 98000", response.Address)
+	require.NotNil(t, response.StructuredAddress)
+	assert.Equal(t, "BOULEVARD DES MOULINS", response.StructuredAddress.StreetName)
+	assert.Equal(t, "12", response.StructuredAddress.BuildingNumber)
+	assert.Equal(t, "98000", response.StructuredAddress.PostCode)
+	assert.Equal(t, address.ConfidenceHigh, response.StructuredAddress.Confidence)
+}
+
+// TestFinancialInstitution tests the GetFinancialInstitution function
+func TestFinancialInstitution(t *testing.T) {
+	cleanup(t)
+
+	err := swiftService.LoadInitialData(testDataFilePath)
+	assert.NoError(t, err)
+
+	clearingCodesFile := filepath.Join(t.TempDir(), "clearing_codes.csv")
+	content := "CLEARING SYSTEM;MEMBER ID;SWIFT CODE\nUSABA;021000021;TPEOPLPWXXX\nSORTCODE;60-16-13;TPEOPLPW\n"
+	err = os.WriteFile(clearingCodesFile, []byte(content), 0644)
+	require.NoError(t, err)
+	require.NoError(t, swiftService.LoadClearingCodes(clearingCodesFile))
+
+	result, err := swiftService.GetFinancialInstitution("TPEOPLPWXXX", "")
+	require.NoError(t, err)
+	assert.Equal(t, "TPEOPLPWXXX", result.BICFI)
+	assert.Equal(t, "PEKAO TFI S.A.", result.Name)
+	require.NotNil(t, result.ClearingSystemMemberID)
+	assert.Equal(t, "601613", result.ClearingSystemMemberID.MemberID)
+	require.NotNil(t, result.PostalAddress)
+	assert.Equal(t, "FOREST ZUBRA", result.PostalAddress.StreetName)
+	assert.Equal(t, "01-066", result.PostalAddress.PostCode)
+	assert.Equal(t, "PL", result.PostalAddress.Country)
+	assert.NoError(t, result.Validate())
+
+	// The requested clearing system
+	result, err = swiftService.GetFinancialInstitution("TPEOPLPWXXX", "ABA")
+	require.NoError(t, err)
+	require.NotNil(t, result.ClearingSystemMemberID)
+	assert.Equal(t, "USABA", result.ClearingSystemMemberID.ClearingSystemID.Code)
+	assert.Equal(t, "021000021", result.ClearingSystemMemberID.MemberID)
+
+	_, err = swiftService.GetFinancialInstitution("TPEOPLPWXXX", "XXCLR")
+	assert.ErrorIs(t, err, clearing.ErrUnknownSystem)
+
+	_, err = swiftService.GetFinancialInstitution("NONEXISTENT", "")
+	assert.ErrorIs(t, err, service.ErrBankNotFound)
+}
+
+// TestValidateISO20022 tests the ValidateISO20022 function
+func TestValidateISO20022(t *testing.T) {
+	cleanup(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 6*time.Second)
+	defer cancel()
+	require.NoError(t, repo.StatusChangesCollection().Drop(ctx))
+
+	err := swiftService.LoadInitialData(testDataFilePath)
+	assert.NoError(t, err)
+
+	_, _, err = swiftService.SetBankStatus("TPEOPLPWPAE", service.BankStatusRequest{Status: "retired", SuccessorSwiftCode: "TPEOPLPWXXX"}, time.Now())
+	require.NoError(t, err)
+
+	message := `<?xml version="1.0" encoding="UTF-8"?>
+<Document xmlns="urn:iso:std:iso:20022:tech:xsd:pain.001.001.09">
+  <CstmrCdtTrfInitn>
+    <GrpHdr><MsgId>MSG-1</MsgId></GrpHdr>
+    <PmtInf>
+      <PmtInfId>PMT-1</PmtInfId>
+      <DbtrAcct><Id><IBAN>PL61109010140000071219812874</IBAN></Id></DbtrAcct>
+      <DbtrAgt><FinInstnId><BICFI>TPEOPLPW</BICFI></FinInstnId></DbtrAgt>
+      <CdtTrfTxInf>
+        <PmtId><EndToEndId>E2E-1</EndToEndId></PmtId>
+        <CdtrAgt><FinInstnId><BICFI>TPEOPLPWP65</BICFI></FinInstnId></CdtrAgt>
+        <CdtrAcct><Id><IBAN>PL61109010140000071219812874</IBAN></Id></CdtrAcct>
+      </CdtTrfTxInf>
+      <CdtTrfTxInf>
+        <PmtId><EndToEndId>E2E-2</EndToEndId></PmtId>
+        <CdtrAgt><FinInstnId><BICFI>TPEOPLPWPAE</BICFI></FinInstnId></CdtrAgt>
+        <CdtrAcct><Id><IBAN>DE89370400440532013000</IBAN></Id></CdtrAcct>
+      </CdtTrfTxInf>
+      <CdtTrfTxInf>
+        <PmtId><EndToEndId>E2E-3</EndToEndId></PmtId>
+        <CdtrAgt><FinInstnId><BICFI>DEUTDEFF</BICFI></FinInstnId></CdtrAgt>
+      </CdtTrfTxInf>
+    </PmtInf>
+  </CstmrCdtTrfInitn>
+</Document>`
+
+	report, err := swiftService.ValidateISO20022(strings.NewReader(message))
+	require.NoError(t, err)
+	assert.Equal(t, "pain.001.001.09", report.MessageType)
+	assert.False(t, report.Valid)
+	assert.Equal(t, 3, report.TransactionCount)
+	assert.Equal(t, 2, report.InvalidTransactionCount)
+	require.Len(t, report.Transactions, 3)
+
+	// The debtor of the payment information is checked in every transaction
+	first := report.Transactions[0]
+	assert.True(t, first.Valid)
+	require.Len(t, first.Parties, 4)
+	assert.Equal(t, service.OutcomeValid, first.Parties[0].Outcome)
+	assert.Equal(t, service.OutcomeFound, first.Parties[1].Outcome)
+	assert.Equal(t, "TPEOPLPWXXX", first.Parties[1].SwiftCode)
+	assert.Equal(t, "PEKAO TFI S.A.", first.Parties[1].BankName)
+
+	second := report.Transactions[1]
+	assert.False(t, second.Valid)
+	assert.Equal(t, service.OutcomeRetired, second.Parties[2].Outcome)
+	assert.Equal(t, "TPEOPLPWXXX", second.Parties[2].SuccessorSwiftCode)
+	assert.Equal(t, service.OutcomeCountryMismatch, second.Parties[3].Outcome)
+
+	third := report.Transactions[2]
+	assert.False(t, third.Valid)
+	assert.Equal(t, service.OutcomeUnknown, third.Parties[2].Outcome)
+
+	_, err = swiftService.ValidateISO20022(strings.NewReader(`<Document xmlns="urn:iso:std:iso:20022:tech:xsd:camt.053.001.08"/>`))
+	assert.ErrorIs(t, err, iso20022.ErrUnsupportedMessage)
+}
+
+// TestValidateMT tests the ValidateMT function
+func TestValidateMT(t *testing.T) {
+	cleanup(t)
+
+	err := swiftService.LoadInitialData(testDataFilePath)
+	assert.NoError(t, err)
+
+	messages := "{1:F01TPEOPLPWAXXX0000000000}{2:I202TPEOPLPWXP65N}{4:\r\n" +
+		":20:REF-1\r\n" +
+		":21:REL-1\r\n" +
+		":32A:250102PLN1000,00\r\n" +
+		":56A:DEUTDEFF\r\n" +
+		":58A:/12345\r\n" +
+		"TPEOPLPWPFI\r\n" +
+		"-}\r\n" +
+		"{1:F01TPEOPLPWAXXX0000000000}{2:I202TPEOPLPWXXXXN}{4:\r\n" +
+		":20:REF-2\r\n" +
+		":58A:TPEO1122\r\n" +
+		"-}"
+
+	report, err := swiftService.ValidateMT(strings.NewReader(messages))
+	require.NoError(t, err)
+	assert.False(t, report.Valid)
+	assert.Equal(t, 2, report.MessageCount)
+	assert.Equal(t, 2, report.InvalidMessageCount)
+	require.Len(t, report.Messages, 2)
+
+	first := report.Messages[0]
+	assert.Equal(t, "202", first.MessageType)
+	assert.Equal(t, "REF-1", first.Reference)
+	assert.Empty(t, first.Issues)
+	require.Len(t, first.BICs, 4)
+	assert.Equal(t, "TPEOPLPWXXX", first.BICs[0].BIC)
+	assert.Equal(t, service.OutcomeFound, first.BICs[0].Outcome)
+	assert.Equal(t, "TPEOPLPWP65", first.BICs[1].BIC)
+	assert.Equal(t, service.OutcomeFound, first.BICs[1].Outcome)
+	assert.Equal(t, "56A", first.BICs[2].Tag)
+	assert.Equal(t, service.OutcomeUnknown, first.BICs[2].Outcome)
+	assert.Equal(t, "58A", first.BICs[3].Tag)
+	assert.Equal(t, service.OutcomeFound, first.BICs[3].Outcome)
+
+	// The missing fields and the malformed BIC
+	second := report.Messages[1]
+	assert.Equal(t, []string{"mandatory field 21 is missing", "mandatory field 32A is missing"}, second.Issues)
+	require.Len(t, second.BICs, 3)
+	assert.Equal(t, service.OutcomeInvalid, second.BICs[2].Outcome)
+
+	_, err = swiftService.ValidateMT(strings.NewReader("not a FIN message"))
+	assert.ErrorIs(t, err, mt.ErrMalformedMessage)
+}
+
+func TestEnrichCSV(t *testing.T) {
+	cleanup(t)
+
+	err := swiftService.LoadInitialData(testDataFilePath)
+	assert.NoError(t, err)
+
+	// The BOM of the spreadsheets is dropped, the BIC8 matches its primary office and the spaces grouping the characters are ignored
+	input := "\ufeffCustomer;SWIFT code\n" +
+		"Alpha;TPEOPLPW\n" +
+		"Beta;tpeo plpw p65\n" +
+		"Gamma;DEUTDEFFXXX\n" +
+		"Delta;TPEO1122\n" +
+		"Epsilon;\n"
+
+	var output strings.Builder
+	err = swiftService.EnrichCSV(strings.NewReader(input), &output, service.EnrichOptions{Column: "swift_code", Delimiter: ";"})
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSuffix(output.String(), "\n"), "\n")
+	require.Len(t, lines, 6)
+	assert.Equal(t, "Customer;SWIFT code;bankName;townName;countryISO2;countryName;status", lines[0])
+	assert.Equal(t, "Alpha;TPEOPLPW;PEKAO TFI S.A.;WARSZAWA;PL;POLAND;found", lines[1])
+	assert.Equal(t, "Beta;tpeo plpw p65;PEKAO TOWARZYSTWO FUNDUSZY  INWESTYCYJNYCH SPOLKA AKCYJNA;WARSZAWA;PL;POLAND;found", lines[2])
+	assert.Equal(t, "Gamma;DEUTDEFFXXX;;;;;unknown", lines[3])
+	assert.Equal(t, "Delta;TPEO1122;;;;;invalid", lines[4])
+	assert.Equal(t, "Epsilon;;;;;;missing", lines[5])
+
+	output.Reset()
+	err = swiftService.EnrichCSV(strings.NewReader("Customer,Account\nAlpha,1\n"), &output, service.EnrichOptions{})
+	assert.ErrorIs(t, err, service.ErrColumnNotFound)
+	assert.Empty(t, output.String())
+
+	err = swiftService.EnrichCSV(strings.NewReader(input), &output, service.EnrichOptions{Delimiter: "ab"})
+	assert.ErrorIs(t, err, service.ErrInvalidDelimiter)
+}
+
+func TestResolve(t *testing.T) {
+	cleanup(t)
+
+	err := swiftService.LoadInitialData(testDataFilePath)
+	assert.NoError(t, err)
+
+	// The legal form is written without the dots, the town is in English and the country is a name
+	response, err := swiftService.Resolve(service.ResolveRequest{
+		Name:    "Pekao TFI SA",
+		Town:    "Warsaw",
+		Country: "Poland",
+		Address: []string{"Forest Zubra 1"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, service.ResolveQuery{Name: "PEKAO TFI SA", Town: "WARSAW", CountryISO2: "PL", Address: "FOREST ZUBRA 1"}, response.Query)
+	require.NotEmpty(t, response.Candidates)
+
+	best := response.Candidates[0]
+	assert.Equal(t, "TPEOPLPWXXX", best.SwiftCode)
+	assert.Greater(t, best.Score, 0.9)
+	require.Len(t, best.Explanation, 3)
+	assert.Equal(t, "name", best.Explanation[0].Field)
+	assert.Equal(t, 1.0, best.Explanation[0].Score)
+	assert.Equal(t, names.ReasonAbbreviation, best.Explanation[0].Matches[2].Reason)
+	assert.Equal(t, "town", best.Explanation[1].Field)
+	assert.Equal(t, names.ReasonFuzzy, best.Explanation[1].Matches[0].Reason)
+	assert.Equal(t, "address", best.Explanation[2].Field)
+	assert.Equal(t, 1.0, best.Explanation[2].Score)
+	// TFI is the acronym of the names of the branches, the headquarter goes first among the equal scores
+	require.Len(t, response.Candidates, 4)
+	for _, candidate := range response.Candidates[1:] {
+		assert.Equal(t, best.Score, candidate.Score)
+		assert.False(t, candidate.IsHeadquarter)
+		assert.Equal(t, names.ReasonAcronym, candidate.Explanation[0].Matches[1].Reason)
+	}
+
+	// The spelled out name with the Polish letters, the limit keeps the headquarter and the first branch
+	response, err = swiftService.Resolve(service.ResolveRequest{Name: "Pekao Towarzystwo Funduszy Inwestycyjnych Spółka Akcyjna", Limit: 2})
+	require.NoError(t, err)
+	require.Len(t, response.Candidates, 2)
+	assert.Equal(t, "TPEOPLPWXXX", response.Candidates[0].SwiftCode)
+	assert.Equal(t, "TPEOPLPWP65", response.Candidates[1].SwiftCode)
+	assert.Equal(t, 1.0, response.Candidates[1].Score)
+	assert.Equal(t, names.ReasonTransliteration, response.Candidates[1].Explanation[0].Matches[4].Reason)
+
+	_, err = swiftService.Resolve(service.ResolveRequest{Name: "Pekao", Country: "Atlantis"})
+	assert.ErrorIs(t, err, service.ErrInvalidResolveRequest)
+	_, err = swiftService.Resolve(service.ResolveRequest{Name: " . "})
+	assert.ErrorIs(t, err, service.ErrInvalidResolveRequest)
+	_, err = swiftService.Resolve(service.ResolveRequest{Name: "Pekao", Limit: service.MaxResolveLimit + 1})
+	assert.ErrorIs(t, err, service.ErrInvalidLimit)
+}
+
+func TestSuggestSwiftCodes(t *testing.T) {
+	cleanup(t)
+
+	err := swiftService.LoadInitialData(testDataFilePath)
+	assert.NoError(t, err)
+
+	// Transposed characters
+	suggestions, err := swiftService.SuggestSwiftCodes("TPEOPLPWP56")
+	require.NoError(t, err)
+	require.NotEmpty(t, suggestions)
+	assert.Equal(t, service.SwiftCodeSuggestion{
+		SwiftCode:   "TPEOPLPWP65",
+		BankName:    "PEKAO TOWARZYSTWO FUNDUSZY  INWESTYCYJNYCH SPOLKA AKCYJNA",
+		CountryISO2: "PL",
+		Edits:       []string{suggest.EditTransposition},
+		Distance:    1,
+	}, suggestions[0])
+
+	// The look-alike characters come before the other edits
+	suggestions, err = swiftService.SuggestSwiftCodes("TPE0PLPWXXX")
+	require.NoError(t, err)
+	require.NotEmpty(t, suggestions)
+	assert.Equal(t, "TPEOPLPWXXX", suggestions[0].SwiftCode)
+	assert.Equal(t, []string{suggest.EditConfusion}, suggestions[0].Edits)
+
+	// The BIC8 of the primary office
+	suggestions, err = swiftService.SuggestSwiftCodes("tpeoplpw")
+	require.NoError(t, err)
+	require.NotEmpty(t, suggestions)
+	assert.Equal(t, "TPEOPLPWXXX", suggestions[0].SwiftCode)
+	assert.Equal(t, []string{suggest.EditPrimaryOffice}, suggestions[0].Edits)
+	assert.Equal(t, 0, suggestions[0].Distance)
+
+	suggestions, err = swiftService.SuggestSwiftCodes("DEUTDEFFXXX")
+	require.NoError(t, err)
+	assert.Empty(t, suggestions)
+}
+
+// TestSanctionsScreening tests the import of the sanctions lists and the screening of the directory
+func TestSanctionsScreening(t *testing.T) {
+	cleanup(t)
+	for _, collection := range []*mongo.Collection{repo.SanctionEntriesCollection(), repo.SanctionListsCollection(), repo.ScreeningHitsCollection()} {
+		require.NoError(t, collection.Drop(context.Background()))
+	}
+
+	err := swiftService.LoadInitialData(testDataFilePath)
+	assert.NoError(t, err)
+
+	dir := t.TempDir()
+	sdnFile := filepath.Join(dir, "sdn.csv")
+	sdn := `306,"ABLV BANK, AS","-0- ","UKRAINE-EO13662","-0- ","-0- ","-0- ","-0- ","-0- ","-0- ","-0- ","SWIFT/BIC AIZKLV22."` + "\n"
+	require.NoError(t, os.WriteFile(sdnFile, []byte(sdn), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "add.csv"), []byte(`306,1,"Elizabetes iela 23","Riga","Latvia",-0-`+"\n"), 0644))
+	euFile := filepath.Join(dir, "eu.xml")
+	eu := `<export xmlns="http://eu.europa.ec/fpi/fsd/export">
+  <sanctionEntity euReferenceNumber="EU.9999.1" logicalId="9999">
+    <regulation programme="TEST"/>
+    <subjectType code="enterprise"/>
+    <nameAlias wholeName="Pekao TFI S.A."/>
+    <address countryIso2Code="PL"/>
+  </sanctionEntity>
+</export>`
+	require.NoError(t, os.WriteFile(euFile, []byte(eu), 0644))
+
+	// The OFAC entry matches none of the banks yet
+	watchSanctionsLists(map[string]string{sanctions.ListOFAC: sdnFile})
+	report, err := swiftService.GetScreeningReport("", "", "")
+	require.NoError(t, err)
+	require.Len(t, report.Lists, 1)
+	assert.Equal(t, models.SanctionList{List: "ofac", Source: "sdn.csv", Entries: 1, LoadedAt: report.Lists[0].LoadedAt}, report.Lists[0])
+	assert.Equal(t, 0, report.Count)
+
+	// A new list screens the directory again
+	watchSanctionsLists(map[string]string{sanctions.ListEU: euFile})
+	report, err = swiftService.GetScreeningReport("eu", "pl", "name")
+	require.NoError(t, err)
+	assert.Equal(t, "PL", report.CountryISO2)
+	assert.Equal(t, 4, report.Banks)
+	require.Len(t, report.Hits, 4)
+	assert.Equal(t, "TPEOPLPWP65", report.Hits[0].SwiftCode)
+	assert.Equal(t, "Pekao TFI S.A.", report.Hits[0].EntryName)
+	assert.True(t, report.Hits[0].CountryMatch)
+
+	response, err := swiftService.GetBySwiftCode("TPEOPLPWXXX")
+	require.NoError(t, err)
+	require.Len(t, response.Screening, 1)
+	assert.Equal(t, "EU.9999.1", response.Screening[0].EntryID)
+	assert.Equal(t, sanctions.MatchName, response.Screening[0].MatchType)
+	assert.Equal(t, []string{"TEST"}, response.Screening[0].Programs)
+
+	// A bank added to the directory is screened on insert, and its hits go with it
+	err = swiftService.PostBankData(map[string]interface{}{
+		"countryISO2":   "LV",
+		"swiftCode":     "AIZKLV22XXX",
+		"codeType":      "BIC11",
+		"bankName":      "ABLV BANK, AS IN LIQUIDATION",
+		"address":       "MIHAILA TALA STREET 1  RIGA, RIGA, LV-1045",
+		"townName":      "RIGA",
+		"countryName":   "LATVIA",
+		"isHeadquarter": true,
+		"timeZone":      "Europe/Riga",
+	})
+	require.NoError(t, err)
+	report, err = swiftService.GetScreeningReport("ofac", "", "")
+	require.NoError(t, err)
+	require.Len(t, report.Hits, 1)
+	assert.Equal(t, "AIZKLV22XXX", report.Hits[0].SwiftCode)
+	assert.Equal(t, sanctions.MatchBIC, report.Hits[0].MatchType)
+	assert.True(t, report.Hits[0].CountryMatch)
+
+	require.NoError(t, swiftService.DeleteSwiftCode("AIZKLV22XXX"))
+	report, err = swiftService.GetScreeningReport("ofac", "", "")
+	require.NoError(t, err)
+	assert.Empty(t, report.Hits)
+
+	// A list imported again replaces its entries
+	require.NoError(t, os.WriteFile(euFile, []byte(`<export xmlns="http://eu.europa.ec/fpi/fsd/export"/>`), 0644))
+	watchSanctionsLists(map[string]string{sanctions.ListEU: euFile})
+	response, err = swiftService.GetBySwiftCode("TPEOPLPWXXX")
+	require.NoError(t, err)
+	assert.Empty(t, response.Screening)
+	entries, err := repo.FindSanctionEntries()
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, sanctions.ListOFAC, entries[0].List)
+
+	_, err = swiftService.GetScreeningReport("un", "", "")
+	assert.ErrorIs(t, err, service.ErrInvalidScreeningFilter)
+	_, err = swiftService.GetScreeningReport("", "", "country")
+	assert.ErrorIs(t, err, service.ErrInvalidScreeningFilter)
+
+	// An unknown list isn't imported
+	watchSanctionsLists(map[string]string{"un": euFile})
+	report, err = swiftService.GetScreeningReport("", "", "")
+	require.NoError(t, err)
+	assert.Len(t, report.Lists, 2)
+}
+
+// watchSanctionsLists imports the lists with a single check of the watcher, which screens the directory afterwards
+func watchSanctionsLists(files map[string]string) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	swiftService.RunSanctionsWatcher(ctx, time.Minute, files)
+}
@@ -127,7 +127,7 @@ if [ $? -ne 0 ] ; then
     printf "Request failed\n"
     NORESPONSE=$((NORESPONSE + 1))
 else
-    expected_response='{"address":"123 TEST BOULEVARD","bankName":"TEST FRENCH BANK","countryISO2":"FR","countryName":"FRANCE","isHeadquarter":false,"swiftCode":"TESTFR22FDF"}'
+    expected_response='{"address":"123 TEST BOULEVARD","structuredAddress":{"streetName":"TEST BOULEVARD","buildingNumber":"123","country":"FR","confidence":"low"},"bankName":"TEST FRENCH BANK","countryISO2":"FR","countryName":"FRANCE","countryDisplayName":"France","isHeadquarter":false,"swiftCode":"TESTFR22FDF","timeZone":"Europe/Paris","links":{"self":"/v1/swift-codes/TESTFR22FDF","headquarter":"/v1/swift-codes/TESTFR22XXX","country":"/v1/swift-codes/country/FR"}}'
     response=$(cat get_branch_response.json)
     if [ "$response" != "$expected_response" ]; then
         printf "Response does not match expected response\n"
@@ -150,7 +150,7 @@ if [ $? -ne 0 ] ; then
     printf "Request failed\n"
     NORESPONSE=$((NORESPONSE + 1))
 else
-    expected_response='{"address":"123 TEST BOULEVARD","bankName":"TEST FRENCH BANK","countryISO2":"FR","countryName":"FRANCE","isHeadquarter":true,"swiftCode":"TESTFR22XXX","branches":[{"address":"123 TEST BOULEVARD","bankName":"TEST FRENCH BANK","countryISO2":"FR","isHeadquarter":false,"swiftCode":"TESTFR22FDF"}]}'
+    expected_response='{"address":"123 TEST BOULEVARD","structuredAddress":{"streetName":"TEST BOULEVARD","buildingNumber":"123","country":"FR","confidence":"low"},"bankName":"TEST FRENCH BANK","countryISO2":"FR","countryName":"FRANCE","countryDisplayName":"France","isHeadquarter":true,"swiftCode":"TESTFR22XXX","timeZone":"Europe/Paris","branches":[{"address":"123 TEST BOULEVARD","bankName":"TEST FRENCH BANK","countryISO2":"FR","isHeadquarter":false,"links":{"self":"/v1/swift-codes/TESTFR22FDF","headquarter":"/v1/swift-codes/TESTFR22XXX","country":"/v1/swift-codes/country/FR"},"swiftCode":"TESTFR22FDF","timeZone":"Europe/Paris"}],"links":{"self":"/v1/swift-codes/TESTFR22XXX","branches":"/v1/swift-codes/TESTFR22XXX/branches","country":"/v1/swift-codes/country/FR"}}'
     response=$(cat get_headquarter_response.json)
     if [ "$response" != "$expected_response" ]; then
         printf "Response does not match expected response\n"